@@ -0,0 +1,245 @@
+// Package benchmarks rates a participant's stats against per-role, per-tier distributions
+// No dataset ships with the binary: one aggregated from real matches is loaded from BENCHMARKS_PATH,
+// and it must state where its numbers come from so every rating can be traced to it
+package benchmarks
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"lol-ranked-new-meta/ordinal"
+	"lol-ranked-new-meta/types"
+)
+
+// AllTiers is the dataset bucket covering every tier, used when the player's rank is unknown
+const AllTiers = "ALL"
+
+// Dataset holds percentile anchors per role, tier and metric, with the provenance of the numbers
+type Dataset struct {
+	Version     string                             `json:"version"`
+	Source      string                             `json:"source"`      // Where the games came from, e.g. "match-v5, EUW1 and NA1"
+	Query       string                             `json:"query"`       // How games were selected and aggregated
+	Patches     string                             `json:"patches"`     // Patch range of the games, e.g. "14.1-14.4"
+	QueueID     int                                `json:"queue_id"`    // Queue of the games; only matches from it are rated
+	Percentiles []int                              `json:"percentiles"` // Percentile of each anchor, ascending
+	Roles       map[string]map[string]Distribution `json:"roles"`       // position -> tier -> distribution
+}
+
+// Distribution is one role and tier cohort
+type Distribution struct {
+	Samples int                  `json:"samples"` // Player-games aggregated into the cohort
+	Metrics map[string][]float64 `json:"metrics"` // metric -> anchors
+}
+
+// metric describes how one rated stat is read from the derived metrics
+type metric struct {
+	key           string
+	label         string
+	lowerIsBetter bool
+	value         func(types.ParticipantMetrics) float64
+}
+
+// metrics lists the rated stats in display order
+var metrics = []metric{
+	{key: "cs_per_minute", label: "CS/min", value: func(pm types.ParticipantMetrics) float64 { return pm.CSPerMinute }},
+	{key: "vision_per_minute", label: "Vision/min", value: func(pm types.ParticipantMetrics) float64 { return pm.VisionPerMinute }},
+	{key: "damage_per_minute", label: "DPM", value: func(pm types.ParticipantMetrics) float64 { return pm.DamagePerMinute }},
+	{key: "kill_participation", label: "KP", value: func(pm types.ParticipantMetrics) float64 { return pm.KillParticipation }},
+	{key: "deaths_per_10", label: "Deaths/10min", lowerIsBetter: true, value: func(pm types.ParticipantMetrics) float64 { return pm.DeathsPer10 }},
+}
+
+// Load reads and validates the benchmark dataset at path
+func Load(path string) (*Dataset, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read benchmark dataset: %w", err)
+	}
+	return Parse(data)
+}
+
+// Parse decodes and validates a benchmark dataset
+// A dataset without its provenance (version, source, query, patches, queue and per-cohort sample counts) is rejected
+func Parse(data []byte) (*Dataset, error) {
+	var d Dataset
+	if err := json.Unmarshal(data, &d); err != nil {
+		return nil, fmt.Errorf("failed to parse benchmark dataset: %w", err)
+	}
+	switch {
+	case d.Version == "":
+		return nil, fmt.Errorf("benchmark dataset needs a version")
+	case d.Source == "" || d.Query == "" || d.Patches == "":
+		return nil, fmt.Errorf("benchmark dataset %q needs its source, query and patches", d.Version)
+	case d.QueueID <= 0:
+		return nil, fmt.Errorf("benchmark dataset %q needs the queue_id its games come from", d.Version)
+	case len(d.Percentiles) < 2:
+		return nil, fmt.Errorf("benchmark dataset %q needs at least two percentile anchors", d.Version)
+	}
+	for position, tiers := range d.Roles {
+		for tier, dist := range tiers {
+			if dist.Samples <= 0 {
+				return nil, fmt.Errorf("benchmark dataset %q: %s %s has no sample count", d.Version, position, tier)
+			}
+			for key, anchors := range dist.Metrics {
+				if len(anchors) != len(d.Percentiles) {
+					return nil, fmt.Errorf("benchmark dataset %q: %s %s %s has %d anchors, want %d",
+						d.Version, position, tier, key, len(anchors), len(d.Percentiles))
+				}
+			}
+		}
+	}
+	return &d, nil
+}
+
+// Rate places the participant's metrics within the distribution for their position and tier
+// An unknown tier falls back to AllTiers; returns nil when the dataset has no data for the position
+func (d *Dataset) Rate(position, tier string, pm types.ParticipantMetrics) *types.BenchmarkReport {
+	if d == nil {
+		return nil
+	}
+	tiers, ok := d.Roles[strings.ToUpper(position)]
+	if !ok {
+		return nil
+	}
+	tier = strings.ToUpper(tier)
+	dist, ok := tiers[tier]
+	if !ok {
+		tier = AllTiers
+		if dist, ok = tiers[tier]; !ok {
+			return nil
+		}
+	}
+
+	report := &types.BenchmarkReport{
+		Puuid:    pm.Puuid,
+		Position: strings.ToUpper(position),
+		Tier:     tier,
+		Version:  d.Version,
+		Source:   d.Source,
+		Patches:  d.Patches,
+		QueueID:  d.QueueID,
+		Samples:  dist.Samples,
+	}
+	for _, m := range metrics {
+		anchors, ok := dist.Metrics[m.key]
+		if !ok {
+			continue
+		}
+		value := m.value(pm)
+		report.Ratings = append(report.Ratings, types.BenchmarkRating{
+			Metric:        m.key,
+			Label:         m.label,
+			Value:         value,
+			Median:        d.median(anchors),
+			Percentile:    d.percentile(anchors, value, m.lowerIsBetter),
+			LowerIsBetter: m.lowerIsBetter,
+		})
+	}
+	return report
+}
+
+// median returns the 50th percentile anchor, interpolating when the dataset has none
+func (d *Dataset) median(anchors []float64) float64 {
+	for i, p := range d.Percentiles {
+		if p == 50 {
+			return anchors[i]
+		}
+	}
+	return (anchors[0] + anchors[len(anchors)-1]) / 2
+}
+
+// percentile interpolates linearly between anchors and extrapolates past the outer ones, clamped to 1-99
+// For lower-is-better stats the result is flipped so a higher percentile is always the better result
+func (d *Dataset) percentile(anchors []float64, value float64, lowerIsBetter bool) int {
+	n := len(anchors)
+	i := 1
+	for i < n-1 && value > anchors[i] {
+		i++
+	}
+	lo, hi := anchors[i-1], anchors[i]
+	plo, phi := float64(d.Percentiles[i-1]), float64(d.Percentiles[i])
+
+	p := phi
+	if hi != lo {
+		p = plo + (value-lo)/(hi-lo)*(phi-plo)
+	} else if value < lo {
+		p = plo
+	}
+	if lowerIsBetter {
+		p = 100 - p
+	}
+	return int(min(max(p+0.5, 1), 99))
+}
+
+// PositionLabel names a position the way players do, e.g. BOTTOM -> ADC
+func PositionLabel(position string) string {
+	switch strings.ToUpper(position) {
+	case types.PositionTop:
+		return "Top"
+	case types.PositionJungle:
+		return "Jungle"
+	case types.PositionMiddle:
+		return "Mid"
+	case types.PositionBottom:
+		return "ADC"
+	case types.PositionUtility:
+		return "Support"
+	}
+	return position
+}
+
+// Cohort names the players a report compares against, e.g. "Gold ADC" or "ADC (all tiers)"
+func Cohort(report *types.BenchmarkReport) string {
+	if report.Tier == AllTiers {
+		return PositionLabel(report.Position) + " (all tiers)"
+	}
+	tier := strings.ToLower(report.Tier)
+	return strings.ToUpper(tier[:1]) + tier[1:] + " " + PositionLabel(report.Position)
+}
+
+// Format lists a report's ratings for the prompt
+func Format(report *types.BenchmarkReport) string {
+	if report == nil || len(report.Ratings) == 0 {
+		return ""
+	}
+	var section string
+	for _, r := range report.Ratings {
+		line := fmt.Sprintf("- %s: %s, %s percentile (median %s)", r.Label, formatValue(r.Metric, r.Value),
+			ordinal.English(r.Percentile), formatValue(r.Metric, r.Median))
+		if r.LowerIsBetter {
+			line += " - lower is better, so a high percentile means a low value"
+		}
+		section += line + "\n"
+	}
+	return section
+}
+
+// StatPairs turns a report into KeyStatistics entries whose context reads e.g. "35th percentile for Gold ADC (median 6.9)"
+func StatPairs(report *types.BenchmarkReport) []types.StatPair {
+	if report == nil {
+		return nil
+	}
+	pairs := make([]types.StatPair, 0, len(report.Ratings))
+	for _, r := range report.Ratings {
+		pairs = append(pairs, types.StatPair{
+			Label:   r.Label,
+			Value:   formatValue(r.Metric, r.Value),
+			Context: fmt.Sprintf("%s percentile for %s (median %s)", ordinal.English(r.Percentile), Cohort(report), formatValue(r.Metric, r.Median)),
+		})
+	}
+	return pairs
+}
+
+// formatValue prints a metric with the precision players read it at
+func formatValue(key string, value float64) string {
+	switch key {
+	case "kill_participation":
+		return fmt.Sprintf("%.0f%%", value*100)
+	case "damage_per_minute":
+		return fmt.Sprintf("%.0f", value)
+	case "vision_per_minute":
+		return fmt.Sprintf("%.2f", value)
+	}
+	return fmt.Sprintf("%.1f", value)
+}
@@ -0,0 +1,123 @@
+package benchmarks_test
+
+import (
+	"strings"
+	"testing"
+
+	"lol-ranked-new-meta/benchmarks"
+	"lol-ranked-new-meta/types"
+)
+
+const testDataset = `{
+  "version": "test-1",
+  "source": "unit test",
+  "query": "fixed anchors",
+  "patches": "14.3",
+  "queue_id": 420,
+  "percentiles": [10, 25, 50, 75, 90],
+  "roles": {
+    "BOTTOM": {
+      "GOLD": {"samples": 1200, "metrics": {"cs_per_minute": [5, 6, 7, 8, 9], "deaths_per_10": [2, 3, 4, 5, 6]}},
+      "ALL": {"samples": 9000, "metrics": {"cs_per_minute": [4, 5, 6, 7, 8]}}
+    }
+  }
+}`
+
+func parseTestDataset(t *testing.T) *benchmarks.Dataset {
+	t.Helper()
+	d, err := benchmarks.Parse([]byte(testDataset))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	return d
+}
+
+func TestParse(t *testing.T) {
+	d := parseTestDataset(t)
+	if d.Version != "test-1" || d.QueueID != 420 || d.Roles["BOTTOM"]["GOLD"].Samples != 1200 {
+		t.Errorf("Parse = %+v", d)
+	}
+
+	tests := []struct {
+		name    string
+		replace [2]string
+		want    string
+	}{
+		{"no version", [2]string{`"version": "test-1"`, `"version": ""`}, "needs a version"},
+		{"no source", [2]string{`"source": "unit test"`, `"source": ""`}, "needs its source"},
+		{"no query", [2]string{`"query": "fixed anchors"`, `"query": ""`}, "needs its source"},
+		{"no queue", [2]string{`"queue_id": 420`, `"queue_id": 0`}, "queue_id"},
+		{"one anchor", [2]string{`"percentiles": [10, 25, 50, 75, 90]`, `"percentiles": [50]`}, "two percentile anchors"},
+		{"short anchors", [2]string{`[5, 6, 7, 8, 9]`, `[5, 6, 7]`}, "has 3 anchors, want 5"},
+		{"no samples", [2]string{`"samples": 1200, `, ``}, "no sample count"},
+		{"zero samples", [2]string{`"samples": 9000`, `"samples": 0`}, "no sample count"},
+		{"bad JSON", [2]string{`"roles": {`, `"roles": [`}, "failed to parse"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := strings.Replace(testDataset, tt.replace[0], tt.replace[1], 1)
+			_, err := benchmarks.Parse([]byte(data))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Parse error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestRate(t *testing.T) {
+	d := parseTestDataset(t)
+	pm := types.ParticipantMetrics{Puuid: "p1", CSPerMinute: 6.5, DeathsPer10: 3}
+
+	report := d.Rate("bottom", "gold", pm)
+	if report == nil {
+		t.Fatal("Rate returned nil for Gold BOTTOM")
+	}
+	if report.Position != "BOTTOM" || report.Tier != "GOLD" || report.Samples != 1200 || report.Version != "test-1" || report.Puuid != "p1" {
+		t.Errorf("report = %+v", report)
+	}
+	if len(report.Ratings) != 2 {
+		t.Fatalf("got %d ratings, want CS/min and deaths", len(report.Ratings))
+	}
+	cs, deaths := report.Ratings[0], report.Ratings[1]
+	if cs.Metric != "cs_per_minute" || cs.Median != 7 || cs.Percentile != 38 {
+		t.Errorf("CS/min rating = %+v, want median 7 and 38th percentile", cs)
+	}
+	// Three deaths per 10 minutes is the 25th percentile value, and fewer deaths rate higher
+	if deaths.Metric != "deaths_per_10" || !deaths.LowerIsBetter || deaths.Percentile != 75 {
+		t.Errorf("deaths rating = %+v, want the 75th percentile", deaths)
+	}
+
+	if got := d.Rate("BOTTOM", "DIAMOND", pm); got == nil || got.Tier != benchmarks.AllTiers || got.Samples != 9000 {
+		t.Errorf("unknown tier should fall back to ALL, got %+v", got)
+	}
+	if got := d.Rate("TOP", "GOLD", pm); got != nil {
+		t.Errorf("position without data should not be rated, got %+v", got)
+	}
+	var none *benchmarks.Dataset
+	if got := none.Rate("BOTTOM", "GOLD", pm); got != nil {
+		t.Errorf("nil dataset rated %+v", got)
+	}
+}
+
+func TestRatePercentileInterpolation(t *testing.T) {
+	d := parseTestDataset(t)
+	tests := []struct {
+		cs   float64
+		want int
+	}{
+		{7, 50},   // On an anchor
+		{6.5, 38}, // Halfway between the 25th and 50th
+		{5.4, 16}, // Between the 10th and 25th
+		{8.5, 83}, // Between the 75th and 90th
+		{9.5, 98}, // Extrapolated past the 90th
+		{20, 99},  // Clamped high
+		{4, 1},    // Extrapolated below the 10th and clamped
+		{0, 1},    // Clamped low
+	}
+	for _, tt := range tests {
+		report := d.Rate("BOTTOM", "GOLD", types.ParticipantMetrics{CSPerMinute: tt.cs})
+		if got := report.Ratings[0].Percentile; got != tt.want {
+			t.Errorf("CS/min %.1f: percentile %d, want %d", tt.cs, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,340 @@
+// Package coaching applies fixed, role-aware rules to match data to find a player's strengths and issues
+// It needs no LLM: its findings ground the model's analysis and replace it when the model is unavailable
+package coaching
+
+import (
+	"fmt"
+
+	"lol-ranked-new-meta/benchmarks"
+	"lol-ranked-new-meta/metrics"
+	"lol-ranked-new-meta/riot"
+	"lol-ranked-new-meta/types"
+)
+
+// minRatedMinutes skips rate-based rules for remakes and early surrenders, where per-minute numbers mean little
+const minRatedMinutes = 10
+
+// With a benchmark report, stats below issuePercentile of the player's role and tier are issues and above strengthPercentile strengths
+const (
+	issuePercentile    = 25
+	strengthPercentile = 75
+)
+
+// thresholds are the per-role lines between an issue, an unremarkable game and a strength
+// Deaths, vision and CS use them only without a benchmark rating; a zero value disables the rule for the role
+type thresholds struct {
+	maxDeathsPer10      float64 // Issue above
+	goodDeathsPer10     float64 // Strength below
+	minVisionPerMinute  float64 // Issue below
+	goodVisionPerMinute float64 // Strength above
+	minControlWards     int     // Issue below
+	minCSPerMinute      float64 // Issue below
+	goodCSPerMinute     float64 // Strength above
+	minObjectiveShare   float64 // Issue below: share of the team's dragons, heralds and barons the player took part in
+	goodObjectiveShare  float64 // Strength above
+}
+
+// roleThresholds holds the thresholds per resolved position; "" covers modes without lanes
+var roleThresholds = map[string]thresholds{
+	types.PositionTop: {
+		maxDeathsPer10: 3.0, goodDeathsPer10: 1.5,
+		minVisionPerMinute: 0.4, goodVisionPerMinute: 0.8, minControlWards: 1,
+		minCSPerMinute: 5.5, goodCSPerMinute: 7.5,
+		minObjectiveShare: 0.25, goodObjectiveShare: 0.6,
+	},
+	types.PositionJungle: {
+		maxDeathsPer10: 3.0, goodDeathsPer10: 1.5,
+		minVisionPerMinute: 0.6, goodVisionPerMinute: 1.1, minControlWards: 2,
+		minCSPerMinute: 4.8, goodCSPerMinute: 6.2,
+		minObjectiveShare: 0.5, goodObjectiveShare: 0.8,
+	},
+	types.PositionMiddle: {
+		maxDeathsPer10: 3.0, goodDeathsPer10: 1.5,
+		minVisionPerMinute: 0.45, goodVisionPerMinute: 0.85, minControlWards: 1,
+		minCSPerMinute: 6.0, goodCSPerMinute: 8.0,
+		minObjectiveShare: 0.35, goodObjectiveShare: 0.7,
+	},
+	types.PositionBottom: {
+		maxDeathsPer10: 3.0, goodDeathsPer10: 1.5,
+		minVisionPerMinute: 0.4, goodVisionPerMinute: 0.8, minControlWards: 1,
+		minCSPerMinute: 6.5, goodCSPerMinute: 8.5,
+		minObjectiveShare: 0.35, goodObjectiveShare: 0.7,
+	},
+	types.PositionUtility: {
+		maxDeathsPer10: 3.5, goodDeathsPer10: 1.8,
+		minVisionPerMinute: 1.4, goodVisionPerMinute: 2.4, minControlWards: 3,
+		minObjectiveShare: 0.4, goodObjectiveShare: 0.75,
+	},
+	"": {
+		maxDeathsPer10: 4.0, goodDeathsPer10: 2.0,
+	},
+}
+
+// Evaluate applies every rule to the target and returns the findings, issues first
+// roles is riot.ResolveRoles(match); report is optional - with it, findings also cite the target's benchmark percentile; the text is written in lang
+func Evaluate(match *types.RiotMatch, roles map[int]types.RoleAssignment, target *types.RiotParticipant, report *types.BenchmarkReport, lang riot.Language) []types.CoachingFinding {
+	if match == nil || target == nil {
+		return nil
+	}
+	pm, ok := metrics.ForParticipant(metrics.Compute(match), target.ParticipantID)
+	if !ok {
+		return nil
+	}
+	minutes := float64(match.Info.GameDuration) / 60.0
+	if minutes < minRatedMinutes {
+		return nil
+	}
+
+	position := ""
+	if target.PlayerSubteamID == 0 {
+		position = riot.ResolvedPosition(roles, target)
+	}
+	t := roleThresholds[position]
+	role := text(lang, roleLabel(position))
+
+	var issues, strengths []types.CoachingFinding
+	add := func(f types.CoachingFinding) {
+		if f.Severity == types.SeverityIssue {
+			issues = append(issues, f)
+		} else {
+			strengths = append(strengths, f)
+		}
+	}
+
+	deathsData := withBenchmark([]string{sprintf(lang, "Deaths: %d", target.Deaths), sprintf(lang, "Deaths per 10 min: %.1f", pm.DeathsPer10)}, report, "deaths_per_10", lang)
+	severity, basis, benchmarked := judge(report, "deaths_per_10", pm.DeathsPer10, t.maxDeathsPer10, t.goodDeathsPer10, true, role, lang)
+	switch severity {
+	case types.SeverityIssue:
+		add(types.CoachingFinding{
+			Rule: "deaths", Severity: types.SeverityIssue, Category: "combat",
+			Title:       text(lang, "Too Many Deaths"),
+			Description: sprintf(lang, "Died %d times (%.1f per 10 minutes), %s", target.Deaths, pm.DeathsPer10, basis),
+			Impact:      text(lang, "Every death hands the enemy gold and leaves your team a player short for the next objective"),
+			Data:        deathsData,
+			Benchmarked: benchmarked,
+			Suggestion:  text(lang, "Before stepping up, check where the enemy jungler and roamers were last seen; back when low instead of staying for one more wave"),
+			CoachingTip: text(lang, "Count the enemies you can see before a fight - if two or more are missing, play as if they are coming"),
+		})
+	case types.SeverityStrength:
+		add(types.CoachingFinding{
+			Rule: "deaths", Severity: types.SeverityStrength, Category: "combat",
+			Title:       text(lang, "Stayed Alive"),
+			Description: sprintf(lang, "Died only %d times (%.1f per 10 minutes), %s", target.Deaths, pm.DeathsPer10, basis),
+			Impact:      text(lang, "Few deaths kept the enemy's bounty gold low and kept you on the map for fights and objectives"),
+			Data:        deathsData,
+			Benchmarked: benchmarked,
+		})
+	}
+
+	if t.minVisionPerMinute > 0 {
+		visionData := withBenchmark([]string{sprintf(lang, "Vision Score: %d", target.VisionScore), sprintf(lang, "Wards Placed: %d, Wards Killed: %d", target.WardsPlaced, target.WardsKilled)}, report, "vision_per_minute", lang)
+		severity, basis, benchmarked := judge(report, "vision_per_minute", pm.VisionPerMinute, t.minVisionPerMinute, t.goodVisionPerMinute, false, role, lang)
+		switch severity {
+		case types.SeverityIssue:
+			add(types.CoachingFinding{
+				Rule: "vision", Severity: types.SeverityIssue, Category: "vision",
+				Title:       text(lang, "Low Vision Score"),
+				Description: sprintf(lang, "Vision score %d (%.2f per minute), %s", target.VisionScore, pm.VisionPerMinute, basis),
+				Impact:      text(lang, "Without vision the team walks into fights and objectives blind"),
+				Data:        visionData,
+				Benchmarked: benchmarked,
+				Suggestion:  text(lang, "Use your trinket on cooldown and ward the next objective about a minute before it spawns"),
+				CoachingTip: text(lang, "Every time you back, spend the first 75 gold on a control ward"),
+			})
+		case types.SeverityStrength:
+			add(types.CoachingFinding{
+				Rule: "vision", Severity: types.SeverityStrength, Category: "vision",
+				Title:       text(lang, "Strong Vision Control"),
+				Description: sprintf(lang, "Vision score %d (%.2f per minute), %s", target.VisionScore, pm.VisionPerMinute, basis),
+				Impact:      text(lang, "Good vision let the team see fights and objectives coming"),
+				Data:        visionData,
+				Benchmarked: benchmarked,
+			})
+		}
+	}
+
+	if t.minControlWards > 0 && target.VisionWardsBoughtInGame < t.minControlWards {
+		add(types.CoachingFinding{
+			Rule: "control_wards", Severity: types.SeverityIssue, Category: "vision",
+			Title:       text(lang, "Few Control Wards"),
+			Description: sprintf(lang, "Bought %d control %s in %.0f minutes; %s should buy at least %d", target.VisionWardsBoughtInGame, plural(target.VisionWardsBoughtInGame, "ward"), minutes, role, t.minControlWards),
+			Impact:      text(lang, "Control wards are the only way to deny enemy vision around objectives"),
+			Data:        []string{sprintf(lang, "Control Wards Purchased: %d", target.VisionWardsBoughtInGame), sprintf(lang, "Wards Killed: %d", target.WardsKilled)},
+			Suggestion:  text(lang, "Keep a control ward in your inventory at all times and place it before dragon and baron"),
+			CoachingTip: text(lang, "Treat the control ward as part of every back, like a potion"),
+		})
+	}
+
+	if t.minCSPerMinute > 0 {
+		cs := metrics.CreepScore(target)
+		csData := withBenchmark([]string{sprintf(lang, "CS: %d", cs), sprintf(lang, "CS per minute: %.1f", pm.CSPerMinute)}, report, "cs_per_minute", lang)
+		severity, basis, benchmarked := judge(report, "cs_per_minute", pm.CSPerMinute, t.minCSPerMinute, t.goodCSPerMinute, false, role, lang)
+		switch severity {
+		case types.SeverityIssue:
+			add(types.CoachingFinding{
+				Rule: "cs", Severity: types.SeverityIssue, Category: "farming",
+				Title:       text(lang, "Low CS"),
+				Description: sprintf(lang, "%d CS (%.1f per minute), %s", cs, pm.CSPerMinute, basis),
+				Impact:      text(lang, "Missed farm is gold and item timings lost without the enemy having to do anything"),
+				Data:        csData,
+				Benchmarked: benchmarked,
+				Suggestion:  text(lang, "Catch side waves between objectives instead of grouping mid with nothing to do"),
+				CoachingTip: text(lang, "Practise last hitting in a custom game for ten minutes before ranked sessions"),
+			})
+		case types.SeverityStrength:
+			add(types.CoachingFinding{
+				Rule: "cs", Severity: types.SeverityStrength, Category: "farming",
+				Title:       text(lang, "Strong Farming"),
+				Description: sprintf(lang, "%d CS (%.1f per minute), %s", cs, pm.CSPerMinute, basis),
+				Impact:      text(lang, "Steady farm kept item timings on track"),
+				Data:        csData,
+				Benchmarked: benchmarked,
+			})
+		}
+	}
+
+	if f, ok := objectiveFinding(match, target, t, role, lang); ok {
+		add(f)
+	}
+
+	return append(issues, strengths...)
+}
+
+// objectiveFinding rates the target's share of their team's dragons, heralds and barons
+// It needs challenge data for takedowns and at least two team objectives to judge
+func objectiveFinding(match *types.RiotMatch, target *types.RiotParticipant, t thresholds, role string, lang riot.Language) (types.CoachingFinding, bool) {
+	if t.minObjectiveShare == 0 {
+		return types.CoachingFinding{}, false
+	}
+	c, ok := target.TypedChallenges()
+	if !ok {
+		return types.CoachingFinding{}, false
+	}
+	teamObjectives := 0
+	for _, team := range match.Info.Teams {
+		if team.TeamID == target.TeamID {
+			teamObjectives = team.Objectives.Dragon.Kills + team.Objectives.RiftHerald.Kills + team.Objectives.Baron.Kills
+		}
+	}
+	if teamObjectives < 2 {
+		return types.CoachingFinding{}, false
+	}
+
+	takedowns := c.DragonTakedowns + c.RiftHeraldTakedowns + c.BaronTakedowns
+	share := float64(takedowns) / float64(teamObjectives)
+	data := []string{
+		sprintf(lang, "Objective Takedowns: %d of the team's %d", takedowns, teamObjectives),
+		sprintf(lang, "Dragons: %d, Heralds: %d, Barons: %d", c.DragonTakedowns, c.RiftHeraldTakedowns, c.BaronTakedowns),
+	}
+	switch {
+	case share < t.minObjectiveShare:
+		return types.CoachingFinding{
+			Rule: "objectives", Severity: types.SeverityIssue, Category: "objective",
+			Title:       text(lang, "Missing From Objectives"),
+			Description: sprintf(lang, "Took part in %d of the team's %d dragons, heralds and barons (%.0f%%), below the %.0f%% expected from %s", takedowns, teamObjectives, share*100, t.minObjectiveShare*100, role),
+			Impact:      text(lang, "Objectives decide games; fighting them a player short risks a steal or a lost fight"),
+			Data:        data,
+			Suggestion:  text(lang, "Shove your wave and move to the objective about 45 seconds before it spawns"),
+			CoachingTip: text(lang, "Check the objective timers every time you back and plan your next reset around them"),
+		}, true
+	case share > t.goodObjectiveShare:
+		return types.CoachingFinding{
+			Rule: "objectives", Severity: types.SeverityStrength, Category: "objective",
+			Title:       text(lang, "Present for Objectives"),
+			Description: sprintf(lang, "Took part in %d of the team's %d dragons, heralds and barons (%.0f%%)", takedowns, teamObjectives, share*100),
+			Impact:      text(lang, "Showing up for objectives turned the team's leads into map control"),
+			Data:        data,
+		}, true
+	}
+	return types.CoachingFinding{}, false
+}
+
+// judge rates a metric as an issue, a strength or neither ("") and describes the line it crossed
+// With a benchmark rating for the metric the lines are the cohort's 25th and 75th percentiles, so a finding never
+// contradicts the percentile reported next to it, and benchmarked is true; without one the fixed role thresholds apply, which ignore rank
+func judge(report *types.BenchmarkReport, metric string, value, issueLine, strengthLine float64, lowerIsBetter bool, role string, lang riot.Language) (severity, basis string, benchmarked bool) {
+	if rating, context, ok := benchmarkRating(report, metric, lang); ok {
+		switch {
+		case rating.Percentile < issuePercentile:
+			return types.SeverityIssue, context, true
+		case rating.Percentile > strengthPercentile:
+			return types.SeverityStrength, context, true
+		}
+		return "", "", true
+	}
+
+	worse, better := value < issueLine, value > strengthLine
+	if lowerIsBetter {
+		worse, better = value > issueLine, value < strengthLine
+	}
+	switch {
+	case worse && lowerIsBetter:
+		return types.SeverityIssue, sprintf(lang, "above the %s %s should stay under (a general target, not adjusted for rank)", formatLine(metric, issueLine), role), false
+	case worse:
+		return types.SeverityIssue, sprintf(lang, "below the %s %s should reach (a general target, not adjusted for rank)", formatLine(metric, issueLine), role), false
+	case better && lowerIsBetter:
+		return types.SeverityStrength, sprintf(lang, "under the %s %s usually stay below", formatLine(metric, strengthLine), role), false
+	case better:
+		return types.SeverityStrength, sprintf(lang, "above the %s %s usually reach", formatLine(metric, strengthLine), role), false
+	}
+	return "", "", false
+}
+
+// formatLine prints a threshold at the precision its metric is shown with
+func formatLine(metric string, value float64) string {
+	if metric == "vision_per_minute" {
+		return fmt.Sprintf("%.2f", value)
+	}
+	return fmt.Sprintf("%.1f", value)
+}
+
+// benchmarkRating returns the report's rating for metric with its context, e.g. "35th percentile for Gold ADC (median 6.9)"
+// Only a report from a cohort with recorded samples is used. The cohort keeps the dataset's tier and role names in every language
+func benchmarkRating(report *types.BenchmarkReport, metric string, lang riot.Language) (types.BenchmarkRating, string, bool) {
+	if report == nil || report.Samples <= 0 {
+		return types.BenchmarkRating{}, "", false
+	}
+	pairs := benchmarks.StatPairs(report)
+	for i, r := range report.Ratings {
+		if r.Metric != metric {
+			continue
+		}
+		if format, ok := messages[lang.Code][percentileContext]; ok {
+			return r, fmt.Sprintf(format, r.Percentile, benchmarks.Cohort(report), formatLine(metric, r.Median)), true
+		}
+		return r, pairs[i].Context, true
+	}
+	return types.BenchmarkRating{}, "", false
+}
+
+// withBenchmark appends the benchmark rating for the metric to the data lines when the report has one
+func withBenchmark(data []string, report *types.BenchmarkReport, metric string, lang riot.Language) []string {
+	if rating, context, ok := benchmarkRating(report, metric, lang); ok {
+		return append(data, fmt.Sprintf("%s: %s", rating.Label, context))
+	}
+	return data
+}
+
+// roleLabel names the players of a position for rule descriptions in English, e.g. "supports"
+func roleLabel(position string) string {
+	switch position {
+	case types.PositionTop:
+		return "top laners"
+	case types.PositionJungle:
+		return "junglers"
+	case types.PositionMiddle:
+		return "mid laners"
+	case types.PositionBottom:
+		return "ADCs"
+	case types.PositionUtility:
+		return "supports"
+	}
+	return "players"
+}
+
+func plural(n int, word string) string {
+	if n == 1 {
+		return word
+	}
+	return word + "s"
+}
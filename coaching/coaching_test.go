@@ -0,0 +1,119 @@
+package coaching_test
+
+import (
+	"strings"
+	"testing"
+
+	"lol-ranked-new-meta/coaching"
+	"lol-ranked-new-meta/riot"
+	"lol-ranked-new-meta/riottest"
+	"lol-ranked-new-meta/types"
+)
+
+// rules lists the rule and severity of each finding, e.g. "objectives:issue"
+func rules(findings []types.CoachingFinding) []string {
+	var out []string
+	for _, f := range findings {
+		out = append(out, f.Rule+":"+f.Severity)
+	}
+	return out
+}
+
+func TestEvaluateThresholds(t *testing.T) {
+	match := riottest.FixtureMatch()
+	roles := riot.ResolveRoles(match)
+
+	tests := []struct {
+		participant int
+		want        string
+	}{
+		{1, "objectives:issue deaths:strength"},                                    // Aatrox top: 1 of 5 objectives, 1.0 deaths per 10
+		{2, "control_wards:issue deaths:strength cs:strength objectives:strength"}, // Lee Sin jungle
+		{5, "deaths:strength objectives:strength"},                                 // Thresh support: no CS rule
+		{6, "vision:issue"}, // Darius top: 0.39 vision per minute
+		{8, ""},             // Syndra mid: nothing crosses a line
+	}
+	for _, tt := range tests {
+		target := &match.Info.Participants[tt.participant-1]
+		findings := coaching.Evaluate(match, roles, target, nil, riot.English)
+		if got := strings.Join(rules(findings), " "); got != tt.want {
+			t.Errorf("participant %d: findings %q, want %q", tt.participant, got, tt.want)
+		}
+		for _, f := range findings {
+			if f.Benchmarked {
+				t.Errorf("participant %d: %s finding is marked benchmarked without a report", tt.participant, f.Rule)
+			}
+			if f.Severity == types.SeverityIssue && (f.Suggestion == "" || f.CoachingTip == "") {
+				t.Errorf("participant %d: %s issue has no suggestion or tip", tt.participant, f.Rule)
+			}
+		}
+	}
+
+	findings := coaching.Evaluate(match, roles, &match.Info.Participants[5], nil, riot.English)
+	if want := "below the 0.40 top laners should reach (a general target, not adjusted for rank)"; !strings.Contains(findings[0].Description, want) {
+		t.Errorf("vision description = %q, want the fixed threshold %q", findings[0].Description, want)
+	}
+}
+
+func TestEvaluateBenchmarks(t *testing.T) {
+	match := riottest.FixtureMatch()
+	roles := riot.ResolveRoles(match)
+	target := &match.Info.Participants[0]
+	report := &types.BenchmarkReport{
+		Puuid: target.Puuid, Position: types.PositionTop, Tier: "GOLD", Version: "test-1", QueueID: 420, Samples: 1200,
+		Ratings: []types.BenchmarkRating{
+			{Metric: "cs_per_minute", Label: "CS/min", Value: 7.2, Median: 7.9, Percentile: 12},
+			{Metric: "deaths_per_10", Label: "Deaths/10min", Value: 1.0, Median: 1.1, Percentile: 55, LowerIsBetter: true},
+		},
+	}
+
+	findings := coaching.Evaluate(match, roles, target, report, riot.English)
+	// CS is judged against the cohort: 12th percentile is an issue though 7.2 CS/min clears the fixed top lane line,
+	// and deaths at the 55th percentile are unremarkable though 1.0 per 10 minutes beats the fixed strength line
+	if got := strings.Join(rules(findings), " "); got != "cs:issue objectives:issue" {
+		t.Fatalf("findings %q, want the CS issue from the benchmark and the objective issue", got)
+	}
+	cs, objectives := findings[0], findings[1]
+	if !cs.Benchmarked || !strings.Contains(cs.Description, "12th percentile for Gold Top (median 7.9)") {
+		t.Errorf("CS finding = %+v, want it benchmarked against Gold Top", cs)
+	}
+	if objectives.Benchmarked {
+		t.Error("the objective rule has no benchmark but is marked benchmarked")
+	}
+
+	// A report without recorded samples is not trusted: the fixed thresholds apply again
+	report.Samples = 0
+	findings = coaching.Evaluate(match, roles, target, report, riot.English)
+	if got := strings.Join(rules(findings), " "); got != "objectives:issue deaths:strength" {
+		t.Errorf("findings without samples %q, want the threshold findings", got)
+	}
+	for _, f := range findings {
+		if f.Benchmarked || strings.Contains(strings.Join(f.Data, ";"), "percentile") {
+			t.Errorf("%s finding cites a report without samples: %+v", f.Rule, f)
+		}
+	}
+}
+
+func TestEvaluateSkipsShortGamesAndMissingTargets(t *testing.T) {
+	match := riottest.FixtureMatch()
+	roles := riot.ResolveRoles(match)
+	if findings := coaching.Evaluate(match, roles, nil, nil, riot.English); findings != nil {
+		t.Errorf("no target: got %d findings", len(findings))
+	}
+	match.Info.GameDuration = 8 * 60
+	if findings := coaching.Evaluate(match, roles, &match.Info.Participants[0], nil, riot.English); findings != nil {
+		t.Errorf("8 minute game: got %d findings, want none", len(findings))
+	}
+}
+
+func TestEvaluateLanguage(t *testing.T) {
+	german, ok := riot.ParseLanguage("de")
+	if !ok {
+		t.Fatal("de is not a known language")
+	}
+	match := riottest.FixtureMatch()
+	findings := coaching.Evaluate(match, riot.ResolveRoles(match), &match.Info.Participants[5], nil, german)
+	if len(findings) != 1 || findings[0].Title != "Niedriger Sichtwert" || !strings.Contains(findings[0].Description, "Toplaner") {
+		t.Errorf("German vision finding = %+v", findings)
+	}
+}
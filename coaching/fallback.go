@@ -0,0 +1,89 @@
+package coaching
+
+import (
+	"fmt"
+	"strings"
+
+	"lol-ranked-new-meta/metrics"
+	"lol-ranked-new-meta/riot"
+	"lol-ranked-new-meta/types"
+)
+
+// Fallback builds a complete analysis from the rule findings, for when the LLM is unavailable
+// The response has no deep dive or critical moments; those need the model. Its text is written in lang
+func Fallback(match *types.RiotMatch, target *types.RiotParticipant, findings []types.CoachingFinding, lang riot.Language) *types.MatchResponse {
+	response := &types.MatchResponse{
+		AnalysisSource: types.AnalysisSourceRules,
+		RuleFindings:   findings,
+		StructuredInsights: &types.StructuredInsights{
+			WhatWentWell:  []types.SpecificEvent{},
+			WhatWentWrong: []types.SpecificEvent{},
+		},
+	}
+	if match == nil || target == nil {
+		response.Analysis = text(lang, "The AI coach is unavailable and no player could be selected for a rule-based review.")
+		return response
+	}
+
+	var issues, strengths []string
+	for _, f := range findings {
+		event := types.SpecificEvent{
+			Title:       f.Title,
+			Description: f.Description,
+			Impact:      f.Impact,
+			Data:        f.Data,
+			Category:    f.Category,
+		}
+		if f.Severity == types.SeverityIssue {
+			response.StructuredInsights.WhatWentWrong = append(response.StructuredInsights.WhatWentWrong, event)
+			response.Suggestions = append(response.Suggestions, f.Suggestion)
+			response.CoachingTips = append(response.CoachingTips, f.CoachingTip)
+			issues = append(issues, f.Title)
+		} else {
+			response.StructuredInsights.WhatWentWell = append(response.StructuredInsights.WhatWentWell, event)
+			strengths = append(strengths, f.Title)
+		}
+	}
+	if len(response.Suggestions) == 0 {
+		response.Suggestions = []string{text(lang, "No rule flagged an issue this game; review the replay for positioning and decisions, which the rules cannot judge")}
+	}
+
+	minutes := float64(match.Info.GameDuration) / 60.0
+	result := text(lang, "Defeat")
+	if target.Win {
+		result = text(lang, "Victory")
+	}
+	cs := metrics.CreepScore(target)
+	analysis := sprintf(lang, "The AI coach is unavailable, so this is a rule-based review of the match data. %s played %s: %s in %.0f minutes, %d/%d/%d, %d CS and a vision score of %d.",
+		target.SummonerName, target.ChampionName, result, minutes, target.Kills, target.Deaths, target.Assists, cs, target.VisionScore)
+	if len(issues) > 0 {
+		analysis += sprintf(lang, " Issues: %s.", strings.Join(issues, ", "))
+	}
+	if len(strengths) > 0 {
+		analysis += sprintf(lang, " Strengths: %s.", strings.Join(strengths, ", "))
+	}
+	if len(findings) == 0 {
+		analysis += text(lang, " No rule applies to this game, so there are no findings.")
+	}
+	response.Analysis = analysis
+
+	response.StructuredInsights.KeyStatistics = types.KeyStatistics{
+		Combat: []types.StatPair{
+			{Label: "K/D/A", Value: fmt.Sprintf("%d/%d/%d", target.Kills, target.Deaths, target.Assists)},
+			{Label: text(lang, "Damage to Champions"), Value: fmt.Sprintf("%d", target.TotalDamageDealtToChampions)},
+		},
+		Objectives: []types.StatPair{
+			{Label: text(lang, "Turret Kills"), Value: fmt.Sprintf("%d", target.TurretKills)},
+			{Label: text(lang, "Dragon Kills"), Value: fmt.Sprintf("%d", target.DragonKills)},
+		},
+		Economy: []types.StatPair{
+			{Label: text(lang, "Gold Earned"), Value: fmt.Sprintf("%d", target.GoldEarned)},
+			{Label: "CS", Value: fmt.Sprintf("%d", cs)},
+		},
+		Vision: []types.StatPair{
+			{Label: text(lang, "Vision Score"), Value: fmt.Sprintf("%d", target.VisionScore)},
+			{Label: text(lang, "Control Wards Purchased"), Value: fmt.Sprintf("%d", target.VisionWardsBoughtInGame)},
+		},
+	}
+	return response
+}
@@ -0,0 +1,61 @@
+package coaching_test
+
+import (
+	"strings"
+	"testing"
+
+	"lol-ranked-new-meta/coaching"
+	"lol-ranked-new-meta/riot"
+	"lol-ranked-new-meta/riottest"
+	"lol-ranked-new-meta/types"
+)
+
+func TestFallback(t *testing.T) {
+	match := riottest.FixtureMatch()
+	target := &match.Info.Participants[0]
+	findings := coaching.Evaluate(match, riot.ResolveRoles(match), target, nil, riot.English)
+
+	response := coaching.Fallback(match, target, findings, riot.English)
+	if response.AnalysisSource != types.AnalysisSourceRules || len(response.RuleFindings) != len(findings) {
+		t.Errorf("source %q with %d findings, want rules with %d", response.AnalysisSource, len(response.RuleFindings), len(findings))
+	}
+	insights := response.StructuredInsights
+	if len(insights.WhatWentWrong) != 1 || insights.WhatWentWrong[0].Title != "Missing From Objectives" {
+		t.Errorf("what went wrong = %+v, want the objective issue", insights.WhatWentWrong)
+	}
+	if len(insights.WhatWentWell) != 1 || insights.WhatWentWell[0].Title != "Stayed Alive" {
+		t.Errorf("what went well = %+v, want the deaths strength", insights.WhatWentWell)
+	}
+	if len(response.Suggestions) != 1 || response.Suggestions[0] != findings[0].Suggestion || len(response.CoachingTips) != 1 {
+		t.Errorf("suggestions %q and tips %q should come from the one issue", response.Suggestions, response.CoachingTips)
+	}
+	for _, want := range []string{"rule-based review", "Aatrox", "Victory", "6/3/7", "Issues: Missing From Objectives.", "Strengths: Stayed Alive."} {
+		if !strings.Contains(response.Analysis, want) {
+			t.Errorf("analysis is missing %q:\n%s", want, response.Analysis)
+		}
+	}
+	if got := insights.KeyStatistics.Economy[1]; got.Label != "CS" || got.Value != "222" {
+		t.Errorf("CS stat = %+v, want minions plus monsters", got)
+	}
+}
+
+func TestFallbackWithoutFindings(t *testing.T) {
+	match := riottest.FixtureMatch()
+	target := &match.Info.Participants[7]
+
+	response := coaching.Fallback(match, target, nil, riot.English)
+	if len(response.Suggestions) != 1 || !strings.Contains(response.Suggestions[0], "No rule flagged an issue") {
+		t.Errorf("suggestions = %q, want the no-issue note", response.Suggestions)
+	}
+	if !strings.Contains(response.Analysis, "No rule applies to this game") || strings.Contains(response.Analysis, "Issues:") {
+		t.Errorf("analysis = %q", response.Analysis)
+	}
+	if response.StructuredInsights.WhatWentWrong == nil || response.StructuredInsights.WhatWentWell == nil {
+		t.Error("empty insight lists should encode as [], not null")
+	}
+
+	response = coaching.Fallback(match, nil, nil, riot.English)
+	if !strings.Contains(response.Analysis, "no player could be selected") || response.AnalysisSource != types.AnalysisSourceRules {
+		t.Errorf("no target: %+v", response)
+	}
+}
@@ -0,0 +1,266 @@
+package coaching
+
+import (
+	"fmt"
+
+	"lol-ranked-new-meta/riot"
+)
+
+// percentileContext is the benchmark context in languages other than English, from the percentile, cohort and median
+// English keeps the benchmarks package's own wording ("35th percentile for Gold ADC (median 6.9)")
+const percentileContext = "%d percentile for %s (median %s)"
+
+// messages translates the text of findings and the fallback analysis, keyed by language code and English text
+// Format strings keep their verbs; translations reorder arguments with explicit indexes (%[2]d) where needed
+var messages = map[string]map[string]string{
+	"de": {
+		percentileContext: "%d. Perzentil für %s (Median %s)",
+		"top laners":      "Toplaner",
+		"junglers":        "Jungler",
+		"mid laners":      "Midlaner",
+		"ADCs":            "ADCs",
+		"supports":        "Supporter",
+		"players":         "Spieler",
+
+		"above the %s %s should stay under (a general target, not adjusted for rank)": "über der Marke von %s, unter der %s bleiben sollten (allgemeiner Richtwert, nicht an den Rang angepasst)",
+		"below the %s %s should reach (a general target, not adjusted for rank)":      "unter der Marke von %s, die %s erreichen sollten (allgemeiner Richtwert, nicht an den Rang angepasst)",
+		"under the %s %s usually stay below":                                          "unter der Marke von %s, unter der %s meist bleiben",
+		"above the %s %s usually reach":                                               "über der Marke von %s, die %s meist erreichen",
+
+		"Deaths: %d":                              "Tode: %d",
+		"Deaths per 10 min: %.1f":                 "Tode pro 10 Min.: %.1f",
+		"Too Many Deaths":                         "Zu viele Tode",
+		"Died %d times (%.1f per 10 minutes), %s": "%d-mal gestorben (%.1f pro 10 Minuten), %s",
+		"Every death hands the enemy gold and leaves your team a player short for the next objective":                                      "Jeder Tod gibt dem Gegner Gold und lässt dein Team beim nächsten Objective in Unterzahl",
+		"Before stepping up, check where the enemy jungler and roamers were last seen; back when low instead of staying for one more wave": "Prüfe vor jedem Vorstoß, wo der gegnerische Jungler und roamende Gegner zuletzt gesehen wurden; geh mit wenig Leben zurück, statt noch eine Welle zu bleiben",
+		"Count the enemies you can see before a fight - if two or more are missing, play as if they are coming":                            "Zähle vor einem Kampf die sichtbaren Gegner - fehlen zwei oder mehr, spiel so, als wären sie unterwegs",
+		"Stayed Alive": "Am Leben geblieben",
+		"Died only %d times (%.1f per 10 minutes), %s":                                                  "Nur %d-mal gestorben (%.1f pro 10 Minuten), %s",
+		"Few deaths kept the enemy's bounty gold low and kept you on the map for fights and objectives": "Wenige Tode hielten das Kopfgeld für den Gegner niedrig und dich für Kämpfe und Objectives auf der Karte",
+
+		"Vision Score: %d":                                               "Sichtwert: %d",
+		"Wards Placed: %d, Wards Killed: %d":                             "Platzierte Wards: %d, zerstörte Wards: %d",
+		"Low Vision Score":                                               "Niedriger Sichtwert",
+		"Vision score %d (%.2f per minute), %s":                          "Sichtwert %d (%.2f pro Minute), %s",
+		"Without vision the team walks into fights and objectives blind": "Ohne Sicht läuft das Team blind in Kämpfe und Objectives",
+		"Use your trinket on cooldown and ward the next objective about a minute before it spawns": "Nutze dein Schmuckstück, sobald es bereit ist, und warde das nächste Objective etwa eine Minute vor dem Erscheinen",
+		"Every time you back, spend the first 75 gold on a control ward":                           "Gib bei jedem Recall die ersten 75 Gold für ein Kontrollward aus",
+		"Strong Vision Control": "Starke Sichtkontrolle",
+		"Good vision let the team see fights and objectives coming": "Gute Sicht ließ das Team Kämpfe und Objectives kommen sehen",
+
+		"Few Control Wards": "Wenige Kontrollwards",
+		"Bought %d control %s in %.0f minutes; %s should buy at least %d":                         "Hat in %[3].0f Minuten %[1]d Kontrollwards gekauft; %[4]s sollten mindestens %[5]d kaufen",
+		"Control wards are the only way to deny enemy vision around objectives":                   "Kontrollwards sind die einzige Möglichkeit, dem Gegner die Sicht um Objectives zu nehmen",
+		"Control Wards Purchased: %d":                                                             "Gekaufte Kontrollwards: %d",
+		"Wards Killed: %d":                                                                        "Zerstörte Wards: %d",
+		"Keep a control ward in your inventory at all times and place it before dragon and baron": "Trag immer ein Kontrollward im Inventar und platziere es vor Drache und Baron",
+		"Treat the control ward as part of every back, like a potion":                             "Behandle das Kontrollward wie einen Trank als festen Teil jedes Recalls",
+
+		"CS: %d":                      "CS: %d",
+		"CS per minute: %.1f":         "CS pro Minute: %.1f",
+		"Low CS":                      "Wenig CS",
+		"%d CS (%.1f per minute), %s": "%d CS (%.1f pro Minute), %s",
+		"Missed farm is gold and item timings lost without the enemy having to do anything": "Verpasster Farm kostet Gold und Item-Timings, ohne dass der Gegner etwas tun muss",
+		"Catch side waves between objectives instead of grouping mid with nothing to do":    "Farme zwischen Objectives die Seitenwellen, statt ohne Aufgabe in der Mitte zu stehen",
+		"Practise last hitting in a custom game for ten minutes before ranked sessions":     "Übe vor Ranglisten-Sessions zehn Minuten Last Hits in einem benutzerdefinierten Spiel",
+		"Strong Farming":                         "Starker Farm",
+		"Steady farm kept item timings on track": "Stetiger Farm hielt die Item-Timings im Plan",
+
+		"Objective Takedowns: %d of the team's %d": "Objective-Beteiligungen: %d von %d des Teams",
+		"Dragons: %d, Heralds: %d, Barons: %d":     "Drachen: %d, Herolde: %d, Barone: %d",
+		"Missing From Objectives":                  "Bei Objectives abwesend",
+		"Took part in %d of the team's %d dragons, heralds and barons (%.0f%%), below the %.0f%% expected from %s": "An %d der %d Drachen, Herolde und Barone des Teams beteiligt (%.0f%%), unter den %.0f%%, die von %s erwartet werden",
+		"Objectives decide games; fighting them a player short risks a steal or a lost fight":                      "Objectives entscheiden Spiele; wer sie in Unterzahl spielt, riskiert einen Steal oder einen verlorenen Kampf",
+		"Shove your wave and move to the objective about 45 seconds before it spawns":                              "Drück deine Welle und geh etwa 45 Sekunden vor dem Erscheinen zum Objective",
+		"Check the objective timers every time you back and plan your next reset around them":                      "Prüfe bei jedem Recall die Objective-Timer und plane deinen nächsten Reset danach",
+		"Present for Objectives": "Bei Objectives präsent",
+		"Took part in %d of the team's %d dragons, heralds and barons (%.0f%%)": "An %d der %d Drachen, Herolde und Barone des Teams beteiligt (%.0f%%)",
+		"Showing up for objectives turned the team's leads into map control":    "Die Präsenz bei Objectives machte die Führung des Teams zu Kartenkontrolle",
+
+		"The AI coach is unavailable and no player could be selected for a rule-based review.":                              "Der KI-Coach ist nicht verfügbar, und für eine regelbasierte Auswertung konnte kein Spieler ausgewählt werden.",
+		"No rule flagged an issue this game; review the replay for positioning and decisions, which the rules cannot judge": "Keine Regel hat in diesem Spiel ein Problem gefunden; prüfe im Replay Positionierung und Entscheidungen, die die Regeln nicht beurteilen können",
+		"Victory": "Sieg",
+		"Defeat":  "Niederlage",
+		"The AI coach is unavailable, so this is a rule-based review of the match data. %s played %s: %s in %.0f minutes, %d/%d/%d, %d CS and a vision score of %d.": "Der KI-Coach ist nicht verfügbar, daher ist dies eine regelbasierte Auswertung der Matchdaten. %s spielte %s: %s nach %.0f Minuten, %d/%d/%d, %d CS und ein Sichtwert von %d.",
+		" Issues: %s.":    " Probleme: %s.",
+		" Strengths: %s.": " Stärken: %s.",
+		" No rule applies to this game, so there are no findings.": " Keine Regel trifft auf dieses Spiel zu, daher gibt es keine Befunde.",
+		"Damage to Champions":     "Schaden an Champions",
+		"Turret Kills":            "Zerstörte Türme",
+		"Dragon Kills":            "Getötete Drachen",
+		"Gold Earned":             "Verdientes Gold",
+		"Vision Score":            "Sichtwert",
+		"Control Wards Purchased": "Gekaufte Kontrollwards",
+	},
+	"pl": {
+		percentileContext: "%d. percentyl dla %s (mediana %s)",
+		"top laners":      "toplanerzy",
+		"junglers":        "junglerzy",
+		"mid laners":      "midlanerzy",
+		"ADCs":            "ADC",
+		"supports":        "supporci",
+		"players":         "gracze",
+
+		"above the %s %s should stay under (a general target, not adjusted for rank)": "powyżej progu %s, poniżej którego powinni pozostać %s (ogólny cel, niezależny od rangi)",
+		"below the %s %s should reach (a general target, not adjusted for rank)":      "poniżej progu %s, który powinni osiągać %s (ogólny cel, niezależny od rangi)",
+		"under the %s %s usually stay below":                                          "poniżej progu %s, poniżej którego zwykle pozostają %s",
+		"above the %s %s usually reach":                                               "powyżej progu %s, który zwykle osiągają %s",
+
+		"Deaths: %d":                              "Śmierci: %d",
+		"Deaths per 10 min: %.1f":                 "Śmierci na 10 min: %.1f",
+		"Too Many Deaths":                         "Zbyt wiele śmierci",
+		"Died %d times (%.1f per 10 minutes), %s": "Śmierci: %d (%.1f na 10 minut), %s",
+		"Every death hands the enemy gold and leaves your team a player short for the next objective":                                      "Każda śmierć daje przeciwnikom złoto i zostawia drużynę bez jednego gracza przy następnym celu",
+		"Before stepping up, check where the enemy jungler and roamers were last seen; back when low instead of staying for one more wave": "Zanim podejdziesz, sprawdź, gdzie ostatnio widziano wrogiego junglera i roamujących graczy; wracaj do bazy przy niskim zdrowiu zamiast zostawać na kolejną falę",
+		"Count the enemies you can see before a fight - if two or more are missing, play as if they are coming":                            "Przed walką policz widocznych przeciwników - jeśli brakuje dwóch lub więcej, graj tak, jakby nadchodzili",
+		"Stayed Alive": "Przeżywanie",
+		"Died only %d times (%.1f per 10 minutes), %s":                                                  "Tylko %d śmierci (%.1f na 10 minut), %s",
+		"Few deaths kept the enemy's bounty gold low and kept you on the map for fights and objectives": "Niewiele śmierci ograniczyło złoto z nagród dla przeciwników i utrzymało cię na mapie do walk i celów",
+
+		"Vision Score: %d":                                               "Wynik wizji: %d",
+		"Wards Placed: %d, Wards Killed: %d":                             "Postawione totemy: %d, zniszczone totemy: %d",
+		"Low Vision Score":                                               "Niski wynik wizji",
+		"Vision score %d (%.2f per minute), %s":                          "Wynik wizji %d (%.2f na minutę), %s",
+		"Without vision the team walks into fights and objectives blind": "Bez wizji drużyna wchodzi w walki i cele na ślepo",
+		"Use your trinket on cooldown and ward the next objective about a minute before it spawns": "Używaj talizmanu, gdy tylko jest gotowy, i stawiaj totemy przy następnym celu około minuty przed jego pojawieniem się",
+		"Every time you back, spend the first 75 gold on a control ward":                           "Przy każdym powrocie do bazy wydaj pierwsze 75 sztuk złota na totem kontrolny",
+		"Strong Vision Control": "Dobra kontrola wizji",
+		"Good vision let the team see fights and objectives coming": "Dobra wizja pozwoliła drużynie przewidzieć walki i cele",
+
+		"Few Control Wards": "Mało totemów kontrolnych",
+		"Bought %d control %s in %.0f minutes; %s should buy at least %d":                         "Kupione totemy kontrolne: %[1]d w ciągu %[3].0f minut; %[4]s powinni kupić co najmniej %[5]d",
+		"Control wards are the only way to deny enemy vision around objectives":                   "Totemy kontrolne to jedyny sposób, by odebrać przeciwnikom wizję wokół celów",
+		"Control Wards Purchased: %d":                                                             "Kupione totemy kontrolne: %d",
+		"Wards Killed: %d":                                                                        "Zniszczone totemy: %d",
+		"Keep a control ward in your inventory at all times and place it before dragon and baron": "Zawsze miej totem kontrolny w ekwipunku i stawiaj go przed smokiem i baronem",
+		"Treat the control ward as part of every back, like a potion":                             "Traktuj totem kontrolny jak mikstura - jako stały element każdego powrotu do bazy",
+
+		"CS: %d":                      "CS: %d",
+		"CS per minute: %.1f":         "CS na minutę: %.1f",
+		"Low CS":                      "Niskie CS",
+		"%d CS (%.1f per minute), %s": "%d CS (%.1f na minutę), %s",
+		"Missed farm is gold and item timings lost without the enemy having to do anything": "Stracony farm to złoto i opóźnione przedmioty, bez żadnego wysiłku ze strony przeciwnika",
+		"Catch side waves between objectives instead of grouping mid with nothing to do":    "Między celami zbieraj fale na bocznych alejach zamiast stać bezczynnie na środku",
+		"Practise last hitting in a custom game for ten minutes before ranked sessions":     "Przed grami rankingowymi poćwicz dobijanie minionów przez dziesięć minut w grze niestandardowej",
+		"Strong Farming":                         "Dobry farm",
+		"Steady farm kept item timings on track": "Równy farm utrzymał terminy zakupu przedmiotów",
+
+		"Objective Takedowns: %d of the team's %d": "Udział w celach: %d z %d drużyny",
+		"Dragons: %d, Heralds: %d, Barons: %d":     "Smoki: %d, heroldy: %d, barony: %d",
+		"Missing From Objectives":                  "Nieobecność przy celach",
+		"Took part in %d of the team's %d dragons, heralds and barons (%.0f%%), below the %.0f%% expected from %s": "Udział w %d z %d smoków, heroldów i baronów drużyny (%.0f%%), poniżej %.0f%% oczekiwanych w tej roli (%s)",
+		"Objectives decide games; fighting them a player short risks a steal or a lost fight":                      "Cele decydują o grach; walka o nie w osłabieniu grozi kradzieżą lub przegraną walką",
+		"Shove your wave and move to the objective about 45 seconds before it spawns":                              "Zepchnij falę i idź do celu około 45 sekund przed jego pojawieniem się",
+		"Check the objective timers every time you back and plan your next reset around them":                      "Przy każdym powrocie do bazy sprawdzaj liczniki celów i planuj pod nie kolejny powrót",
+		"Present for Objectives": "Obecność przy celach",
+		"Took part in %d of the team's %d dragons, heralds and barons (%.0f%%)": "Udział w %d z %d smoków, heroldów i baronów drużyny (%.0f%%)",
+		"Showing up for objectives turned the team's leads into map control":    "Obecność przy celach zamieniła przewagę drużyny w kontrolę mapy",
+
+		"The AI coach is unavailable and no player could be selected for a rule-based review.":                              "Trener AI jest niedostępny i nie udało się wybrać gracza do analizy opartej na regułach.",
+		"No rule flagged an issue this game; review the replay for positioning and decisions, which the rules cannot judge": "Żadna reguła nie wykazała problemu w tej grze; przejrzyj powtórkę pod kątem ustawienia i decyzji, których reguły nie oceniają",
+		"Victory": "Zwycięstwo",
+		"Defeat":  "Porażka",
+		"The AI coach is unavailable, so this is a rule-based review of the match data. %s played %s: %s in %.0f minutes, %d/%d/%d, %d CS and a vision score of %d.": "Trener AI jest niedostępny, więc to jest analiza danych meczu oparta na regułach. %s (%s): %s po %.0f minutach, %d/%d/%d, %d CS i wynik wizji %d.",
+		" Issues: %s.":    " Problemy: %s.",
+		" Strengths: %s.": " Mocne strony: %s.",
+		" No rule applies to this game, so there are no findings.": " Żadna reguła nie dotyczy tej gry, więc nie ma ustaleń.",
+		"Damage to Champions":     "Obrażenia zadane bohaterom",
+		"Turret Kills":            "Zniszczone wieże",
+		"Dragon Kills":            "Zabite smoki",
+		"Gold Earned":             "Zdobyte złoto",
+		"Vision Score":            "Wynik wizji",
+		"Control Wards Purchased": "Kupione totemy kontrolne",
+	},
+	"es": {
+		percentileContext: "percentil %d para %s (mediana %s)",
+		"top laners":      "los top laners",
+		"junglers":        "los junglas",
+		"mid laners":      "los mid laners",
+		"ADCs":            "los ADC",
+		"supports":        "los supports",
+		"players":         "los jugadores",
+
+		"above the %s %s should stay under (a general target, not adjusted for rank)": "por encima del límite de %s que %s no deberían superar (un objetivo general, no ajustado al rango)",
+		"below the %s %s should reach (a general target, not adjusted for rank)":      "por debajo del mínimo de %s que %s deberían alcanzar (un objetivo general, no ajustado al rango)",
+		"under the %s %s usually stay below":                                          "por debajo del límite de %s que %s no suelen superar",
+		"above the %s %s usually reach":                                               "por encima de los %s que %s suelen alcanzar",
+
+		"Deaths: %d":                              "Muertes: %d",
+		"Deaths per 10 min: %.1f":                 "Muertes cada 10 min: %.1f",
+		"Too Many Deaths":                         "Demasiadas muertes",
+		"Died %d times (%.1f per 10 minutes), %s": "Murió %d veces (%.1f cada 10 minutos), %s",
+		"Every death hands the enemy gold and leaves your team a player short for the next objective":                                      "Cada muerte da oro al enemigo y deja a tu equipo con un jugador menos para el siguiente objetivo",
+		"Before stepping up, check where the enemy jungler and roamers were last seen; back when low instead of staying for one more wave": "Antes de avanzar, comprueba dónde se vio por última vez al jungla enemigo y a los que rotan; vuelve a base con poca vida en lugar de quedarte otra oleada",
+		"Count the enemies you can see before a fight - if two or more are missing, play as if they are coming":                            "Cuenta los enemigos visibles antes de una pelea - si faltan dos o más, juega como si estuvieran llegando",
+		"Stayed Alive": "Se mantuvo con vida",
+		"Died only %d times (%.1f per 10 minutes), %s":                                                  "Murió solo %d veces (%.1f cada 10 minutos), %s",
+		"Few deaths kept the enemy's bounty gold low and kept you on the map for fights and objectives": "Pocas muertes mantuvieron bajo el oro de recompensa del enemigo y te dejaron en el mapa para peleas y objetivos",
+
+		"Vision Score: %d":                                               "Puntuación de visión: %d",
+		"Wards Placed: %d, Wards Killed: %d":                             "Guardianes colocados: %d, guardianes destruidos: %d",
+		"Low Vision Score":                                               "Puntuación de visión baja",
+		"Vision score %d (%.2f per minute), %s":                          "Puntuación de visión %d (%.2f por minuto), %s",
+		"Without vision the team walks into fights and objectives blind": "Sin visión, el equipo entra a ciegas en peleas y objetivos",
+		"Use your trinket on cooldown and ward the next objective about a minute before it spawns": "Usa tu abalorio en cuanto esté listo y pon guardianes en el siguiente objetivo un minuto antes de que aparezca",
+		"Every time you back, spend the first 75 gold on a control ward":                           "Cada vez que vuelvas a base, gasta los primeros 75 de oro en un guardián de control",
+		"Strong Vision Control": "Buen control de visión",
+		"Good vision let the team see fights and objectives coming": "La buena visión permitió al equipo anticipar peleas y objetivos",
+
+		"Few Control Wards": "Pocos guardianes de control",
+		"Bought %d control %s in %.0f minutes; %s should buy at least %d":                         "Compró %[1]d guardianes de control en %[3].0f minutos; %[4]s deberían comprar al menos %[5]d",
+		"Control wards are the only way to deny enemy vision around objectives":                   "Los guardianes de control son la única forma de negar la visión enemiga alrededor de los objetivos",
+		"Control Wards Purchased: %d":                                                             "Guardianes de control comprados: %d",
+		"Wards Killed: %d":                                                                        "Guardianes destruidos: %d",
+		"Keep a control ward in your inventory at all times and place it before dragon and baron": "Lleva siempre un guardián de control en el inventario y colócalo antes del dragón y del barón",
+		"Treat the control ward as part of every back, like a potion":                             "Trata el guardián de control como una poción: parte de cada vuelta a base",
+
+		"CS: %d":                      "CS: %d",
+		"CS per minute: %.1f":         "CS por minuto: %.1f",
+		"Low CS":                      "CS bajo",
+		"%d CS (%.1f per minute), %s": "%d CS (%.1f por minuto), %s",
+		"Missed farm is gold and item timings lost without the enemy having to do anything": "El farmeo perdido es oro y tiempos de objetos perdidos sin que el enemigo haga nada",
+		"Catch side waves between objectives instead of grouping mid with nothing to do":    "Recoge las oleadas laterales entre objetivos en lugar de agruparte en medio sin nada que hacer",
+		"Practise last hitting in a custom game for ten minutes before ranked sessions":     "Practica el último golpe diez minutos en una partida personalizada antes de jugar clasificatorias",
+		"Strong Farming":                         "Buen farmeo",
+		"Steady farm kept item timings on track": "Un farmeo constante mantuvo los tiempos de objetos",
+
+		"Objective Takedowns: %d of the team's %d": "Participación en objetivos: %d de %d del equipo",
+		"Dragons: %d, Heralds: %d, Barons: %d":     "Dragones: %d, heraldos: %d, barones: %d",
+		"Missing From Objectives":                  "Ausente en los objetivos",
+		"Took part in %d of the team's %d dragons, heralds and barons (%.0f%%), below the %.0f%% expected from %s": "Participó en %d de los %d dragones, heraldos y barones del equipo (%.0f%%), por debajo del %.0f%% que se espera de %s",
+		"Objectives decide games; fighting them a player short risks a steal or a lost fight":                      "Los objetivos deciden partidas; disputarlos con un jugador menos arriesga un robo o una pelea perdida",
+		"Shove your wave and move to the objective about 45 seconds before it spawns":                              "Empuja tu oleada y ve al objetivo unos 45 segundos antes de que aparezca",
+		"Check the objective timers every time you back and plan your next reset around them":                      "Revisa los temporizadores de objetivos cada vez que vuelvas a base y planifica tu siguiente vuelta en torno a ellos",
+		"Present for Objectives": "Presente en los objetivos",
+		"Took part in %d of the team's %d dragons, heralds and barons (%.0f%%)": "Participó en %d de los %d dragones, heraldos y barones del equipo (%.0f%%)",
+		"Showing up for objectives turned the team's leads into map control":    "Estar presente en los objetivos convirtió la ventaja del equipo en control del mapa",
+
+		"The AI coach is unavailable and no player could be selected for a rule-based review.":                              "El entrenador de IA no está disponible y no se pudo elegir a ningún jugador para una revisión basada en reglas.",
+		"No rule flagged an issue this game; review the replay for positioning and decisions, which the rules cannot judge": "Ninguna regla detectó un problema en esta partida; revisa la repetición para ver el posicionamiento y las decisiones, que las reglas no pueden juzgar",
+		"Victory": "Victoria",
+		"Defeat":  "Derrota",
+		"The AI coach is unavailable, so this is a rule-based review of the match data. %s played %s: %s in %.0f minutes, %d/%d/%d, %d CS and a vision score of %d.": "El entrenador de IA no está disponible, así que esta es una revisión de los datos de la partida basada en reglas. %s jugó con %s: %s en %.0f minutos, %d/%d/%d, %d CS y una puntuación de visión de %d.",
+		" Issues: %s.":    " Problemas: %s.",
+		" Strengths: %s.": " Puntos fuertes: %s.",
+		" No rule applies to this game, so there are no findings.": " Ninguna regla se aplica a esta partida, así que no hay hallazgos.",
+		"Damage to Champions":     "Daño a campeones",
+		"Turret Kills":            "Torres destruidas",
+		"Dragon Kills":            "Dragones asesinados",
+		"Gold Earned":             "Oro obtenido",
+		"Vision Score":            "Puntuación de visión",
+		"Control Wards Purchased": "Guardianes de control comprados",
+	},
+}
+
+// text translates a fixed string into lang, falling back to English
+func text(lang riot.Language, english string) string {
+	if translated, ok := messages[lang.Code][english]; ok {
+		return translated
+	}
+	return english
+}
+
+// sprintf formats with the translation of format into lang
+func sprintf(lang riot.Language, format string, args ...interface{}) string {
+	return fmt.Sprintf(text(lang, format), args...)
+}
@@ -2,8 +2,10 @@ package config
 
 import (
 	"fmt"
+	"log"
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/joho/godotenv"
 )
@@ -13,11 +15,21 @@ type Config struct {
 	OpenAIAPIKey         string
 	ServerPort           string
 	RiotAPIRegion        string
+	RiotAppRateLimit     string // App rate limit assumed until Riot reports it, e.g. "20:1,100:120"
+	RiotAPIBaseURL       string // Riot API host template, {region} is replaced by the routing region
 	OpenAIModel          string
+	OpenAIInputFormat    string // Match data format for prompts: text (default) or json
 	AnalyticsDataPath    string
 	AnalyticsMaxDays     int  // Maximum days to keep requests (0 = unlimited)
 	AnalyticsMaxRecords  int  // Maximum total records to keep (0 = unlimited)
 	DashboardDataPath    string // Path to store dashboard data
+	MatchCachePath       string // Path to store cached Riot match/timeline responses
+	MatchCacheSize       int    // Number of responses kept in memory
+	DataDragonPath       string // Directory with extracted Data Dragon bundles (<version>/data/<locale>/*.json)
+	SummaryVerbosity     string // Default match summary verbosity: compact, standard or full
+	SummaryTokenBudget   int    // Token budget for the match summary when the model has no entry in SummaryTokenBudgets (0 = unlimited)
+	SummaryTokenBudgets  map[string]int // Per-model token budgets, e.g. "gpt-4o-mini:12000,gpt-4o:24000"
+	BenchmarksPath       string // Role and rank benchmark dataset (JSON); empty disables benchmarks
 }
 
 // Load reads configuration from environment variables
@@ -30,7 +42,10 @@ func Load() (*Config, error) {
 		OpenAIAPIKey:      getEnv("OPENAI_API_KEY", ""),
 		ServerPort:        getEnv("PORT", "8080"),
 		RiotAPIRegion:     getEnv("RIOT_API_REGION", "americas"), // americas, europe, asia, sea
+		RiotAppRateLimit:  getEnv("RIOT_APP_RATE_LIMIT", "20:1,100:120"), // Development key default
+		RiotAPIBaseURL:    getEnv("RIOT_API_BASE_URL", "https://{region}.api.riotgames.com"),
 		OpenAIModel:       getEnv("OPENAI_MODEL", "gpt-4o-mini"),
+		OpenAIInputFormat: getEnv("OPENAI_INPUT_FORMAT", ""),
 		// Default to /data/analytics.json for Render.com persistent disk
 		// For local development, use ./data/analytics.json
 		AnalyticsDataPath:   getEnv("ANALYTICS_DATA_PATH", "/data/analytics.json"),
@@ -38,6 +53,16 @@ func Load() (*Config, error) {
 		AnalyticsMaxRecords: getEnvInt("ANALYTICS_MAX_RECORDS", 0),   // 0 = unlimited
 		// Dashboard data path - stores on Render persistent disk
 		DashboardDataPath:   getEnv("DASHBOARD_DATA_PATH", "/data/dashboards"),
+		// Match cache - finished matches never change, so they are kept on disk indefinitely
+		MatchCachePath:      getEnv("MATCH_CACHE_PATH", "/data/match-cache"),
+		MatchCacheSize:      getEnvInt("MATCH_CACHE_SIZE", 200),
+		DataDragonPath:      getEnv("DATA_DRAGON_PATH", "./data/ddragon"),
+		// Match summary size - the summary is sent with every LLM call, so keep it within the model's context
+		SummaryVerbosity:    getEnv("SUMMARY_VERBOSITY", "standard"),
+		SummaryTokenBudget:  getEnvInt("SUMMARY_TOKEN_BUDGET", 8000),
+		SummaryTokenBudgets: parseBudgets(getEnv("SUMMARY_TOKEN_BUDGETS", "gpt-4o-mini:12000,gpt-4o:16000")),
+		// Benchmarks - no dataset ships with the app, since percentiles must come from real aggregated matches
+		BenchmarksPath:      getEnv("BENCHMARKS_PATH", ""),
 	}
 
 	// Validate required configuration
@@ -51,6 +76,38 @@ func Load() (*Config, error) {
 	return config, nil
 }
 
+// SummaryBudget returns the match summary token budget for model
+func (c *Config) SummaryBudget(model string) int {
+	if budget, ok := c.SummaryTokenBudgets[model]; ok {
+		return budget
+	}
+	return c.SummaryTokenBudget
+}
+
+// parseBudgets parses "model:tokens" pairs separated by commas; malformed pairs are logged and skipped
+// The budget follows the last colon, since fine-tuned model IDs (ft:gpt-4o-mini:org::id) contain colons themselves
+func parseBudgets(value string) map[string]int {
+	budgets := make(map[string]int)
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		i := strings.LastIndex(pair, ":")
+		if i <= 0 {
+			log.Printf("Warning: ignoring summary token budget %q: expected model:tokens", pair)
+			continue
+		}
+		budget, err := strconv.Atoi(strings.TrimSpace(pair[i+1:]))
+		if err != nil || budget <= 0 {
+			log.Printf("Warning: ignoring summary token budget %q: %q is not a positive token count", pair, pair[i+1:])
+			continue
+		}
+		budgets[strings.TrimSpace(pair[:i])] = budget
+	}
+	return budgets
+}
+
 func getEnv(key, defaultValue string) string {
 	value := os.Getenv(key)
 	if value == "" {
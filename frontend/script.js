@@ -36,18 +36,20 @@ async function analyzeMatch() {
     const gameId = document.getElementById('gameId').value.trim();
     const focusType = document.querySelector('input[name="focusType"]:checked').value;
     const focusValue = document.getElementById('focusValue').value.trim();
+    const language = document.getElementById('language').value;
     
     // Get selected focus areas
     const focusAreas = Array.from(document.querySelectorAll('input[name="focusAreas"]:checked'))
         .map(cb => cb.value);
 
-    if (!region) {
-        showError('Please select a Region');
+    if (!gameId) {
+        showError('Please enter a Game ID');
         return;
     }
 
-    if (!gameId) {
-        showError('Please enter a Game ID');
+    // Match IDs and links carry their own platform; a bare game ID needs the selected region
+    if (!region && /^[0-9]+$/.test(gameId)) {
+        showError('Please select a Region');
         return;
     }
 
@@ -57,9 +59,6 @@ async function analyzeMatch() {
         return;
     }
 
-    // Combine region and game ID to create full match ID
-    const matchId = `${region}_${gameId}`;
-
     // Show loading
     document.getElementById('loading').classList.remove('hidden');
     document.getElementById('error').classList.add('hidden');
@@ -67,7 +66,7 @@ async function analyzeMatch() {
 
     try {
         // Build URL with query params
-        let url = `${API_URL}/analyze-match-get?match_id=${encodeURIComponent(matchId)}&region=${encodeURIComponent(region)}`;
+        let url = `${API_URL}/analyze-match-get?match_id=${encodeURIComponent(gameId)}&platform=${encodeURIComponent(region)}`;
         
         if (focusType === 'champion' && focusValue) {
             url += `&champion_name=${encodeURIComponent(focusValue)}`;
@@ -78,6 +77,9 @@ async function analyzeMatch() {
         if (focusAreas.length > 0) {
             url += `&focus_areas=${encodeURIComponent(focusAreas.join(','))}`;
         }
+        if (language) {
+            url += `&language=${encodeURIComponent(language)}`;
+        }
 
         const response = await fetch(url);
         const data = await response.json();
@@ -166,12 +168,18 @@ function renderOverview(data) {
         addStats(stats.objectives);
         addStats(stats.economy);
         addStats(stats.vision);
+        addStats(stats.challenges);
+        addStats(stats.benchmarks);
+        addStats(stats.spells);
     }
     
     html += '</div>';
     
     html += '<div class="deep-dive-content">';
     html += `<h2>Match Analysis</h2>`;
+    if (data.analysis_source === 'rules') {
+        html += `<p class="help-text">⚠️ The AI coach was unavailable, so this analysis comes from fixed rules applied to the match data.</p>`;
+    }
     html += `${formatText(data.analysis || 'Analysis not available')}`;
     
     if (data.suggestions && data.suggestions.length > 0) {
@@ -422,18 +430,17 @@ async function addToDashboard() {
     const gameId = document.getElementById('gameId').value.trim();
     const dashboardIdInput = document.getElementById('dashboardId').value.trim();
 
-    if (!region) {
-        showError('Please select a Region');
+    if (!gameId) {
+        showError('Please enter a Game ID');
         return;
     }
 
-    if (!gameId) {
-        showError('Please enter a Game ID');
+    // Match IDs and links carry their own platform; a bare game ID needs the selected region
+    if (!region && /^[0-9]+$/.test(gameId)) {
+        showError('Please select a Region');
         return;
     }
 
-    const matchId = `${region}_${gameId}`;
-    
     // Use input field value, or stored value, or let server generate
     const dashboardIdToUse = dashboardIdInput || currentDashboardID;
 
@@ -454,7 +461,8 @@ async function addToDashboard() {
                 'Content-Type': 'application/json',
             },
             body: JSON.stringify({
-                match_id: matchId,
+                match_id: gameId,
+                platform: region,
                 dashboard_id: dashboardIdToUse
             })
         });
@@ -5,6 +5,7 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"log"
 	"net/http"
 	"strings"
 
@@ -28,7 +29,8 @@ func NewDashboardHandler(storage *dashboard.Storage, riotClient *riot.Client) *D
 
 // SaveMatchRequest represents a request to save a match to dashboard
 type SaveMatchRequest struct {
-	MatchID     string `json:"match_id"`
+	MatchID     string `json:"match_id"`     // Match ID (EUW1_123), bare game ID or match link
+	Platform    string `json:"platform"`     // Optional - platform for a bare game ID (e.g. EUW1)
 	DashboardID string `json:"dashboard_id"` // Optional - will create new if empty
 }
 
@@ -77,6 +79,16 @@ func (h *DashboardHandler) HandleSaveMatch(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	ref, err := riot.ParseMatchReference(req.MatchID, req.Platform)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(SaveMatchResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
 	// Generate dashboard ID if not provided
 	dashboardID := req.DashboardID
 	if dashboardID == "" {
@@ -86,24 +98,21 @@ func (h *DashboardHandler) HandleSaveMatch(w http.ResponseWriter, r *http.Reques
 	// Sanitize dashboard ID
 	dashboardID = sanitizeDashboardID(dashboardID)
 
-	// Fetch COMPLETE match data from Riot API
-	riotMatch, err := h.riotClient.GetMatch(req.MatchID)
+	// Fetch COMPLETE match data from Riot API (served from the match cache if it was already analyzed)
+	riotMatch, err := h.riotClient.GetMatchWithRegion(ref.MatchID, ref.RoutingRegion)
 	if err != nil {
+		log.Printf("Error fetching match for dashboard: %v", err)
+		status, message := riotErrorStatus(w, err, "Match not found. Check the match ID (e.g. NA1_1234567890).")
+		w.WriteHeader(status)
 		json.NewEncoder(w).Encode(SaveMatchResponse{
 			Success: false,
-			Error:   "Failed to fetch match: " + err.Error(),
+			Error:   message,
 		})
 		return
 	}
 
-	// Extract region from match ID (format: REGION_GAMEID)
-	region := "unknown"
-	if parts := strings.Split(req.MatchID, "_"); len(parts) > 0 {
-		region = parts[0]
-	}
-
 	// Create dashboard match with FULL Riot data
-	dashMatch := dashboard.CreateDashboardMatch(riotMatch, dashboardID, region)
+	dashMatch := dashboard.CreateDashboardMatch(riotMatch, dashboardID, ref.Platform)
 
 	// Save to dashboard
 	if err := h.storage.AddMatch(dashboardID, dashMatch); err != nil {
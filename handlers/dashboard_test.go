@@ -0,0 +1,110 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"lol-ranked-new-meta/dashboard"
+	"lol-ranked-new-meta/handlers"
+	"lol-ranked-new-meta/riottest"
+)
+
+func newDashboardHandler(t *testing.T, server *riottest.Server) *handlers.DashboardHandler {
+	storage, err := dashboard.NewStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("dashboard.NewStorage: %v", err)
+	}
+	return handlers.NewDashboardHandler(storage, server.Client("test-key", "europe"))
+}
+
+func saveMatch(t *testing.T, handler *handlers.DashboardHandler, body string) (*httptest.ResponseRecorder, handlers.SaveMatchResponse) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	handler.HandleSaveMatch(rec, httptest.NewRequest(http.MethodPost, "/dashboard-save", strings.NewReader(body)))
+
+	var response handlers.SaveMatchResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("invalid response body %q: %v", rec.Body.String(), err)
+	}
+	return rec, response
+}
+
+func TestDashboardSaveAndGet(t *testing.T) {
+	server := riottest.NewServerWithFixtures()
+	defer server.Close()
+	handler := newDashboardHandler(t, server)
+
+	// A bare game ID with its platform is saved under the canonical match ID; saving it again replaces it
+	for i := 0; i < 2; i++ {
+		rec, response := saveMatch(t, handler, `{"match_id": "7000000001", "platform": "euw", "dashboard_id": "team-1"}`)
+		if rec.Code != http.StatusOK || !response.Success || response.DashboardID != "team-1" {
+			t.Fatalf("save %d: status %d, response %+v, want success for team-1", i+1, rec.Code, response)
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	handler.HandleGetDashboard(rec, httptest.NewRequest(http.MethodGet, "/d/team-1?format=json", nil))
+	var data dashboard.DashboardData
+	if err := json.Unmarshal(rec.Body.Bytes(), &data); err != nil {
+		t.Fatalf("invalid dashboard body %q: %v", rec.Body.String(), err)
+	}
+	if len(data.Matches) != 1 {
+		t.Fatalf("dashboard has %d matches, want 1", len(data.Matches))
+	}
+	if m := data.Matches[0]; m.RiotMatch.Metadata.MatchID != riottest.FixtureMatchID || m.Region != "EUW1" || len(m.RiotMatch.Info.Participants) != 10 {
+		t.Errorf("saved match %s (%s) with %d participants, want %s (EUW1) with 10",
+			m.RiotMatch.Metadata.MatchID, m.Region, len(m.RiotMatch.Info.Participants), riottest.FixtureMatchID)
+	}
+
+	rec = httptest.NewRecorder()
+	handler.HandleListDashboards(rec, httptest.NewRequest(http.MethodGet, "/dashboards", nil))
+	var list struct {
+		Total int `json:"total"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &list); err != nil || list.Total != 1 {
+		t.Errorf("dashboard list %q, want 1 dashboard", rec.Body.String())
+	}
+}
+
+func TestDashboardSaveErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		body   string
+		setup  func(s *riottest.Server)
+		status int
+	}{
+		{
+			name:   "bare game ID without platform",
+			body:   `{"match_id": "7000000001"}`,
+			setup:  func(s *riottest.Server) {},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "unknown match",
+			body:   `{"match_id": "EUW1_7000000002"}`,
+			setup:  func(s *riottest.Server) {},
+			status: http.StatusNotFound,
+		},
+		{
+			name:   "expired key",
+			body:   `{"match_id": "` + riottest.FixtureMatchID + `"}`,
+			setup:  func(s *riottest.Server) { s.FailMatch(riottest.FixtureMatchID, http.StatusForbidden) },
+			status: http.StatusBadGateway,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := riottest.NewServerWithFixtures()
+			defer server.Close()
+			tt.setup(server)
+
+			rec, response := saveMatch(t, newDashboardHandler(t, server), tt.body)
+			if rec.Code != tt.status || response.Success || response.Error == "" {
+				t.Errorf("status = %d, response %+v, want %d with an error", rec.Code, response, tt.status)
+			}
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"lol-ranked-new-meta/openai"
+	"lol-ranked-new-meta/riot"
+	"lol-ranked-new-meta/staticdata"
+	"lol-ranked-new-meta/types"
+)
+
+const (
+	defaultLiveRecentGames = 3
+	maxLiveRecentGames     = 5 // Ten players at 5 games is 60 match-v5 calls, inside the 100 per 2 minutes of a development key
+)
+
+// LiveHandler handles pre-game scouting reports for active games
+type LiveHandler struct {
+	riotClient   *riot.Client
+	openaiClient *openai.Client
+	staticData   *staticdata.Service
+}
+
+// NewLiveHandler creates a new live game handler
+// staticData is optional - without it champions and spells are reported by ID
+func NewLiveHandler(riotClient *riot.Client, openaiClient *openai.Client, staticData *staticdata.Service) *LiveHandler {
+	return &LiveHandler{
+		riotClient:   riotClient,
+		openaiClient: openaiClient,
+		staticData:   staticData,
+	}
+}
+
+// HandleLiveGame builds a scouting report and game plan for a player's active game
+// GET /live/{gameName}-{tagLine}?platform=euw1&recent=3&plan=false
+func (h *LiveHandler) HandleLiveGame(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Access-Control-Allow-Methods", "GET, OPTIONS")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	if r.Method != http.MethodGet {
+		h.sendError(w, "Method not allowed. Use GET.", http.StatusMethodNotAllowed)
+		return
+	}
+
+	gameName, tagLine, ok := parseRiotID(strings.Trim(strings.TrimPrefix(r.URL.Path, "/live/"), "/"))
+	if !ok {
+		h.sendError(w, "Invalid Riot ID. Use /live/{gameName}-{tagLine} (e.g. /live/Faker-KR1?platform=kr)", http.StatusBadRequest)
+		return
+	}
+
+	// spectator-v5 and league-v4 are served per platform, so a routing region is not enough here
+	platform := strings.ToLower(strings.TrimSpace(r.URL.Query().Get("platform")))
+	routingRegion := riot.RoutingRegionFromPlatform(platform)
+	if routingRegion == "" {
+		h.sendError(w, "platform query parameter is required (e.g. euw1, na1, kr)", http.StatusBadRequest)
+		return
+	}
+
+	recentGames := defaultLiveRecentGames
+	if recentStr := r.URL.Query().Get("recent"); recentStr != "" {
+		parsed, err := strconv.Atoi(recentStr)
+		if err != nil || parsed < 0 {
+			h.sendError(w, "recent must be a number of games (0 skips match history)", http.StatusBadRequest)
+			return
+		}
+		recentGames = parsed
+	}
+	if recentGames > maxLiveRecentGames {
+		recentGames = maxLiveRecentGames
+	}
+
+	log.Printf("Looking up live game for Riot ID %s#%s on %s", gameName, tagLine, platform)
+	account, err := h.riotClient.GetAccountByRiotID(gameName, tagLine, routingRegion)
+	if err != nil {
+		log.Printf("Error looking up Riot ID: %v", err)
+		status, message := riotErrorStatus(w, err, fmt.Sprintf("Riot ID %s#%s not found", gameName, tagLine))
+		h.sendError(w, message, status)
+		return
+	}
+
+	game, err := h.riotClient.GetActiveGameByPUUID(account.Puuid, platform)
+	if err != nil {
+		log.Printf("Error fetching active game: %v", err)
+		status, message := riotErrorStatus(w, err, fmt.Sprintf("%s#%s is not in a game on %s right now", account.GameName, account.TagLine, strings.ToUpper(platform)))
+		h.sendError(w, message, status)
+		return
+	}
+
+	var static *staticdata.Bundle
+	if h.staticData != nil {
+		// Live games run on the current patch, which is the newest local Data Dragon version
+		bundle, err := h.staticData.Bundle("")
+		if err != nil {
+			log.Printf("Warning: failed to load static data: %v", err)
+		} else {
+			static = bundle
+		}
+	}
+
+	// Spread what is left of the app rate limit over the players, so one report does not stall on the limiter
+	players := 0
+	for _, p := range game.Participants {
+		if !p.Bot && p.Puuid != "" {
+			players++
+		}
+	}
+	if budget := h.riotClient.LiveRecentGamesBudget(routingRegion, players, recentGames); budget < recentGames {
+		log.Printf("Lowering recent games from %d to %d to stay inside the Riot app rate limit", recentGames, budget)
+		recentGames = budget
+	}
+
+	reports := h.riotClient.ScoutActiveGame(game, account.Puuid, recentGames, static)
+	riot.SortLiveReports(reports)
+
+	response := types.LiveGameResponse{
+		GameID:        game.GameID,
+		PlatformID:    game.PlatformID,
+		QueueID:       game.GameQueueConfigID,
+		GameMode:      game.GameMode,
+		GameStartTime: game.GameStartTime,
+		GameLength:    game.GameLength,
+		Players:       reports,
+	}
+
+	// The scouting data is useful on its own, so a failed game plan is logged rather than returned as an error
+	if r.URL.Query().Get("plan") != "false" {
+		plan, err := h.openaiClient.GenerateLiveGamePlan(r.Context(), riot.FormatLiveGame(game, reports, static))
+		if err != nil {
+			log.Printf("Error generating live game plan: %v", err)
+		} else {
+			response.GamePlan = plan
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		log.Printf("Error encoding response: %v", err)
+	}
+}
+
+func (h *LiveHandler) sendError(w http.ResponseWriter, message string, statusCode int) {
+	response := types.LiveGameResponse{
+		Players: []types.LivePlayerReport{},
+		Error:   message,
+	}
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(response)
+}
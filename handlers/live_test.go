@@ -0,0 +1,151 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"lol-ranked-new-meta/handlers"
+	"lol-ranked-new-meta/openai"
+	"lol-ranked-new-meta/riottest"
+	"lol-ranked-new-meta/types"
+)
+
+// newLiveServer returns a fake Riot server where Fenrir is in the fixture's active game and every player
+// has the fixture match as their only recent game
+func newLiveServer() *riottest.Server {
+	server := riottest.NewServerWithFixtures()
+	server.AddAccount(types.RiotAccount{Puuid: "fixture-puuid-01", GameName: "Fenrir", TagLine: "EUW"})
+	game := riottest.FixtureActiveGame()
+	server.AddActiveGame(game)
+	for _, p := range game.Participants {
+		server.AddJSON("/lol/match/v5/matches/by-puuid/"+p.Puuid+"/ids", []string{riottest.FixtureMatchID})
+	}
+	return server
+}
+
+// getLiveGame runs GET target against a live handler with the fixture static data and an OpenAI stub that always fails
+func getLiveGame(t *testing.T, server *riottest.Server, target string) (*httptest.ResponseRecorder, types.LiveGameResponse) {
+	t.Helper()
+	llm := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error":{"message":"unavailable"}}`, http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(llm.Close)
+	openaiClient := openai.NewClient("test-key", "gpt-4o-mini")
+	openaiClient.SetBaseURL(llm.URL + "/v1")
+
+	static, err := riottest.NewStaticData(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStaticData: %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	handler := handlers.NewLiveHandler(server.Client("test-key", "europe"), openaiClient, static)
+	handler.HandleLiveGame(rec, httptest.NewRequest(http.MethodGet, target, nil))
+
+	var response types.LiveGameResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("invalid response body %q: %v", rec.Body.String(), err)
+	}
+	return rec, response
+}
+
+// matchHistoryCounts returns the count query parameter of every match history request
+func matchHistoryCounts(server *riottest.Server) []string {
+	var counts []string
+	for _, call := range server.Calls() {
+		if strings.HasSuffix(call.Path, "/ids") {
+			for _, param := range strings.Split(call.Query, "&") {
+				if strings.HasPrefix(param, "count=") {
+					counts = append(counts, strings.TrimPrefix(param, "count="))
+				}
+			}
+		}
+	}
+	return counts
+}
+
+func TestLiveGame(t *testing.T) {
+	server := newLiveServer()
+	defer server.Close()
+
+	// The game plan fails at the OpenAI stub, which leaves the scouting report intact
+	rec, response := getLiveGame(t, server, "/live/Fenrir-EUW?platform=euw1&recent=2")
+	if rec.Code != http.StatusOK || response.Error != "" {
+		t.Fatalf("status = %d with error %q, want 200: %s", rec.Code, response.Error, rec.Body.String())
+	}
+	if response.PlatformID != "EUW1" || response.QueueID != 420 || response.GamePlan != "" || len(response.Players) != 10 {
+		t.Fatalf("response = %+v, want ten EUW1 queue 420 players and no game plan", response)
+	}
+
+	// Players are sorted by team, then by likely position
+	var order []string
+	for _, p := range response.Players {
+		order = append(order, p.ChampionName+"/"+p.LikelyPosition)
+	}
+	want := "Aatrox/TOP,Lee Sin/JUNGLE,Ahri/MIDDLE,Jinx/BOTTOM,Thresh/UTILITY,Darius/TOP,Vi/JUNGLE,Syndra/MIDDLE,Caitlyn/BOTTOM,Nautilus/UTILITY"
+	if got := strings.Join(order, ","); got != want {
+		t.Errorf("players = %s, want %s", got, want)
+	}
+	if fenrir := response.Players[0]; !fenrir.IsRequested || fenrir.RecentForm == nil || fenrir.RecentForm.Games != 1 {
+		t.Errorf("requested player = %+v, want Fenrir with one recent game", fenrir)
+	}
+
+	for _, count := range matchHistoryCounts(server) {
+		if count != "2" {
+			t.Errorf("match history count = %s, want 2", count)
+		}
+	}
+	for _, call := range server.Calls() {
+		if strings.Contains(call.Path, "/spectator/") && call.Region != "euw1" {
+			t.Errorf("spectator-v5 was requested from %s, want euw1", call.Region)
+		}
+	}
+}
+
+func TestLiveGameRecentGamesBudget(t *testing.T) {
+	server := newLiveServer()
+	defer server.Close()
+
+	// After the account lookup 29 of 30 calls are left: ten players get one match list call and one game each
+	server.SetAppRateLimit("30:120")
+	rec, _ := getLiveGame(t, server, "/live/Fenrir-EUW?platform=euw1&recent=5&plan=false")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200: %s", rec.Code, rec.Body.String())
+	}
+	counts := matchHistoryCounts(server)
+	if len(counts) != 10 {
+		t.Fatalf("%d match history requests, want 10", len(counts))
+	}
+	for _, count := range counts {
+		if count != "1" {
+			t.Errorf("match history count = %s, want the budget of 1", count)
+		}
+	}
+}
+
+func TestLiveGameErrors(t *testing.T) {
+	server := newLiveServer()
+	defer server.Close()
+	server.AddAccount(types.RiotAccount{Puuid: "idle-puuid", GameName: "Idle", TagLine: "EUW"})
+
+	tests := []struct {
+		target string
+		status int
+	}{
+		{"/live/Fenrir?platform=euw1", http.StatusBadRequest},
+		{"/live/Fenrir-EUW", http.StatusBadRequest},
+		{"/live/Fenrir-EUW?platform=moon1", http.StatusBadRequest},
+		{"/live/Fenrir-EUW?platform=euw1&recent=-1", http.StatusBadRequest},
+		{"/live/Nobody-EUW?platform=euw1", http.StatusNotFound},
+		{"/live/Idle-EUW?platform=euw1", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		rec, response := getLiveGame(t, server, tt.target)
+		if rec.Code != tt.status || response.Error == "" {
+			t.Errorf("%s: status = %d with error %q, want %d with an error", tt.target, rec.Code, response.Error, tt.status)
+		}
+	}
+}
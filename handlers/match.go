@@ -2,29 +2,50 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
 	"strings"
 
+	"lol-ranked-new-meta/benchmarks"
+	"lol-ranked-new-meta/coaching"
+	"lol-ranked-new-meta/metrics"
 	"lol-ranked-new-meta/openai"
 	"lol-ranked-new-meta/riot"
+	"lol-ranked-new-meta/staticdata"
 	"lol-ranked-new-meta/types"
 )
 
 type MatchHandler struct {
 	riotClient   *riot.Client
 	openaiClient *openai.Client
+	staticData   *staticdata.Service // Optional: resolves item/rune/spell IDs to names
+	summary      riot.SummaryOptions // Default verbosity and token budget of the match summary
+	benchmarks   *benchmarks.Dataset // Optional: rates the deep dive target against their role and tier
 }
 
 // NewMatchHandler creates a new match handler
-func NewMatchHandler(riotClient *riot.Client, openaiClient *openai.Client) *MatchHandler {
+// staticData may be nil, in which case the summary only contains IDs
+func NewMatchHandler(riotClient *riot.Client, openaiClient *openai.Client, staticData *staticdata.Service) *MatchHandler {
 	return &MatchHandler{
 		riotClient:   riotClient,
 		openaiClient: openaiClient,
+		staticData:   staticData,
+		summary:      riot.SummaryOptions{Verbosity: riot.VerbosityFull},
 	}
 }
 
+// SetSummaryOptions sets the default verbosity and token budget of the match summary sent to the LLM
+func (h *MatchHandler) SetSummaryOptions(opts riot.SummaryOptions) {
+	h.summary = opts
+}
+
+// SetBenchmarks sets the dataset the deep dive target is rated against; nil disables benchmarks
+func (h *MatchHandler) SetBenchmarks(dataset *benchmarks.Dataset) {
+	h.benchmarks = dataset
+}
+
 // HandleAnalyzeMatch handles requests to analyze a match
 func (h *MatchHandler) HandleAnalyzeMatch(w http.ResponseWriter, r *http.Request) {
 	// Set CORS headers for frontend access
@@ -49,56 +70,7 @@ func (h *MatchHandler) HandleAnalyzeMatch(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	// Fetch match data from Riot API
-	log.Printf("Fetching match data for match ID: %s", req.MatchID)
-	routingRegion := riot.NormalizeRoutingRegion(req.Region)
-	if routingRegion == "" {
-		routingRegion = riot.RoutingRegionFromMatchID(req.MatchID)
-	}
-	match, err := h.riotClient.GetMatchWithRegion(req.MatchID, routingRegion)
-	if err != nil {
-		log.Printf("Error fetching match: %v", err)
-		h.sendError(w, "Failed to fetch match data: "+err.Error(), http.StatusInternalServerError)
-		return
-	}
-
-	championFilter, summonerFilter, deepDiveTarget, deepDiveMode := resolveDeepDiveTarget(match, req.ChampionName, req.SummonerName)
-
-	// Format match data for analysis (with optional champion/summoner filter)
-	matchSummary := riot.FormatMatchForAnalysis(match, championFilter, summonerFilter)
-	if deepDiveMode == "auto" && deepDiveTarget != "" {
-		matchSummary = fmt.Sprintf("AUTO-SELECTED DEEP DIVE TARGET: %s (based on match impact)\n\n%s", deepDiveTarget, matchSummary)
-	}
-
-	// Analyze match using OpenAI
-	log.Printf("Analyzing match using OpenAI")
-	if championFilter != "" {
-		log.Printf("Deep dive requested for champion: %s", championFilter)
-	} else if summonerFilter != "" {
-		log.Printf("Deep dive requested for summoner: %s", summonerFilter)
-	} else {
-		log.Printf("Deep dive target auto-selected")
-	}
-	if len(req.FocusAreas) > 0 {
-		log.Printf("Focus areas requested: %v", req.FocusAreas)
-	}
-	analysis, err := h.openaiClient.AnalyzeMatch(r.Context(), matchSummary, championFilter, summonerFilter, req.FocusAreas)
-	if err != nil {
-		log.Printf("Error analyzing match: %v", err)
-		h.sendError(w, "Failed to analyze match: "+err.Error(), http.StatusInternalServerError)
-		return
-	}
-
-	// Set match ID in response
-	analysis.MatchID = req.MatchID
-	analysis.DeepDiveTarget = deepDiveTarget
-	analysis.DeepDiveMode = deepDiveMode
-
-	// Send response
-	w.WriteHeader(http.StatusOK)
-	if err := json.NewEncoder(w).Encode(analysis); err != nil {
-		log.Printf("Error encoding response: %v", err)
-	}
+	h.analyze(w, r, req)
 }
 
 // HandleAnalyzeMatchGET handles GET requests (for testing convenience)
@@ -122,6 +94,9 @@ func (h *MatchHandler) HandleAnalyzeMatchGET(w http.ResponseWriter, r *http.Requ
 	championFilter := r.URL.Query().Get("champion_name")
 	summonerFilter := r.URL.Query().Get("summoner_name")
 	regionParam := r.URL.Query().Get("region")
+	platformParam := r.URL.Query().Get("platform")
+	verbosityParam := r.URL.Query().Get("verbosity")
+	languageParam := r.URL.Query().Get("language")
 
 	// Get optional focus areas from query params (comma-separated)
 	var focusAreas []string
@@ -132,23 +107,117 @@ func (h *MatchHandler) HandleAnalyzeMatchGET(w http.ResponseWriter, r *http.Requ
 		}
 	}
 
+	h.analyze(w, r, types.MatchRequest{
+		MatchID:      matchID,
+		Platform:     platformParam,
+		Region:       regionParam,
+		ChampionName: championFilter,
+		SummonerName: summonerFilter,
+		FocusAreas:   focusAreas,
+		Verbosity:    verbosityParam,
+		Language:     languageParam,
+	})
+}
+
+// analyze fetches the match, runs the OpenAI analysis and writes the response
+func (h *MatchHandler) analyze(w http.ResponseWriter, r *http.Request, req types.MatchRequest) {
+	// Accept match IDs in any case, bare game IDs and match links; the region field may also hold a platform
+	platformHint := req.Platform
+	if platformHint == "" && riot.RoutingRegionFromPlatform(req.Region) != "" {
+		platformHint = req.Region
+	}
+	ref, err := riot.ParseMatchReference(req.MatchID, platformHint)
+	if err != nil {
+		log.Printf("Invalid match reference: %v", err)
+		h.sendMatchReferenceError(w, err)
+		return
+	}
+	req.MatchID = ref.MatchID
+
 	// Fetch match data from Riot API
-	log.Printf("Fetching match data for match ID: %s", matchID)
-	routingRegion := riot.NormalizeRoutingRegion(regionParam)
+	log.Printf("Fetching match data for match ID: %s", req.MatchID)
+	routingRegion := riot.NormalizeRoutingRegion(req.Region)
 	if routingRegion == "" {
-		routingRegion = riot.RoutingRegionFromMatchID(matchID)
+		routingRegion = ref.RoutingRegion
 	}
-	match, err := h.riotClient.GetMatchWithRegion(matchID, routingRegion)
+	match, err := h.riotClient.GetMatchWithRegion(req.MatchID, routingRegion)
 	if err != nil {
 		log.Printf("Error fetching match: %v", err)
-		h.sendError(w, "Failed to fetch match data: "+err.Error(), http.StatusInternalServerError)
+		status, message := riotErrorStatus(w, err, "Match not found. Check the match ID and region (e.g. NA1_1234567890).")
+		h.sendError(w, message, status)
 		return
 	}
 
-	championFilter, summonerFilter, deepDiveTarget, deepDiveMode := resolveDeepDiveTarget(match, championFilter, summonerFilter)
+	// The timeline only enriches the summary, so a failure here is not fatal
+	extras := &riot.MatchExtras{}
+	timeline, err := h.riotClient.GetMatchTimelineWithRegion(req.MatchID, routingRegion)
+	if err != nil {
+		log.Printf("Warning: failed to fetch match timeline: %v", err)
+	} else {
+		extras.Timeline = timeline
+	}
+	// Unknown languages fall back to English rather than failing the request
+	language, ok := riot.ParseLanguage(req.Language)
+	if !ok && req.Language != "" {
+		log.Printf("Warning: unsupported language %q, answering in English", req.Language)
+	}
+
+	if h.staticData != nil {
+		bundle, err := h.staticData.BundleForLocale(match.Info.GameVersion, language.Locale)
+		if err != nil && language.Locale != staticdata.DefaultLocale {
+			// Localized names are a nicety; English names beat IDs only
+			log.Printf("Warning: no %s static data for patch %s, using %s: %v", language.Locale, match.Info.GameVersion, staticdata.DefaultLocale, err)
+			bundle, err = h.staticData.Bundle(match.Info.GameVersion)
+		}
+		if err != nil {
+			log.Printf("Warning: failed to load static data for patch %s: %v", match.Info.GameVersion, err)
+		} else {
+			if !bundle.MatchesGamePatch() {
+				log.Printf("Warning: no static data for patch %s, using Data Dragon %s", bundle.GamePatch, bundle.Version)
+			}
+			extras.Static = bundle
+		}
+	}
+
+	// Roles are resolved once and shared by the summary, the benchmarks, the rules and the response
+	extras.Roles = riot.ResolveRoles(match)
+
+	// Ranks only add context; players whose rank cannot be fetched are listed as unavailable
+	// They cost one league-v4 call per player, so they are only fetched where the elo matters: ranked queues
+	queue := riot.MatchQueue(match)
+	if queue.Ranked {
+		extras.Ranks = h.riotClient.GetLobbyRanks(match)
+	}
+
+	championFilter, summonerFilter, deepDiveTarget, deepDiveMode := resolveDeepDiveTarget(match, req.ChampionName, req.SummonerName)
+
+	// Mastery tells a first game on the champion apart from a one-trick; the deep dive works without it
+	target := riot.FindTargetParticipant(match, championFilter, summonerFilter)
+	if target != nil {
+		mastery, err := h.riotClient.GetChampionMastery(target.Puuid, riot.MatchPlatform(match), target.ChampionID)
+		if err != nil {
+			log.Printf("Warning: failed to fetch champion mastery: %v", err)
+		} else {
+			extras.Mastery = mastery
+		}
+	}
+	extras.Benchmarks = riot.RateAgainstBenchmarks(h.benchmarks, match, extras.Roles, target, extras.Ranks)
 
 	// Format match data for analysis (with optional champion/summoner filter)
-	matchSummary := riot.FormatMatchForAnalysis(match, championFilter, summonerFilter)
+	summaryOpts := h.summary
+	if verbosity, ok := riot.ParseVerbosity(req.Verbosity); ok {
+		summaryOpts.Verbosity = verbosity
+	}
+	summaryOpts.FocusAreas = req.FocusAreas
+	summaryOpts.Language = language
+	// Rule findings are hard facts for the model, and the whole analysis if the model is unavailable
+	findings := coaching.Evaluate(match, extras.Roles, target, extras.Benchmarks, language)
+	extras.Findings = findings
+	if h.openaiClient.InputFormat() == openai.InputFormatJSON {
+		summaryOpts.Format = riot.SummaryFormatJSON
+	}
+	matchSummary := riot.BuildMatchSummary(match, extras, championFilter, summonerFilter, summaryOpts)
+	log.Printf("Match summary: %s verbosity, %s format, ~%d tokens (budget %d)", summaryOpts.Verbosity, h.openaiClient.InputFormat(), riot.EstimateTokens(matchSummary), summaryOpts.TokenBudget)
 	if deepDiveMode == "auto" && deepDiveTarget != "" {
 		matchSummary = fmt.Sprintf("AUTO-SELECTED DEEP DIVE TARGET: %s (based on match impact)\n\n%s", deepDiveTarget, matchSummary)
 	}
@@ -162,20 +231,54 @@ func (h *MatchHandler) HandleAnalyzeMatchGET(w http.ResponseWriter, r *http.Requ
 	} else {
 		log.Printf("Deep dive target auto-selected")
 	}
-	if len(focusAreas) > 0 {
-		log.Printf("Focus areas requested: %v", focusAreas)
+	if len(req.FocusAreas) > 0 {
+		log.Printf("Focus areas requested: %v", req.FocusAreas)
 	}
-	analysis, err := h.openaiClient.AnalyzeMatch(r.Context(), matchSummary, championFilter, summonerFilter, focusAreas)
+	analysis, err := h.openaiClient.AnalyzeMatch(r.Context(), matchSummary, openai.AnalysisOptions{
+		ChampionFilter: championFilter,
+		SummonerFilter: summonerFilter,
+		FocusAreas:     req.FocusAreas,
+		Queue:          queue,
+		Language:       language.Name,
+	})
 	if err != nil {
-		log.Printf("Error analyzing match: %v", err)
-		h.sendError(w, "Failed to analyze match: "+err.Error(), http.StatusInternalServerError)
-		return
+		log.Printf("Error analyzing match, falling back to rule-based coaching: %v", err)
+		analysis = coaching.Fallback(match, target, findings, language)
+	} else {
+		analysis.AnalysisSource = types.AnalysisSourceLLM
+		analysis.RuleFindings = findings
 	}
 
 	// Set match ID in response
-	analysis.MatchID = matchID
+	analysis.MatchID = req.MatchID
 	analysis.DeepDiveTarget = deepDiveTarget
 	analysis.DeepDiveMode = deepDiveMode
+	analysis.LobbyRanks = extras.Ranks
+	analysis.Queue = &queue
+	analysis.Metrics = metrics.Compute(match)
+	analysis.TeamComparison = metrics.CompareTeams(match)
+	analysis.Draft = riot.BuildDraft(match, extras.Roles, extras.Static, target)
+	analysis.Benchmarks = extras.Benchmarks
+	if riot.HasFocusArea(req.FocusAreas, types.FocusCommunication) {
+		analysis.Communication = riot.BuildCommunicationProfiles(match)
+	}
+	if analysis.StructuredInsights != nil {
+		analysis.StructuredInsights.KeyStatistics.Challenges = riot.ChallengeStatPairs(target, extras.Roles)
+		analysis.StructuredInsights.KeyStatistics.Benchmarks = benchmarks.StatPairs(extras.Benchmarks)
+		analysis.StructuredInsights.KeyStatistics.Spells = riot.SpellStatPairs(match, target, extras)
+		if page := riot.BuildRunePage(target, extras.Static); page != nil {
+			if analysis.StructuredInsights.RuneAnalysis == nil {
+				analysis.StructuredInsights.RuneAnalysis = &types.RuneAnalysis{}
+			}
+			analysis.StructuredInsights.RuneAnalysis.Page = page
+		}
+		if _, laneOpponent, ok := riot.FindLaneOpponent(match, extras.Roles, target); ok {
+			if analysis.StructuredInsights.MatchupAnalysis == nil {
+				analysis.StructuredInsights.MatchupAnalysis = &types.MatchupAnalysis{}
+			}
+			analysis.StructuredInsights.MatchupAnalysis.LaneOpponent = &laneOpponent
+		}
+	}
 
 	// Send response
 	w.WriteHeader(http.StatusOK)
@@ -192,6 +295,20 @@ func (h *MatchHandler) sendError(w http.ResponseWriter, message string, statusCo
 	json.NewEncoder(w).Encode(response)
 }
 
+// sendMatchReferenceError writes a 400 that lists the match reference formats the API accepts
+func (h *MatchHandler) sendMatchReferenceError(w http.ResponseWriter, err error) {
+	response := types.MatchResponse{
+		Error:            err.Error(),
+		SupportedFormats: riot.SupportedMatchFormats,
+	}
+	var refErr *riot.MatchReferenceError
+	if errors.As(err, &refErr) {
+		response.SupportedFormats = refErr.SupportedFormats
+	}
+	w.WriteHeader(http.StatusBadRequest)
+	json.NewEncoder(w).Encode(response)
+}
+
 func resolveDeepDiveTarget(match *types.RiotMatch, championFilter, summonerFilter string) (string, string, string, string) {
 	if strings.TrimSpace(championFilter) != "" || strings.TrimSpace(summonerFilter) != "" {
 		targetLabel := championFilter
@@ -0,0 +1,149 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"lol-ranked-new-meta/handlers"
+	"lol-ranked-new-meta/openai"
+	"lol-ranked-new-meta/riottest"
+	"lol-ranked-new-meta/types"
+)
+
+const fixtureMatchPath = "/lol/match/v5/matches/" + riottest.FixtureMatchID
+
+// newMatchHandler returns a match handler on the fake Riot server whose OpenAI calls fail,
+// so analyses come from the rule-based fallback
+func newMatchHandler(t *testing.T, server *riottest.Server) *handlers.MatchHandler {
+	llm := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error":{"message":"unavailable"}}`, http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(llm.Close)
+
+	openaiClient := openai.NewClient("test-key", "gpt-4o-mini")
+	openaiClient.SetBaseURL(llm.URL + "/v1")
+	return handlers.NewMatchHandler(server.Client("test-key", "europe"), openaiClient, nil)
+}
+
+func analyzeMatch(t *testing.T, handler *handlers.MatchHandler, body string) (*httptest.ResponseRecorder, types.MatchResponse) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	handler.HandleAnalyzeMatch(rec, httptest.NewRequest(http.MethodPost, "/analyze-match", strings.NewReader(body)))
+
+	var response types.MatchResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("invalid response body %q: %v", rec.Body.String(), err)
+	}
+	return rec, response
+}
+
+func TestAnalyzeMatch(t *testing.T) {
+	server := riottest.NewServerWithFixtures()
+	defer server.Close()
+
+	// A lowercase ID with a region works like the canonical match ID
+	rec, response := analyzeMatch(t, newMatchHandler(t, server), `{"match_id": "euw1_7000000001", "champion_name": "Aatrox"}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200: %s", rec.Code, rec.Body.String())
+	}
+	if response.MatchID != riottest.FixtureMatchID || response.DeepDiveMode != "requested" {
+		t.Errorf("got match %s (%s), want %s (requested)", response.MatchID, response.DeepDiveMode, riottest.FixtureMatchID)
+	}
+	if response.AnalysisSource != types.AnalysisSourceRules || response.Analysis == "" {
+		t.Errorf("analysis source = %q with %d characters, want the rule-based fallback", response.AnalysisSource, len(response.Analysis))
+	}
+	if response.Metrics == nil || len(response.Metrics.Participants) != 10 || response.TeamComparison == nil {
+		t.Error("response lacks the derived metrics or the team comparison")
+	}
+	if len(response.LobbyRanks) != 10 {
+		t.Errorf("got %d lobby ranks, want 10", len(response.LobbyRanks))
+	}
+	if response.Queue == nil || response.Queue.QueueID != 420 {
+		t.Errorf("queue = %+v, want ranked solo/duo (420)", response.Queue)
+	}
+	for _, call := range server.Calls() {
+		if call.Region != "europe" && !strings.HasPrefix(call.Path, "/lol/league/") && !strings.HasPrefix(call.Path, "/lol/champion-mastery/") {
+			t.Errorf("%s was requested from %s, want europe", call.Path, call.Region)
+		}
+	}
+}
+
+func TestAnalyzeMatchUnrankedSkipsLeague(t *testing.T) {
+	server := riottest.NewServerWithFixtures()
+	defer server.Close()
+	match := riottest.FixtureMatch()
+	match.Info.QueueID = 400 // Normal draft
+	server.AddMatch(match)
+
+	rec, response := analyzeMatch(t, newMatchHandler(t, server), `{"match_id": "`+riottest.FixtureMatchID+`"}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200: %s", rec.Code, rec.Body.String())
+	}
+	if len(response.LobbyRanks) != 0 {
+		t.Errorf("got %d lobby ranks for a normal game, want none", len(response.LobbyRanks))
+	}
+	for _, call := range server.Calls() {
+		if strings.HasPrefix(call.Path, "/lol/league/") {
+			t.Errorf("requested %s for a normal game", call.Path)
+		}
+	}
+}
+
+func TestAnalyzeMatchErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		setup      func(s *riottest.Server)
+		status     int
+		retryAfter string
+	}{
+		{
+			name:   "invalid reference",
+			body:   `{"match_id": "not a match"}`,
+			setup:  func(s *riottest.Server) {},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "not found",
+			body:   `{"match_id": "EUW1_7000000002"}`,
+			setup:  func(s *riottest.Server) {},
+			status: http.StatusNotFound,
+		},
+		{
+			name: "rate limited",
+			body: `{"match_id": "` + riottest.FixtureMatchID + `"}`,
+			setup: func(s *riottest.Server) {
+				s.Fail(fixtureMatchPath, riottest.Failure{Status: http.StatusTooManyRequests, RetryAfter: 60})
+			},
+			status:     http.StatusTooManyRequests,
+			retryAfter: "60",
+		},
+		{
+			name:   "expired key",
+			body:   `{"match_id": "` + riottest.FixtureMatchID + `"}`,
+			setup:  func(s *riottest.Server) { s.RequireAPIKey("other-key") },
+			status: http.StatusBadGateway,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := riottest.NewServerWithFixtures()
+			defer server.Close()
+			tt.setup(server)
+
+			rec, response := analyzeMatch(t, newMatchHandler(t, server), tt.body)
+			if rec.Code != tt.status || response.Error == "" {
+				t.Errorf("status = %d with error %q, want %d with an error", rec.Code, response.Error, tt.status)
+			}
+			if got := rec.Header().Get("Retry-After"); got != tt.retryAfter {
+				t.Errorf("Retry-After = %q, want %q", got, tt.retryAfter)
+			}
+			if tt.status == http.StatusBadRequest && len(response.SupportedFormats) == 0 {
+				t.Error("invalid reference response lists no supported formats")
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"lol-ranked-new-meta/riot"
+	"lol-ranked-new-meta/types"
+)
+
+const (
+	defaultPlayerMatchCount = 10
+	maxPlayerMatchCount     = 20
+)
+
+// PlayerHandler handles Riot ID lookups and match history listing
+type PlayerHandler struct {
+	riotClient *riot.Client
+}
+
+// NewPlayerHandler creates a new player handler
+func NewPlayerHandler(riotClient *riot.Client) *PlayerHandler {
+	return &PlayerHandler{
+		riotClient: riotClient,
+	}
+}
+
+// HandlePlayerMatches lists recent matches for a Riot ID
+// GET /player/{gameName}-{tagLine}/matches?count=&queue=&region=
+func (h *PlayerHandler) HandlePlayerMatches(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Access-Control-Allow-Methods", "GET, OPTIONS")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	if r.Method != http.MethodGet {
+		h.sendError(w, "Method not allowed. Use GET.", http.StatusMethodNotAllowed)
+		return
+	}
+
+	path := strings.Trim(strings.TrimPrefix(r.URL.Path, "/player/"), "/")
+	if !strings.HasSuffix(path, "/matches") {
+		h.sendError(w, "Use /player/{gameName}-{tagLine}/matches", http.StatusNotFound)
+		return
+	}
+	gameName, tagLine, ok := parseRiotID(strings.TrimSuffix(path, "/matches"))
+	if !ok {
+		h.sendError(w, "Invalid Riot ID. Use gameName-tagLine (e.g. Faker-KR1)", http.StatusBadRequest)
+		return
+	}
+
+	count := defaultPlayerMatchCount
+	if countStr := r.URL.Query().Get("count"); countStr != "" {
+		parsed, err := strconv.Atoi(countStr)
+		if err != nil || parsed < 1 {
+			h.sendError(w, "count must be a positive number", http.StatusBadRequest)
+			return
+		}
+		count = parsed
+	}
+	if count > maxPlayerMatchCount {
+		count = maxPlayerMatchCount
+	}
+
+	queue := 0
+	if queueStr := r.URL.Query().Get("queue"); queueStr != "" {
+		parsed, err := strconv.Atoi(queueStr)
+		if err != nil || parsed < 0 {
+			h.sendError(w, "queue must be a numeric queue ID (e.g. 420 for ranked solo)", http.StatusBadRequest)
+			return
+		}
+		queue = parsed
+	}
+
+	// Accept either a routing region (europe) or a platform (EUW1)
+	regionParam := r.URL.Query().Get("region")
+	routingRegion := riot.NormalizeRoutingRegion(regionParam)
+	if routingRegion == "" {
+		routingRegion = riot.RoutingRegionFromPlatform(regionParam)
+	}
+
+	log.Printf("Looking up Riot ID %s#%s", gameName, tagLine)
+	account, err := h.riotClient.GetAccountByRiotID(gameName, tagLine, routingRegion)
+	if err != nil {
+		log.Printf("Error looking up Riot ID: %v", err)
+		status, message := riotErrorStatus(w, err, fmt.Sprintf("Riot ID %s#%s not found", gameName, tagLine))
+		h.sendError(w, message, status)
+		return
+	}
+
+	matchIDs, err := h.riotClient.GetMatchIDsByPUUID(account.Puuid, routingRegion, 0, count, queue)
+	if err != nil {
+		log.Printf("Error listing matches: %v", err)
+		status, message := riotErrorStatus(w, err, "No match history found for this player in the selected region")
+		h.sendError(w, message, status)
+		return
+	}
+
+	response := types.PlayerMatchesResponse{
+		Puuid:    account.Puuid,
+		GameName: account.GameName,
+		TagLine:  account.TagLine,
+		Matches:  make([]types.PlayerMatchSummary, 0, len(matchIDs)),
+	}
+	for _, matchID := range matchIDs {
+		summary := types.PlayerMatchSummary{MatchID: matchID}
+		match, err := h.riotClient.GetMatchWithRegion(matchID, routingRegion)
+		if err != nil {
+			log.Printf("Error fetching match %s: %v", matchID, err)
+			summary.Error = "Failed to fetch match data"
+		} else {
+			summary = riot.SummarizeMatchForPlayer(match, account.Puuid)
+		}
+		response.Matches = append(response.Matches, summary)
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		log.Printf("Error encoding response: %v", err)
+	}
+}
+
+func (h *PlayerHandler) sendError(w http.ResponseWriter, message string, statusCode int) {
+	response := types.PlayerMatchesResponse{
+		Matches: []types.PlayerMatchSummary{},
+		Error:   message,
+	}
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(response)
+}
+
+// parseRiotID splits "gameName-tagLine" (or "gameName#tagLine") into its parts
+// Game names may contain dashes, so the split happens on the last separator
+func parseRiotID(value string) (string, string, bool) {
+	value = strings.TrimSpace(value)
+	idx := strings.LastIndex(value, "#")
+	if idx < 0 {
+		idx = strings.LastIndex(value, "-")
+	}
+	if idx <= 0 || idx == len(value)-1 {
+		return "", "", false
+	}
+	gameName := strings.TrimSpace(value[:idx])
+	tagLine := strings.TrimSpace(value[idx+1:])
+	if gameName == "" || tagLine == "" {
+		return "", "", false
+	}
+	return gameName, tagLine, true
+}
@@ -0,0 +1,75 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"lol-ranked-new-meta/handlers"
+	"lol-ranked-new-meta/riottest"
+	"lol-ranked-new-meta/types"
+)
+
+func getPlayerMatches(t *testing.T, server *riottest.Server, target string) (*httptest.ResponseRecorder, types.PlayerMatchesResponse) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	handlers.NewPlayerHandler(server.Client("test-key", "europe")).HandlePlayerMatches(rec, httptest.NewRequest(http.MethodGet, target, nil))
+
+	var response types.PlayerMatchesResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("invalid response body %q: %v", rec.Body.String(), err)
+	}
+	return rec, response
+}
+
+func TestPlayerMatches(t *testing.T) {
+	server := riottest.NewServerWithFixtures()
+	defer server.Close()
+	server.AddAccount(types.RiotAccount{Puuid: "fixture-puuid-01", GameName: "Fenrir", TagLine: "EUW"}, riottest.FixtureMatchID, "EUW1_7000000002")
+
+	rec, response := getPlayerMatches(t, server, "/player/Fenrir-EUW/matches?region=EUW1&count=2&queue=420")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200: %s", rec.Code, rec.Body.String())
+	}
+	if response.Puuid != "fixture-puuid-01" || len(response.Matches) != 2 {
+		t.Fatalf("got %s with %d matches, want fixture-puuid-01 with 2", response.Puuid, len(response.Matches))
+	}
+	if m := response.Matches[0]; m.MatchID != riottest.FixtureMatchID || m.ChampionName != "Aatrox" || !m.Win || m.Error != "" {
+		t.Errorf("first match = %+v, want a won Aatrox game", m)
+	}
+	// A match that cannot be loaded is reported in place, not as a failed request
+	if m := response.Matches[1]; m.MatchID != "EUW1_7000000002" || m.Error == "" {
+		t.Errorf("second match = %+v, want EUW1_7000000002 with an error", m)
+	}
+
+	for _, call := range server.Calls() {
+		if call.Region != "europe" {
+			t.Errorf("%s was requested from %s, want europe", call.Path, call.Region)
+		}
+		if call.Path == "/lol/match/v5/matches/by-puuid/fixture-puuid-01/ids" && call.Query != "count=2&queue=420&start=0" {
+			t.Errorf("match history query = %q, want count=2&queue=420&start=0", call.Query)
+		}
+	}
+}
+
+func TestPlayerMatchesErrors(t *testing.T) {
+	server := riottest.NewServer()
+	defer server.Close()
+
+	tests := []struct {
+		target string
+		status int
+	}{
+		{"/player/Fenrir/matches", http.StatusBadRequest},
+		{"/player/Fenrir-EUW/matches?count=zero", http.StatusBadRequest},
+		{"/player/Fenrir-EUW", http.StatusNotFound},
+		{"/player/Nobody-EUW/matches", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		rec, response := getPlayerMatches(t, server, tt.target)
+		if rec.Code != tt.status || response.Error == "" {
+			t.Errorf("%s: status = %d with error %q, want %d with an error", tt.target, rec.Code, response.Error, tt.status)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+
+	"lol-ranked-new-meta/riot"
+)
+
+// riotErrorStatus maps a Riot client error to an HTTP status and a user-facing message
+// notFound is the message used when Riot has no such resource (e.g. "Match not found")
+// Rate limited responses also get a Retry-After header so clients know when to try again
+func riotErrorStatus(w http.ResponseWriter, err error, notFound string) (int, string) {
+	switch {
+	case errors.Is(err, riot.ErrNotFound):
+		return http.StatusNotFound, notFound
+	case errors.Is(err, riot.ErrRateLimited):
+		if delay, ok := riot.RetryAfter(err); ok {
+			seconds := int(math.Ceil(delay.Seconds()))
+			w.Header().Set("Retry-After", strconv.Itoa(seconds))
+			unit := "seconds"
+			if seconds == 1 {
+				unit = "second"
+			}
+			return http.StatusTooManyRequests, fmt.Sprintf("The Riot API rate limit was reached. Please try again in %d %s.", seconds, unit)
+		}
+		return http.StatusTooManyRequests, "The Riot API rate limit was reached. Please try again in a minute."
+	case errors.Is(err, riot.ErrForbidden):
+		return http.StatusBadGateway, "The Riot API rejected this server's API key (it may have expired). Please contact the site owner."
+	case errors.Is(err, riot.ErrUnavailable):
+		return http.StatusBadGateway, "The Riot API is currently unavailable. Please try again later."
+	default:
+		return http.StatusBadGateway, "Unexpected error from the Riot API. Please try again later."
+	}
+}
@@ -1,16 +1,20 @@
 package main
 
 import (
+	"encoding/json"
 	"log"
 	"net/http"
 	"strings"
 
 	"lol-ranked-new-meta/analytics"
+	"lol-ranked-new-meta/benchmarks"
 	"lol-ranked-new-meta/config"
 	"lol-ranked-new-meta/dashboard"
 	"lol-ranked-new-meta/handlers"
+	"lol-ranked-new-meta/matchcache"
 	"lol-ranked-new-meta/openai"
 	"lol-ranked-new-meta/riot"
+	"lol-ranked-new-meta/staticdata"
 )
 
 func main() {
@@ -22,7 +26,31 @@ func main() {
 
 	// Initialize clients
 	riotClient := riot.NewClient(cfg.RiotAPIKey, cfg.RiotAPIRegion)
+	riotClient.SetAppRateLimit(cfg.RiotAppRateLimit)
+	riotClient.SetBaseURL(cfg.RiotAPIBaseURL)
 	openaiClient := openai.NewClient(cfg.OpenAIAPIKey, cfg.OpenAIModel)
+	openaiClient.SetInputFormat(cfg.OpenAIInputFormat)
+
+	// Initialize match cache (in-memory LRU in front of on-disk storage)
+	matchCache, err := matchcache.New(cfg.MatchCachePath, cfg.MatchCacheSize)
+	if err != nil {
+		log.Printf("Warning: Failed to initialize match cache: %v", err)
+		log.Printf("Riot match data will not be cached")
+		matchCache = nil
+	} else {
+		riotClient.SetCache(matchCache)
+		log.Printf("Match cache enabled (data stored at: %s)", cfg.MatchCachePath)
+	}
+
+	// Initialize Data Dragon static data (item, champion, rune and summoner spell names)
+	staticData, err := staticdata.NewService(cfg.DataDragonPath)
+	if err != nil {
+		log.Printf("Warning: Failed to load Data Dragon static data: %v", err)
+		log.Printf("Match summaries will contain item/spell IDs instead of names")
+		staticData = nil
+	} else {
+		log.Printf("Static data enabled (versions: %s)", strings.Join(staticData.Versions(), ", "))
+	}
 
 	// Initialize analytics tracker
 	// Keep last 100 requests in memory for quick access
@@ -42,7 +70,27 @@ func main() {
 	}
 
 	// Create handlers
-	matchHandler := handlers.NewMatchHandler(riotClient, openaiClient)
+	matchHandler := handlers.NewMatchHandler(riotClient, openaiClient, staticData)
+	verbosity, ok := riot.ParseVerbosity(cfg.SummaryVerbosity)
+	if !ok {
+		log.Printf("Warning: unknown SUMMARY_VERBOSITY %q, using %s", cfg.SummaryVerbosity, verbosity)
+	}
+	matchHandler.SetSummaryOptions(riot.SummaryOptions{
+		Verbosity:   verbosity,
+		TokenBudget: cfg.SummaryBudget(cfg.OpenAIModel),
+	})
+	if cfg.BenchmarksPath != "" {
+		dataset, err := benchmarks.Load(cfg.BenchmarksPath)
+		if err != nil {
+			log.Printf("Warning: Failed to load benchmark dataset: %v", err)
+			log.Printf("Analyses will not include role and rank benchmarks")
+		} else {
+			matchHandler.SetBenchmarks(dataset)
+			log.Printf("Benchmarks enabled (dataset %s: %s, patches %s, queue %d)", dataset.Version, dataset.Source, dataset.Patches, dataset.QueueID)
+		}
+	}
+	playerHandler := handlers.NewPlayerHandler(riotClient)
+	liveHandler := handlers.NewLiveHandler(riotClient, openaiClient, staticData)
 	
 	// Create analytics handler (if tracker is available)
 	var analyticsHandler *handlers.AnalyticsHandler
@@ -72,10 +120,21 @@ func main() {
 	// Set up API routes (must be before frontend to take precedence)
 	mux.HandleFunc("/analyze-match", matchHandler.HandleAnalyzeMatch)
 	mux.HandleFunc("/analyze-match-get", matchHandler.HandleAnalyzeMatchGET) // Convenience GET endpoint
+	mux.HandleFunc("/player/", playerHandler.HandlePlayerMatches)
+	mux.HandleFunc("/live/", liveHandler.HandleLiveGame)
 	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
 		w.Write([]byte("OK"))
 	})
+
+	// Match cache statistics (only if cache is available)
+	if matchCache != nil {
+		mux.HandleFunc("/cache-stats", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(matchCache.Stats())
+		})
+		log.Printf("  GET  /cache-stats - Match cache hit/miss statistics")
+	}
 	
 	// Analytics endpoint (only if tracker is available)
 	if analyticsHandler != nil {
@@ -120,7 +179,7 @@ func main() {
 	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
 		path := r.URL.Path
 		// Serve API routes normally (they're already registered above)
-		if path == "/analyze-match" || path == "/analyze-match-get" || path == "/health" || path == "/analytics" || path == "/whitepaper" || path == "/whitepaper.md" || path == "/riot.txt" || path == "/dashboard-save" {
+		if path == "/analyze-match" || path == "/analyze-match-get" || path == "/health" || path == "/analytics" || path == "/whitepaper" || path == "/whitepaper.md" || path == "/riot.txt" || path == "/dashboard-save" || path == "/cache-stats" {
 			// This won't be reached since those routes are registered first, but good to check
 			return
 		}
@@ -142,6 +201,8 @@ func main() {
 	log.Printf("Endpoints available:")
 	log.Printf("  POST /analyze-match - Analyze a match (requires JSON body with match_id)")
 	log.Printf("  GET  /analyze-match-get?match_id=<match_id> - Analyze a match (convenience endpoint)")
+	log.Printf("  GET  /player/{gameName}-{tagLine}/matches?count=&queue= - List a player's recent matches")
+	log.Printf("  GET  /live/{gameName}-{tagLine}?platform=<platform> - Scouting report and game plan for an active game")
 	log.Printf("  GET  /health - Health check")
 	log.Printf("  GET  /riot.txt - Riot API verification file")
 
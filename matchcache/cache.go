@@ -0,0 +1,175 @@
+package matchcache
+
+import (
+	"container/list"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// Kinds of cached Riot responses
+const (
+	KindMatch    = "match"
+	KindTimeline = "timeline"
+)
+
+// Stats reports cache effectiveness
+type Stats struct {
+	MemoryHits    int64 `json:"memory_hits"`
+	DiskHits      int64 `json:"disk_hits"`
+	Misses        int64 `json:"misses"`
+	Stores        int64 `json:"stores"`
+	MemoryEntries int   `json:"memory_entries"`
+	Capacity      int   `json:"capacity"`
+}
+
+// Cache is a two-tier cache for immutable Riot responses (finished matches and their timelines)
+// Entries live in an in-memory LRU and on disk under basePath/<kind>/<MATCH_ID>.json
+// They hold Riot's response body as received, so fields added to the types later still decode from old entries
+type Cache struct {
+	basePath string
+	capacity int
+	mu       sync.Mutex
+	order    *list.List // Front = most recently used
+	entries  map[string]*list.Element
+	stats    Stats
+}
+
+type entry struct {
+	key   string
+	value []byte
+}
+
+// New creates a cache storing up to capacity entries in memory and everything on disk under basePath
+func New(basePath string, capacity int) (*Cache, error) {
+	for _, kind := range []string{KindMatch, KindTimeline} {
+		if err := os.MkdirAll(filepath.Join(basePath, kind), 0755); err != nil {
+			return nil, fmt.Errorf("failed to create match cache directory: %w", err)
+		}
+	}
+	if capacity < 1 {
+		capacity = 1
+	}
+
+	return &Cache{
+		basePath: basePath,
+		capacity: capacity,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+	}, nil
+}
+
+// Get decodes the cached value for matchID into out, reporting whether it was found
+func (c *Cache) Get(kind, matchID string, out interface{}) bool {
+	key := cacheKey(kind, matchID)
+
+	c.mu.Lock()
+	elem, inMemory := c.entries[key]
+	var value []byte
+	if inMemory {
+		c.order.MoveToFront(elem)
+		value = elem.Value.(*entry).value
+	}
+	c.mu.Unlock()
+
+	if inMemory {
+		// Only a value that decodes counts as a hit
+		decoded := json.Unmarshal(value, out) == nil
+		c.mu.Lock()
+		if decoded {
+			c.stats.MemoryHits++
+		} else {
+			c.stats.Misses++
+		}
+		c.mu.Unlock()
+		return decoded
+	}
+
+	value, err := os.ReadFile(c.filePath(kind, matchID))
+	if err != nil || json.Unmarshal(value, out) != nil {
+		c.mu.Lock()
+		c.stats.Misses++
+		c.mu.Unlock()
+		return false
+	}
+
+	c.mu.Lock()
+	c.stats.DiskHits++
+	c.add(key, value)
+	c.mu.Unlock()
+	return true
+}
+
+// Put stores the raw JSON response body for matchID in memory and on disk
+func (c *Cache) Put(kind, matchID string, data []byte) error {
+	if !json.Valid(data) {
+		return fmt.Errorf("failed to cache entry: response is not valid JSON")
+	}
+
+	c.mu.Lock()
+	c.stats.Stores++
+	c.add(cacheKey(kind, matchID), data)
+	c.mu.Unlock()
+
+	// Write to a temp file first, then rename (atomic write)
+	filePath := c.filePath(kind, matchID)
+	tmpFile := filePath + ".tmp"
+	if err := os.WriteFile(tmpFile, data, 0644); err != nil {
+		return fmt.Errorf("failed to write cache file: %w", err)
+	}
+	if err := os.Rename(tmpFile, filePath); err != nil {
+		os.Remove(tmpFile)
+		return fmt.Errorf("failed to rename cache file: %w", err)
+	}
+	return nil
+}
+
+// Stats returns a snapshot of the cache statistics
+func (c *Cache) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := c.stats
+	stats.MemoryEntries = c.order.Len()
+	stats.Capacity = c.capacity
+	return stats
+}
+
+// add inserts or refreshes an in-memory entry, evicting the least recently used one when full
+// Callers must hold c.mu
+func (c *Cache) add(key string, value []byte) {
+	if elem, ok := c.entries[key]; ok {
+		elem.Value.(*entry).value = value
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&entry{key: key, value: value})
+	for c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*entry).key)
+	}
+}
+
+func (c *Cache) filePath(kind, matchID string) string {
+	return filepath.Join(c.basePath, kind, normalizeMatchID(matchID)+".json")
+}
+
+func cacheKey(kind, matchID string) string {
+	return kind + "/" + normalizeMatchID(matchID)
+}
+
+// normalizeMatchID upper-cases the ID and drops anything that is not safe in a file name
+func normalizeMatchID(matchID string) string {
+	var b strings.Builder
+	for _, r := range strings.ToUpper(strings.TrimSpace(matchID)) {
+		if (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '_' {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
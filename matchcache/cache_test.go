@@ -0,0 +1,143 @@
+package matchcache_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"lol-ranked-new-meta/matchcache"
+)
+
+type match struct {
+	ID       string `json:"id"`
+	Duration int    `json:"duration"`
+}
+
+func newCache(t *testing.T, capacity int) (*matchcache.Cache, string) {
+	t.Helper()
+	dir := t.TempDir()
+	cache, err := matchcache.New(dir, capacity)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	return cache, dir
+}
+
+func put(t *testing.T, cache *matchcache.Cache, kind, matchID, data string) {
+	t.Helper()
+	if err := cache.Put(kind, matchID, []byte(data)); err != nil {
+		t.Fatalf("Put(%s, %s): %v", kind, matchID, err)
+	}
+}
+
+func TestCacheFileNames(t *testing.T) {
+	cache, dir := newCache(t, 10)
+	put(t, cache, matchcache.KindMatch, " euw1_7000000001", `{"id":"EUW1_7000000001"}`)
+	put(t, cache, matchcache.KindTimeline, "EUW1_7000000001", `{"id":"timeline"}`)
+	// Path characters are dropped rather than followed
+	put(t, cache, matchcache.KindMatch, "../na1_1", `{"id":"NA1_1"}`)
+
+	for _, name := range []string{"match/EUW1_7000000001.json", "timeline/EUW1_7000000001.json", "match/NA1_1.json"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("cache file %s: %v", name, err)
+		}
+	}
+	matches, _ := filepath.Glob(filepath.Join(dir, "match", "*"))
+	if len(matches) != 2 {
+		t.Errorf("match directory holds %v, want the two entries and no temp files", matches)
+	}
+
+	// Kinds are separate entries for the same match ID
+	var m match
+	if !cache.Get(matchcache.KindTimeline, "euw1_7000000001", &m) || m.ID != "timeline" {
+		t.Errorf("Get(timeline) = %+v, want the timeline entry", m)
+	}
+}
+
+func TestCacheEviction(t *testing.T) {
+	cache, _ := newCache(t, 2)
+	put(t, cache, matchcache.KindMatch, "EUW1_1", `{"id":"EUW1_1"}`)
+	put(t, cache, matchcache.KindMatch, "EUW1_2", `{"id":"EUW1_2"}`)
+
+	// Reading EUW1_1 makes EUW1_2 the least recently used entry, which EUW1_3 then evicts
+	var m match
+	if !cache.Get(matchcache.KindMatch, "EUW1_1", &m) {
+		t.Fatal("Get(EUW1_1) missed")
+	}
+	put(t, cache, matchcache.KindMatch, "EUW1_3", `{"id":"EUW1_3"}`)
+	if stats := cache.Stats(); stats.MemoryEntries != 2 || stats.Capacity != 2 || stats.MemoryHits != 1 || stats.Stores != 3 {
+		t.Fatalf("Stats = %+v, want 2 of 2 entries, 1 memory hit and 3 stores", stats)
+	}
+
+	tests := []struct {
+		matchID    string
+		memoryHits int64
+		diskHits   int64
+	}{
+		{"EUW1_1", 2, 0},
+		{"EUW1_3", 3, 0},
+		// The evicted entry is read back from disk, which evicts EUW1_1 in turn
+		{"EUW1_2", 3, 1},
+		{"EUW1_2", 4, 1},
+		{"EUW1_1", 4, 2},
+	}
+	for _, tt := range tests {
+		m = match{}
+		if !cache.Get(matchcache.KindMatch, tt.matchID, &m) || m.ID != tt.matchID {
+			t.Fatalf("Get(%s) = %+v, want a hit", tt.matchID, m)
+		}
+		if stats := cache.Stats(); stats.MemoryHits != tt.memoryHits || stats.DiskHits != tt.diskHits || stats.MemoryEntries != 2 {
+			t.Errorf("after Get(%s) Stats = %+v, want %d memory and %d disk hits with 2 entries", tt.matchID, stats, tt.memoryHits, tt.diskHits)
+		}
+	}
+}
+
+func TestCacheReadThrough(t *testing.T) {
+	cache, dir := newCache(t, 10)
+	put(t, cache, matchcache.KindMatch, "EUW1_1", `{"id":"EUW1_1","duration":1800}`)
+
+	// A new cache over the same directory starts with an empty memory tier and reads entries from disk
+	reopened, err := matchcache.New(dir, 10)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	var m match
+	if !reopened.Get(matchcache.KindMatch, "euw1_1", &m) || m.Duration != 1800 {
+		t.Fatalf("Get after reopening = %+v, want the stored match", m)
+	}
+	if !reopened.Get(matchcache.KindMatch, "EUW1_1", &m) {
+		t.Fatal("second Get missed")
+	}
+	if stats := reopened.Stats(); stats.DiskHits != 1 || stats.MemoryHits != 1 || stats.MemoryEntries != 1 {
+		t.Errorf("Stats = %+v, want one disk hit followed by one memory hit", stats)
+	}
+
+	if reopened.Get(matchcache.KindMatch, "EUW1_2", &m) {
+		t.Error("Get of an uncached match hit")
+	}
+	if stats := reopened.Stats(); stats.Misses != 1 {
+		t.Errorf("Misses = %d, want 1", stats.Misses)
+	}
+}
+
+func TestCacheDecodeFailures(t *testing.T) {
+	cache, dir := newCache(t, 10)
+	if err := cache.Put(matchcache.KindMatch, "EUW1_1", []byte(`{"id":`)); err == nil {
+		t.Error("Put of invalid JSON succeeded, want an error")
+	}
+
+	// Valid JSON of the wrong shape is a miss in memory and on disk, not a hit
+	put(t, cache, matchcache.KindMatch, "EUW1_2", `["not","a","match"]`)
+	if err := os.WriteFile(filepath.Join(dir, "match", "EUW1_3.json"), []byte(`{"id":`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	var m match
+	for _, matchID := range []string{"EUW1_1", "EUW1_2", "EUW1_3"} {
+		if cache.Get(matchcache.KindMatch, matchID, &m) {
+			t.Errorf("Get(%s) hit, want a miss", matchID)
+		}
+	}
+	if stats := cache.Stats(); stats.MemoryHits != 0 || stats.DiskHits != 0 || stats.Misses != 3 || stats.Stores != 1 || stats.MemoryEntries != 1 {
+		t.Errorf("Stats = %+v, want 3 misses, no hits and only the stored entry in memory", stats)
+	}
+}
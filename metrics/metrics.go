@@ -0,0 +1,116 @@
+// Package metrics computes derived per-participant and per-team numbers from match-v5 data
+// Every value is deterministic, so the frontend, dashboards and prompts can all cite the same numbers
+package metrics
+
+import (
+	"math"
+
+	"lol-ranked-new-meta/types"
+)
+
+// Compute derives participant and team metrics from a match
+// Arena duos are treated as teams; returns nil for a nil match
+func Compute(match *types.RiotMatch) *types.MatchMetrics {
+	if match == nil {
+		return nil
+	}
+	minutes := float64(match.Info.GameDuration) / 60.0
+
+	teams := make(map[int]*types.TeamMetrics)
+	var order []int
+	for i := range match.Info.Participants {
+		p := &match.Info.Participants[i]
+		id := TeamKey(p)
+		team, ok := teams[id]
+		if !ok {
+			team = &types.TeamMetrics{TeamID: id, Win: p.Win}
+			teams[id] = team
+			order = append(order, id)
+		}
+		team.Kills += p.Kills
+		team.Deaths += p.Deaths
+		team.Assists += p.Assists
+		team.GoldEarned += p.GoldEarned
+		team.DamageToChamps += p.TotalDamageDealtToChampions
+		team.CS += CreepScore(p)
+		team.VisionScore += p.VisionScore
+	}
+
+	result := &types.MatchMetrics{GameMinutes: round(minutes, 2)}
+	for _, id := range order {
+		team := teams[id]
+		team.CSPerMinute = round(perMinute(team.CS, minutes), 2)
+		team.VisionPerMinute = round(perMinute(team.VisionScore, minutes), 2)
+		team.DamagePerMinute = round(perMinute(team.DamageToChamps, minutes), 1)
+		team.GoldPerMinute = round(perMinute(team.GoldEarned, minutes), 1)
+		team.DamagePerGold = round(ratio(team.DamageToChamps, team.GoldEarned), 3)
+		team.DeathsPer10 = round(perMinute(team.Deaths, minutes)*10, 2)
+		result.Teams = append(result.Teams, *team)
+	}
+
+	for i := range match.Info.Participants {
+		p := &match.Info.Participants[i]
+		team := teams[TeamKey(p)]
+		result.Participants = append(result.Participants, types.ParticipantMetrics{
+			Puuid:             p.Puuid,
+			ParticipantID:     p.ParticipantID,
+			SummonerName:      p.SummonerName,
+			ChampionName:      p.ChampionName,
+			TeamID:            team.TeamID,
+			KillParticipation: round(ratio(p.Kills+p.Assists, team.Kills), 3),
+			DamageShare:       round(ratio(p.TotalDamageDealtToChampions, team.DamageToChamps), 3),
+			GoldShare:         round(ratio(p.GoldEarned, team.GoldEarned), 3),
+			CSPerMinute:       round(perMinute(CreepScore(p), minutes), 2),
+			VisionPerMinute:   round(perMinute(p.VisionScore, minutes), 2),
+			DamagePerMinute:   round(perMinute(p.TotalDamageDealtToChampions, minutes), 1),
+			DamagePerGold:     round(ratio(p.TotalDamageDealtToChampions, p.GoldEarned), 3),
+			DeathsPer10:       round(perMinute(p.Deaths, minutes)*10, 2),
+		})
+	}
+	return result
+}
+
+// TeamKey groups a participant with their team, or with their duo in Arena
+func TeamKey(p *types.RiotParticipant) int {
+	if p.PlayerSubteamID > 0 {
+		return p.PlayerSubteamID
+	}
+	return p.TeamID
+}
+
+// ForParticipant finds a participant's metrics by participant ID
+func ForParticipant(m *types.MatchMetrics, participantID int) (types.ParticipantMetrics, bool) {
+	if m == nil {
+		return types.ParticipantMetrics{}, false
+	}
+	for _, pm := range m.Participants {
+		if pm.ParticipantID == participantID {
+			return pm, true
+		}
+	}
+	return types.ParticipantMetrics{}, false
+}
+
+// CreepScore counts lane minions and jungle monsters; it is the one CS definition used across the summary
+func CreepScore(p *types.RiotParticipant) int {
+	return p.TotalMinionsKilled + p.NeutralMinionsKilled
+}
+
+func perMinute(value int, minutes float64) float64 {
+	if minutes <= 0 {
+		return 0
+	}
+	return float64(value) / minutes
+}
+
+func ratio(part, whole int) float64 {
+	if whole <= 0 {
+		return 0
+	}
+	return float64(part) / float64(whole)
+}
+
+func round(value float64, places int) float64 {
+	scale := math.Pow(10, float64(places))
+	return math.Round(value*scale) / scale
+}
@@ -0,0 +1,112 @@
+package metrics_test
+
+import (
+	"testing"
+
+	"lol-ranked-new-meta/metrics"
+	"lol-ranked-new-meta/riottest"
+	"lol-ranked-new-meta/types"
+)
+
+func TestCompute(t *testing.T) {
+	match := riottest.FixtureMatch()
+	m := metrics.Compute(match)
+	if m.GameMinutes != 30.78 {
+		t.Errorf("GameMinutes = %v, want 30.78", m.GameMinutes)
+	}
+	if len(m.Participants) != 10 || len(m.Teams) != 2 {
+		t.Fatalf("got %d participants and %d teams, want 10 and 2", len(m.Participants), len(m.Teams))
+	}
+
+	pm, ok := metrics.ForParticipant(m, 1)
+	if !ok {
+		t.Fatal("no metrics for participant 1")
+	}
+	want := types.ParticipantMetrics{
+		Puuid: "fixture-puuid-01", ParticipantID: 1, SummonerName: "Fenrir", ChampionName: "Aatrox", TeamID: 100,
+		KillParticipation: 0.406, DamageShare: 0.223, GoldShare: 0.209,
+		CSPerMinute: 7.21, VisionPerMinute: 0.58, DamagePerMinute: 789.7, DamagePerGold: 1.853, DeathsPer10: 0.97,
+	}
+	if pm != want {
+		t.Errorf("participant 1 = %+v, want %+v", pm, want)
+	}
+	if _, ok := metrics.ForParticipant(m, 11); ok {
+		t.Error("found metrics for a participant not in the match")
+	}
+
+	// Team totals are the sums over the team's players, with CS counting minions and monsters
+	for _, tm := range m.Teams {
+		kills, cs := 0, 0
+		for i := range match.Info.Participants {
+			p := &match.Info.Participants[i]
+			if p.TeamID == tm.TeamID {
+				kills += p.Kills
+				cs += metrics.CreepScore(p)
+			}
+		}
+		if tm.Kills != kills || tm.CS != cs {
+			t.Errorf("team %d: kills %d, CS %d, want %d and %d", tm.TeamID, tm.Kills, tm.CS, kills, cs)
+		}
+	}
+}
+
+func TestComputeArenaDuos(t *testing.T) {
+	match := riottest.FixtureMatch()
+	for i := range match.Info.Participants {
+		match.Info.Participants[i].PlayerSubteamID = i/2 + 1
+	}
+	m := metrics.Compute(match)
+	if len(m.Teams) != 5 {
+		t.Errorf("got %d teams, want one per duo (5)", len(m.Teams))
+	}
+	if pm, _ := metrics.ForParticipant(m, 3); pm.TeamID != 2 {
+		t.Errorf("participant 3 team = %d, want duo 2", pm.TeamID)
+	}
+}
+
+func TestCreepScore(t *testing.T) {
+	p := &riottest.FixtureMatch().Info.Participants[0]
+	if cs := metrics.CreepScore(p); cs != 222 {
+		t.Errorf("CreepScore = %d, want 222 (214 minions + 8 monsters)", cs)
+	}
+}
+
+func TestCompareTeams(t *testing.T) {
+	match := riottest.FixtureMatch()
+	cmp := metrics.CompareTeams(match)
+	if cmp == nil {
+		t.Fatal("CompareTeams returned nil for a two-team match")
+	}
+	if cmp.Teams[0].TeamID != 100 || !cmp.Teams[0].Win {
+		t.Errorf("first team = %+v, want the winning blue side", cmp.Teams[0])
+	}
+	// CC time sums totalTimeCCDealt (seconds), not the weighted timeCCingOthers score (122 per team)
+	if cmp.Teams[0].CCTime != 760 || cmp.Teams[1].CCTime != 760 {
+		t.Errorf("CC time = %d and %d seconds, want 760 each", cmp.Teams[0].CCTime, cmp.Teams[1].CCTime)
+	}
+	wantDiff := types.TeamDifferential{
+		Kills: 16, GoldEarned: 12610, DamageToChampions: 26580, DamageToBuildings: 13735, VisionScore: 32,
+		Turrets: 7, Inhibitors: 1, Dragons: 2, Heralds: 1, Barons: 1,
+	}
+	if cmp.Differential != wantDiff {
+		t.Errorf("differential = %+v, want %+v", cmp.Differential, wantDiff)
+	}
+	wantFirsts := types.FirstObjectives{FirstBlood: 100, FirstTower: 100, FirstInhibitor: 100, FirstDragon: 100, FirstHerald: 100, FirstBaron: 100}
+	if cmp.Firsts != wantFirsts {
+		t.Errorf("firsts = %+v, want %+v", cmp.Firsts, wantFirsts)
+	}
+
+	// Blue side stays the reference when red is listed first
+	match.Info.Teams[0], match.Info.Teams[1] = match.Info.Teams[1], match.Info.Teams[0]
+	if swapped := metrics.CompareTeams(match); swapped.Teams[0].TeamID != 100 || swapped.Differential != wantDiff {
+		t.Errorf("with red listed first got %+v, want blue first and the same differential", swapped)
+	}
+}
+
+func TestCompareTeamsArena(t *testing.T) {
+	match := riottest.FixtureMatch()
+	match.Info.Participants[0].PlayerSubteamID = 1
+	if cmp := metrics.CompareTeams(match); cmp != nil {
+		t.Errorf("CompareTeams = %+v, want nil for Arena duos", cmp)
+	}
+}
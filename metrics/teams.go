@@ -0,0 +1,78 @@
+package metrics
+
+import "lol-ranked-new-meta/types"
+
+// CompareTeams totals each team's players and objectives and computes the differential
+// Returns nil unless the match has exactly two teams (e.g. Arena duos are not compared)
+func CompareTeams(match *types.RiotMatch) *types.TeamComparison {
+	if match == nil || len(match.Info.Teams) != 2 {
+		return nil
+	}
+	for _, p := range match.Info.Participants {
+		if p.PlayerSubteamID > 0 {
+			return nil
+		}
+	}
+
+	cmp := &types.TeamComparison{}
+	for _, team := range match.Info.Teams {
+		totals := types.TeamTotals{
+			TeamID:     team.TeamID,
+			Win:        team.Win,
+			Kills:      team.Objectives.Champion.Kills,
+			Turrets:    team.Objectives.Tower.Kills,
+			Inhibitors: team.Objectives.Inhibitor.Kills,
+			Dragons:    team.Objectives.Dragon.Kills,
+			Heralds:    team.Objectives.RiftHerald.Kills,
+			Barons:     team.Objectives.Baron.Kills,
+		}
+		for _, p := range match.Info.Participants {
+			if p.TeamID != team.TeamID {
+				continue
+			}
+			totals.GoldEarned += p.GoldEarned
+			totals.DamageToChampions += p.TotalDamageDealtToChampions
+			totals.DamageToBuildings += p.DamageDealtToBuildings
+			totals.VisionScore += p.VisionScore
+			totals.CCTime += p.TotalTimeCCDealt
+		}
+		cmp.Teams = append(cmp.Teams, totals)
+
+		firsts := []struct {
+			objective types.RiotObjective
+			teamID    *int
+		}{
+			{team.Objectives.Champion, &cmp.Firsts.FirstBlood},
+			{team.Objectives.Tower, &cmp.Firsts.FirstTower},
+			{team.Objectives.Inhibitor, &cmp.Firsts.FirstInhibitor},
+			{team.Objectives.Dragon, &cmp.Firsts.FirstDragon},
+			{team.Objectives.RiftHerald, &cmp.Firsts.FirstHerald},
+			{team.Objectives.Baron, &cmp.Firsts.FirstBaron},
+		}
+		for _, f := range firsts {
+			if f.objective.First {
+				*f.teamID = team.TeamID
+			}
+		}
+	}
+
+	// Blue side (team 100) is always the reference; match-v5 usually lists it first
+	if cmp.Teams[0].TeamID != 100 && cmp.Teams[1].TeamID == 100 {
+		cmp.Teams[0], cmp.Teams[1] = cmp.Teams[1], cmp.Teams[0]
+	}
+	a, b := cmp.Teams[0], cmp.Teams[1]
+	cmp.Differential = types.TeamDifferential{
+		Kills:             a.Kills - b.Kills,
+		GoldEarned:        a.GoldEarned - b.GoldEarned,
+		DamageToChampions: a.DamageToChampions - b.DamageToChampions,
+		DamageToBuildings: a.DamageToBuildings - b.DamageToBuildings,
+		VisionScore:       a.VisionScore - b.VisionScore,
+		CCTime:            a.CCTime - b.CCTime,
+		Turrets:           a.Turrets - b.Turrets,
+		Inhibitors:        a.Inhibitors - b.Inhibitors,
+		Dragons:           a.Dragons - b.Dragons,
+		Heralds:           a.Heralds - b.Heralds,
+		Barons:            a.Barons - b.Barons,
+	}
+	return cmp
+}
@@ -4,29 +4,50 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"strings"
 
 	openai "github.com/sashabaranov/go-openai"
 	"lol-ranked-new-meta/types"
 )
 
 type Client struct {
-	client *openai.Client
-	model  string
+	client      *openai.Client
+	apiKey      string
+	model       string
+	inputFormat string // InputFormatText or InputFormatJSON; anything else means text
 }
 
 // NewClient creates a new OpenAI client
 func NewClient(apiKey, model string) *Client {
 	return &Client{
 		client: openai.NewClient(apiKey),
+		apiKey: apiKey,
 		model:  model,
 	}
 }
 
+// SetBaseURL points the client at another OpenAI-compatible API, e.g. a fake server in tests
+func (c *Client) SetBaseURL(baseURL string) {
+	config := openai.DefaultConfig(c.apiKey)
+	config.BaseURL = baseURL
+	c.client = openai.NewClientWithConfig(config)
+}
+
+// SetInputFormat selects the match data format sent to the model ("" restores text)
+func (c *Client) SetInputFormat(format string) {
+	c.inputFormat = format
+}
+
+// InputFormat returns the match data format to prompt with: InputFormatJSON when selected, otherwise InputFormatText
+func (c *Client) InputFormat() string {
+	if c.inputFormat == InputFormatJSON {
+		return InputFormatJSON
+	}
+	return InputFormatText
+}
+
 // AnalyzeMatch analyzes a League of Legends match and provides coaching advice
-// championFilter and summonerFilter are optional - if provided, will generate a deep dive analysis
-// focusAreas specifies which data aspects to analyze deeply (combat, vision, objectives, items, matchup, economy, farming)
-func (c *Client) AnalyzeMatch(ctx context.Context, matchSummary string, championFilter, summonerFilter string, focusAreas []string) (*types.MatchResponse, error) {
+// opts selects the deep dive target, focus areas and game mode; all fields are optional
+func (c *Client) AnalyzeMatch(ctx context.Context, matchSummary string, opts AnalysisOptions) (*types.MatchResponse, error) {
 	// Define the function schema for structured output
 	analyzeMatchFunction := openai.FunctionDefinition{
 		Name:        "analyze_match",
@@ -65,12 +86,16 @@ Your analysis must:
 - Identify what ACTUALLY happened, not generic patterns
 - Compare actual performance vs opponents using real data
 - Explain WHY specific events mattered based on the match outcome
-- Avoid inventing timelines, timestamps, or item names if they are not in the data`
+- Avoid inventing timelines, timestamps, or item names if they are not in the data
+- Pitch advice at the lobby's skill level when LOBBY RANKS are provided (fundamentals for Iron-Silver, finer macro and matchup detail for Diamond and above)
+- Explain why the winning team won from the TEAM COMPARISON differentials (gold, damage, vision, objectives and firsts; the t and f keys of compact JSON match data) rather than guessing
+- Judge the player's numbers against ROLE & RANK BENCHMARKS when provided (e.g. "6.2 CS/min, 35th percentile for Gold ADC") instead of calling them above or below average
+- Comment on summoner spell economy from SUMMONER SPELL USAGE (Flash trades, Ignite kill conversion, Teleport plays vs the lane opponent) when it is provided`
 
-	focusAreasNote := ""
-	if len(focusAreas) > 0 {
-		focusAreasNote = fmt.Sprintf("\n\nSPECIAL FOCUS: Pay extra attention to these aspects: %s", strings.Join(focusAreas, ", "))
-	}
+	systemPrompt += modeGuidance(opts.Queue)
+	systemPrompt += languageGuidance(opts.Language)
+
+	focusAreasNote := focusNote(opts.FocusAreas)
 
 	userPrompt := fmt.Sprintf(`Analyze this EXACT League of Legends match using the specific data provided:
 
@@ -143,7 +168,7 @@ Focus on what actually happened, not generic coaching advice.`, matchSummary, fo
 			}
 		}
 
-		deepDive, err := c.AnalyzeChampionDeepDive(ctx, matchSummary, championFilter, summonerFilter, focusAreas)
+		deepDive, err := c.AnalyzeChampionDeepDive(ctx, matchSummary, opts)
 		if err != nil {
 			// Log error but don't fail the whole request
 			response.ChampionDeepDive = "Failed to generate deep dive analysis: " + err.Error()
@@ -152,7 +177,7 @@ Focus on what actually happened, not generic coaching advice.`, matchSummary, fo
 		}
 
 		// Generate structured insights for interactive frontend
-		structuredInsights, err := c.GenerateStructuredInsights(ctx, matchSummary, championFilter, summonerFilter, focusAreas)
+		structuredInsights, err := c.GenerateStructuredInsights(ctx, matchSummary, opts)
 		if err == nil {
 			response.StructuredInsights = structuredInsights
 		}
@@ -163,7 +188,7 @@ Focus on what actually happened, not generic coaching advice.`, matchSummary, fo
 	// Fallback: extract from content if function calling didn't work as expected
 	response := c.extractFromContent(choice.Message.Content)
 
-	deepDive, err := c.AnalyzeChampionDeepDive(ctx, matchSummary, championFilter, summonerFilter, focusAreas)
+	deepDive, err := c.AnalyzeChampionDeepDive(ctx, matchSummary, opts)
 	if err != nil {
 		// Log error but don't fail the whole request
 		response.ChampionDeepDive = "Failed to generate deep dive analysis: " + err.Error()
@@ -172,7 +197,7 @@ Focus on what actually happened, not generic coaching advice.`, matchSummary, fo
 	}
 
 	// Generate structured insights for interactive frontend
-	structuredInsights, err := c.GenerateStructuredInsights(ctx, matchSummary, championFilter, summonerFilter, focusAreas)
+	structuredInsights, err := c.GenerateStructuredInsights(ctx, matchSummary, opts)
 	if err == nil {
 		response.StructuredInsights = structuredInsights
 	}
@@ -181,10 +206,10 @@ Focus on what actually happened, not generic coaching advice.`, matchSummary, fo
 }
 
 // AnalyzeChampionDeepDive provides a detailed analysis focused on a specific champion
-func (c *Client) AnalyzeChampionDeepDive(ctx context.Context, matchSummary, championFilter, summonerFilter string, focusAreas []string) (string, error) {
-	targetName := championFilter
-	if summonerFilter != "" {
-		targetName = summonerFilter
+func (c *Client) AnalyzeChampionDeepDive(ctx context.Context, matchSummary string, opts AnalysisOptions) (string, error) {
+	targetName := opts.ChampionFilter
+	if opts.SummonerFilter != "" {
+		targetName = opts.SummonerFilter
 	}
 	if targetName == "" {
 		targetName = "the auto-selected focus player"
@@ -201,13 +226,15 @@ Your analysis must:
 - Analyze item builds in context of the actual opponent champions faced
 - Identify concrete mistakes using specific match statistics
 - Highlight specific good plays using actual numbers and achievements
+- Match the depth of advice to the lobby's rank when LOBBY RANKS are provided
+- Adjust to the player's Champion Mastery when provided: with little experience on the champion, prioritize champion fundamentals (combos, power spikes, core build); with a lot, skip the basics and focus on matchup, wave and macro decisions
 
 Avoid generic advice like "ward more" - instead say "placed only X wards compared to opponent's Y" with specific impact.`
 
-	focusAreasNote := ""
-	if len(focusAreas) > 0 {
-		focusAreasNote = fmt.Sprintf("\n\nSPECIAL FOCUS: Pay extra attention to these aspects: %s", strings.Join(focusAreas, ", "))
-	}
+	systemPrompt += modeGuidance(opts.Queue)
+	systemPrompt += languageGuidance(opts.Language)
+
+	focusAreasNote := focusNote(opts.FocusAreas)
 
 	userPrompt := fmt.Sprintf(`Analyze the performance of %s in this EXACT match. Use the actual data provided.
 
@@ -249,11 +276,60 @@ Provide analysis focusing on SPECIFIC EVENTS AND NUMBERS from this match:
 	return resp.Choices[0].Message.Content, nil
 }
 
+// GenerateLiveGamePlan writes a lane-by-lane game plan for an active game from its scouting summary
+func (c *Client) GenerateLiveGamePlan(ctx context.Context, liveGameSummary string) (string, error) {
+	systemPrompt := `You are an expert League of Legends coach preparing a player during the loading screen.
+CRITICAL: Only use the provided scouting data (champions, spells, ranks, recent form). Do not invent stats or match history.
+Positions are inferred and may be wrong; say so when a plan depends on an uncertain role.
+
+Your game plan must:
+- Be short enough to read in under a minute
+- Go lane by lane (top, jungle, mid, bot, support) for the requested player's team
+- Point out enemy threats using their recent form and rank (e.g. a player on a win streak or with many games on their champion)
+- Suggest win conditions and objective priorities for the requested player's team`
+
+	userPrompt := fmt.Sprintf(`Write a pre-game plan for the REQUESTED PLAYER's team in this live game:
+
+%s
+
+Structure the plan as:
+1. Win condition - one or two sentences
+2. Lane by lane - one short paragraph per lane with the matchup, the threat and what to do
+3. Players to watch - enemies whose rank or recent form stands out
+4. Objective plan - early dragon/herald/grub priorities based on the compositions`, liveGameSummary)
+
+	req := openai.ChatCompletionRequest{
+		Model: c.model,
+		Messages: []openai.ChatCompletionMessage{
+			{
+				Role:    openai.ChatMessageRoleSystem,
+				Content: systemPrompt,
+			},
+			{
+				Role:    openai.ChatMessageRoleUser,
+				Content: userPrompt,
+			},
+		},
+		Temperature: 0.3,
+	}
+
+	resp, err := c.client.CreateChatCompletion(ctx, req)
+	if err != nil {
+		return "", fmt.Errorf("failed to create live game plan: %w", err)
+	}
+
+	if len(resp.Choices) == 0 {
+		return "", fmt.Errorf("no choices in response")
+	}
+
+	return resp.Choices[0].Message.Content, nil
+}
+
 // GenerateStructuredInsights creates structured, data-driven insights for interactive frontend
-func (c *Client) GenerateStructuredInsights(ctx context.Context, matchSummary, championFilter, summonerFilter string, focusAreas []string) (*types.StructuredInsights, error) {
-	targetName := championFilter
-	if summonerFilter != "" {
-		targetName = summonerFilter
+func (c *Client) GenerateStructuredInsights(ctx context.Context, matchSummary string, opts AnalysisOptions) (*types.StructuredInsights, error) {
+	targetName := opts.ChampionFilter
+	if opts.SummonerFilter != "" {
+		targetName = opts.SummonerFilter
 	}
 	if targetName == "" {
 		targetName = "the auto-selected focus player"
@@ -313,6 +389,13 @@ func (c *Client) GenerateStructuredInsights(ctx context.Context, matchSummary, c
 						"recommendations":  map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}},
 					},
 				},
+				"rune_analysis": map[string]interface{}{
+					"type": "object",
+					"properties": map[string]interface{}{
+						"evaluation":      map[string]interface{}{"type": "string"},
+						"recommendations": map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}},
+					},
+				},
 				"matchup_analysis": map[string]interface{}{
 					"type": "object",
 					"properties": map[string]interface{}{
@@ -382,10 +465,10 @@ CRITICAL: Only reference specific numbers, stats, and events from the provided m
 Each insight must cite actual data (e.g., "Died 3 times before 10 minutes" not "died early").
 If the data does not provide timing or item names, explicitly note that it is unavailable.`
 
-	focusAreasNote := ""
-	if len(focusAreas) > 0 {
-		focusAreasNote = fmt.Sprintf("\n\nSPECIAL FOCUS: Pay extra attention to these aspects: %s", strings.Join(focusAreas, ", "))
-	}
+	systemPrompt += modeGuidance(opts.Queue)
+	systemPrompt += languageGuidance(opts.Language)
+
+	focusAreasNote := focusNote(opts.FocusAreas)
 
 	userPrompt := fmt.Sprintf(`Generate structured insights for %s in this match. Use ONLY the actual data provided:
 
@@ -396,8 +479,9 @@ Return structured data with:
 2. What went wrong - specific failures with supporting data
 3. Critical moments - game-changing events with context
 4. Item analysis - evaluate items vs actual opponent champions
-5. Matchup analysis - compare actual performance vs lane opponent
-6. Key statistics - 1-3 key stats per category (combat, objectives, economy, vision)`, targetName, matchSummary, focusAreasNote)
+5. Rune analysis - evaluate the rune page vs the matchup, citing the recorded rune values (e.g. keystone damage or healing)
+6. Matchup analysis - compare actual performance vs lane opponent
+7. Key statistics - 1-3 key stats per category (combat, objectives, economy, vision)`, targetName, matchSummary, focusAreasNote)
 
 	req := openai.ChatCompletionRequest{
 		Model: c.model,
@@ -458,6 +542,17 @@ Return structured data with:
 			}
 		}
 
+		// Parse rune_analysis
+		if ra, ok := rawData["rune_analysis"].(map[string]interface{}); ok {
+			insights.RuneAnalysis = &types.RuneAnalysis{}
+			if ev, ok := ra["evaluation"].(string); ok {
+				insights.RuneAnalysis.Evaluation = ev
+			}
+			if recs, ok := ra["recommendations"].([]interface{}); ok {
+				insights.RuneAnalysis.Recommendations = parseStringArray(recs)
+			}
+		}
+
 		// Parse matchup_analysis
 		if ma, ok := rawData["matchup_analysis"].(map[string]interface{}); ok {
 			insights.MatchupAnalysis = &types.MatchupAnalysis{}
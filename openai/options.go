@@ -0,0 +1,79 @@
+package openai
+
+import (
+	"fmt"
+	"strings"
+
+	"lol-ranked-new-meta/types"
+)
+
+// AnalysisOptions tunes a match analysis
+// Every field is optional: without filters the summary's auto-selected target is analyzed
+type AnalysisOptions struct {
+	ChampionFilter string
+	SummonerFilter string
+	FocusAreas     []string        // Data aspects to analyze deeply (combat, vision, objectives, items, matchup, economy, farming, communication)
+	Queue          types.QueueInfo // Queue and map of the match; non-rift modes get mode-specific instructions
+	Language       string          // English name of the language to answer in, e.g. "German"; empty means English
+}
+
+// Match data formats a model can be prompted with
+const (
+	InputFormatText = "text" // Prose summary
+	InputFormatJSON = "json" // Compact JSON scoreboard plus prose sections
+)
+
+// modeGuidance returns system prompt instructions for modes where Summoner's Rift advice does not apply
+func modeGuidance(queue types.QueueInfo) string {
+	switch queue.Format {
+	case types.FormatARAM:
+		return `
+
+GAME MODE: ARAM on Howling Abyss - a single lane with random champions.
+There are no lanes, lane opponents, jungle, dragons, barons or meaningful warding.
+Do NOT give lane opponent, jungling, objective or vision advice. Focus on teamfight positioning, poke and engage, health relic timing, build adaptation and death timers.`
+	case types.FormatArena:
+		return `
+
+GAME MODE: Arena - 16 players in eight duos (subteams) fight 2v2 rounds, and each duo finishes with a placement from 1st to 8th.
+There are no lanes, minions, towers or objectives, so CS, vision and objective stats are meaningless.
+Judge performance by the duo's placement, augment choices, item choices and round-to-round fighting; never give lane, farming, vision or objective advice.`
+	case types.FormatRotating:
+		return fmt.Sprintf(`
+
+GAME MODE: %s on %s - a featured/rotating mode with non-standard rules.
+Ranked Summoner's Rift benchmarks (CS/min, vision score, lane matchups) may not apply; say so instead of judging against them.`, queue.Name, queue.MapName)
+	}
+	if queue.Custom {
+		return `
+
+GAME MODE: Custom game - positions and matchups may not follow normal matchmaking, so treat lane assignments as uncertain.`
+	}
+	return ""
+}
+
+// languageGuidance returns system prompt instructions for answering in a language other than English
+func languageGuidance(language string) string {
+	if language == "" || strings.EqualFold(language, "English") {
+		return ""
+	}
+	return fmt.Sprintf(`
+
+LANGUAGE: Write every text value of your answer in %s, using the terms %s-speaking League of Legends players use.
+Keep JSON keys, category values and numbers unchanged. Use champion, item, rune and summoner spell names exactly as they appear in the match data.`, language, language)
+}
+
+// focusNote returns the user prompt note for the requested focus areas
+func focusNote(focusAreas []string) string {
+	if len(focusAreas) == 0 {
+		return ""
+	}
+	note := fmt.Sprintf("\n\nSPECIAL FOCUS: Pay extra attention to these aspects: %s", strings.Join(focusAreas, ", "))
+	for _, area := range focusAreas {
+		if strings.EqualFold(strings.TrimSpace(area), types.FocusCommunication) {
+			note += "\nCOMMUNICATION: Use the COMMUNICATION section's ping counts, mix and styles and compare the target with teammates. Relate pinging to what happened (e.g. heavy ? pings after deaths, a silent jungler) and keep the advice constructive. Chat logs are not available; do not speculate about them."
+			break
+		}
+	}
+	return note
+}
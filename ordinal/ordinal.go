@@ -0,0 +1,19 @@
+// Package ordinal formats English ordinal numbers for placements and percentiles
+package ordinal
+
+import "fmt"
+
+// English formats 1 as "1st", 2 as "2nd" and so on
+func English(n int) string {
+	suffix := "th"
+	switch {
+	case n%100 >= 11 && n%100 <= 13:
+	case n%10 == 1:
+		suffix = "st"
+	case n%10 == 2:
+		suffix = "nd"
+	case n%10 == 3:
+		suffix = "rd"
+	}
+	return fmt.Sprintf("%d%s", n, suffix)
+}
@@ -0,0 +1,15 @@
+package ordinal_test
+
+import (
+	"testing"
+
+	"lol-ranked-new-meta/ordinal"
+)
+
+func TestEnglish(t *testing.T) {
+	for n, want := range map[int]string{1: "1st", 2: "2nd", 3: "3rd", 4: "4th", 11: "11th", 12: "12th", 13: "13th", 21: "21st", 22: "22nd", 99: "99th", 101: "101st", 111: "111th"} {
+		if got := ordinal.English(n); got != want {
+			t.Errorf("English(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package riot
+
+import (
+	"lol-ranked-new-meta/benchmarks"
+	"lol-ranked-new-meta/metrics"
+	"lol-ranked-new-meta/types"
+)
+
+// RateAgainstBenchmarks rates the participant against players of their resolved role and current tier
+// roles is ResolveRoles(match); ranks is optional - without the player's rank the all-tiers benchmark is used.
+// Returns nil without a dataset, and for matches outside the dataset's queue (a flex or normal game is not rated against solo/duo).
+func RateAgainstBenchmarks(dataset *benchmarks.Dataset, match *types.RiotMatch, roles map[int]types.RoleAssignment, participant *types.RiotParticipant, ranks []types.PlayerRank) *types.BenchmarkReport {
+	if dataset == nil || match == nil || participant == nil || match.Info.QueueID != dataset.QueueID {
+		return nil
+	}
+	position := ResolvedPosition(roles, participant)
+	if position == "" {
+		return nil
+	}
+	pm, ok := metrics.ForParticipant(metrics.Compute(match), participant.ParticipantID)
+	if !ok {
+		return nil
+	}
+	tier, source := benchmarks.AllTiers, "all_tiers"
+	for _, r := range ranks {
+		if r.Puuid != participant.Puuid {
+			continue
+		}
+		switch {
+		case r.SoloDuo != nil:
+			tier, source = r.SoloDuo.Tier, "solo_duo"
+		case r.Flex != nil:
+			tier, source = r.Flex.Tier, "flex"
+		}
+		break
+	}
+
+	report := dataset.Rate(position, tier, pm)
+	if report == nil {
+		return nil
+	}
+	report.TierSource = source
+	if report.Tier == benchmarks.AllTiers {
+		report.TierSource = "all_tiers"
+	}
+	return report
+}
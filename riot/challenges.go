@@ -0,0 +1,80 @@
+package riot
+
+import (
+	"fmt"
+
+	"lol-ranked-new-meta/types"
+)
+
+// FormatChallengeMetrics lists the participant's challenge metrics for the deep dive, labelled in lang
+// Lane metrics are only listed for participants with a resolved lane position (roles is ResolveRoles(match)).
+// Kill participation, damage per minute and vision per minute come from the metrics package instead, so they are not repeated here.
+func FormatChallengeMetrics(participant *types.RiotParticipant, roles map[int]types.RoleAssignment, lang Language) string {
+	c, ok := participant.TypedChallenges()
+	if !ok {
+		return ""
+	}
+
+	var detail string
+	detail += lang.sprintf("- Damage Taken Share: %.0f%% of team damage taken", c.DamageTakenOnTeamPercentage*100) + "\n"
+	detail += lang.sprintf("- Takedowns Before 10:00: %d", c.TakedownsFirstXMinutes) + "\n"
+	detail += lang.sprintf("- Solo Kills: %d, Outnumbered Kills: %d, Picks With an Ally: %d", c.SoloKills, c.OutnumberedKills, c.PickKillWithAlly) + "\n"
+	detail += lang.sprintf("- Skillshots Hit: %d, Skillshots Dodged: %d", c.SkillshotsHit, c.SkillshotsDodged) + "\n"
+	detail += lang.sprintf("- Enemy Champion Immobilizations: %d", c.EnemyChampionImmobilizations) + "\n"
+	detail += lang.sprintf("- Saved an Ally From Death: %d, Survived on Single-Digit HP: %d", c.SaveAllyFromDeath, c.SurvivedSingleDigitHpCount) + "\n"
+
+	if position := ResolvedPosition(roles, participant); position != "" {
+		if position == types.PositionJungle {
+			detail += lang.sprintf("- Jungle CS Before 10:00: %.0f", c.JungleCsBefore10Minutes) + "\n"
+			detail += lang.sprintf("- Enemy Jungle Monsters Killed: %.0f, Buffs Stolen: %d", c.EnemyJungleMonsterKills, c.BuffsStolen) + "\n"
+		} else {
+			detail += lang.sprintf("- Lane Minions by 10:00: %d", c.LaneMinionsFirst10Minutes) + "\n"
+			detail += lang.sprintf("- Turret Plates Taken: %d", c.TurretPlatesTaken) + "\n"
+		}
+		detail += lang.sprintf("- Max CS Lead on Lane Opponent: %.0f, Max Level Lead: %d", c.MaxCsAdvantageOnLaneOpponent, c.MaxLevelLeadLaneOpponent) + "\n"
+		detail += lang.sprintf("- Ahead in Gold and XP at 7:00: %s, at 14:00: %s",
+			lang.label(yesNo(c.EarlyLaningPhaseGoldExpAdvantage > 0)), lang.label(yesNo(c.LaningPhaseGoldExpAdvantage > 0))) + "\n"
+		detail += lang.sprintf("- Vision Score Advantage vs Lane Opponent: %+.0f%%", c.VisionScoreAdvantageLaneOpponent*100) + "\n"
+	}
+
+	detail += lang.sprintf("- Objective Takedowns: %d turrets, %d dragons, %d barons, %d heralds",
+		c.TurretTakedowns, c.DragonTakedowns, c.BaronTakedowns, c.RiftHeraldTakedowns) + "\n"
+	detail += lang.sprintf("- Control Wards Placed: %d, Wards Cleared: %d", c.ControlWardsPlaced, c.WardTakedowns) + "\n"
+	return detail
+}
+
+// ChallengeStatPairs picks the headline challenge metrics for KeyStatistics
+// They are computed from the match data, not by the LLM, so they are always exact.
+// Kill participation and damage per minute are in the response's metrics block, not repeated here.
+func ChallengeStatPairs(participant *types.RiotParticipant, roles map[int]types.RoleAssignment) []types.StatPair {
+	if participant == nil {
+		return nil
+	}
+	c, ok := participant.TypedChallenges()
+	if !ok {
+		return nil
+	}
+
+	stats := []types.StatPair{
+		{Label: "Solo Kills", Value: fmt.Sprintf("%d", c.SoloKills)},
+		{Label: "Skillshots Dodged", Value: fmt.Sprintf("%d", c.SkillshotsDodged), Context: fmt.Sprintf("%d skillshots hit", c.SkillshotsHit)},
+	}
+	switch ResolvedPosition(roles, participant) {
+	case "":
+	case types.PositionJungle:
+		stats = append(stats, types.StatPair{Label: "Jungle CS Before 10:00", Value: fmt.Sprintf("%.0f", c.JungleCsBefore10Minutes)})
+	default:
+		stats = append(stats,
+			types.StatPair{Label: "Lane Minions by 10:00", Value: fmt.Sprintf("%d", c.LaneMinionsFirst10Minutes)},
+			types.StatPair{Label: "Turret Plates Taken", Value: fmt.Sprintf("%d", c.TurretPlatesTaken)},
+		)
+	}
+	return stats
+}
+
+func yesNo(value bool) string {
+	if value {
+		return "Yes"
+	}
+	return "No"
+}
@@ -4,29 +4,79 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"log"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
 	"time"
 	"unicode"
 
+	"lol-ranked-new-meta/benchmarks"
+	"lol-ranked-new-meta/matchcache"
+	"lol-ranked-new-meta/metrics"
+	"lol-ranked-new-meta/staticdata"
 	"lol-ranked-new-meta/types"
 )
 
+// DefaultBaseURL is the Riot API host template; {region} is replaced by the routing region or platform
+const DefaultBaseURL = "https://{region}.api.riotgames.com"
+
+const (
+	maxRetries     = 3                      // Retries after a 429 or 5xx response
+	retryBaseDelay = 500 * time.Millisecond // First backoff delay when Riot gives no Retry-After
+	maxRetryWait   = 30 * time.Second       // Give up instead of holding a caller longer than this
+)
+
 type Client struct {
-	apiKey string
-	region string
-	client *http.Client
+	apiKey  string
+	region  string
+	baseURL string
+	client  *http.Client
+	limiter *RateLimiter
+	cache   *matchcache.Cache // Optional: finished matches never change, so they are served from here when present
+
+	leagueCache  *ttlCache
+	masteryCache *ttlCache
 }
 
 // NewClient creates a new Riot API client
 func NewClient(apiKey, region string) *Client {
 	return &Client{
-		apiKey: apiKey,
-		region: region,
+		apiKey:  apiKey,
+		region:  region,
+		baseURL: DefaultBaseURL,
 		client: &http.Client{
 			Timeout: 30 * time.Second,
 		},
+		limiter:      NewRateLimiter(DefaultAppRateLimit),
+		leagueCache:  newTTLCache(leagueCacheTTL),
+		masteryCache: newTTLCache(masteryCacheTTL),
+	}
+}
+
+// SetAppRateLimit sets the app rate limit assumed before Riot reports it (e.g. "500:10,30000:600" for production keys)
+func (c *Client) SetAppRateLimit(spec string) {
+	c.limiter = NewRateLimiter(spec)
+}
+
+// SetBaseURL points the client at a different Riot API host, e.g. a proxy or the riottest fake server
+// "{region}" in baseURL is replaced by the routing region or platform; without it every region uses the same host
+func (c *Client) SetBaseURL(baseURL string) {
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
 	}
+	c.baseURL = strings.TrimSuffix(baseURL, "/")
+}
+
+// SetHTTPClient replaces the HTTP client used for Riot API requests
+func (c *Client) SetHTTPClient(client *http.Client) {
+	c.client = client
+}
+
+// SetCache enables caching of match and timeline responses
+func (c *Client) SetCache(cache *matchcache.Cache) {
+	c.cache = cache
 }
 
 // GetMatch fetches match details from the Riot Games API
@@ -36,188 +86,305 @@ func (c *Client) GetMatch(matchID string) (*types.RiotMatch, error) {
 
 // GetMatchWithRegion fetches match details using an optional routing region override
 func (c *Client) GetMatchWithRegion(matchID, region string) (*types.RiotMatch, error) {
-	routingRegion := NormalizeRoutingRegion(region)
-	if routingRegion == "" {
-		routingRegion = c.region
+	var match types.RiotMatch
+	if c.cache != nil && c.cache.Get(matchcache.KindMatch, matchID, &match) {
+		return &match, nil
 	}
-	// Riot API v5 uses regional routing (americas, europe, asia, sea)
-	url := fmt.Sprintf("https://%s.api.riotgames.com/lol/match/v5/matches/%s", routingRegion, matchID)
-
-	req, err := http.NewRequest("GET", url, nil)
+	body, err := c.getRaw(c.routingRegion(region), "match-v5.getMatch", "/lol/match/v5/matches/"+matchID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, err
+	}
+	if err := json.Unmarshal(body, &match); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
+	if c.cache != nil {
+		// Cache the body as received, not the decoded struct, so later type fields are not lost
+		if err := c.cache.Put(matchcache.KindMatch, matchID, body); err != nil {
+			log.Printf("Warning: failed to cache match %s: %v", matchID, err)
+		}
+	}
+	return &match, nil
+}
 
-	req.Header.Set("X-Riot-Token", c.apiKey)
+// GetMatchTimeline fetches the event timeline for a match
+func (c *Client) GetMatchTimeline(matchID string) (*types.RiotMatchTimeline, error) {
+	return c.GetMatchTimelineWithRegion(matchID, "")
+}
 
-	resp, err := c.client.Do(req)
+// GetMatchTimelineWithRegion fetches the event timeline using an optional routing region override
+func (c *Client) GetMatchTimelineWithRegion(matchID, region string) (*types.RiotMatchTimeline, error) {
+	var timeline types.RiotMatchTimeline
+	if c.cache != nil && c.cache.Get(matchcache.KindTimeline, matchID, &timeline) {
+		return &timeline, nil
+	}
+	body, err := c.getRaw(c.routingRegion(region), "match-v5.getTimeline", "/lol/match/v5/matches/"+matchID+"/timeline")
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute request: %w", err)
+		return nil, err
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("riot API error: status %d, body: %s", resp.StatusCode, string(bodyBytes))
+	if err := json.Unmarshal(body, &timeline); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	if c.cache != nil {
+		// Cache the body as received, not the decoded struct, so later type fields are not lost
+		if err := c.cache.Put(matchcache.KindTimeline, matchID, body); err != nil {
+			log.Printf("Warning: failed to cache timeline %s: %v", matchID, err)
+		}
 	}
+	return &timeline, nil
+}
 
-	var match types.RiotMatch
-	if err := json.NewDecoder(resp.Body).Decode(&match); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+// GetAccountByRiotID resolves a Riot ID (gameName#tagLine) to an account using account-v1
+func (c *Client) GetAccountByRiotID(gameName, tagLine, region string) (*types.RiotAccount, error) {
+	// account-v1 is served by americas, asia and europe; any of them can resolve every account
+	accountRegion := c.routingRegion(region)
+	if accountRegion == "sea" {
+		accountRegion = "asia"
 	}
+	path := fmt.Sprintf("/riot/account/v1/accounts/by-riot-id/%s/%s", url.PathEscape(gameName), url.PathEscape(tagLine))
 
-	return &match, nil
+	var account types.RiotAccount
+	if err := c.get(accountRegion, "account-v1.getByRiotId", path, &account); err != nil {
+		return nil, err
+	}
+	return &account, nil
 }
 
-// FormatMatchForAnalysis converts Riot match data into a format suitable for OpenAI analysis
-// championFilter and summonerFilter are optional - if provided, detailed analysis will focus on that champion/summoner
-func FormatMatchForAnalysis(match *types.RiotMatch, championFilter, summonerFilter string) string {
-	if match == nil {
-		return ""
+// GetMatchIDsByPUUID lists a player's most recent match IDs, newest first
+// queue is optional (0 = all queues)
+func (c *Client) GetMatchIDsByPUUID(puuid, region string, start, count, queue int) ([]string, error) {
+	query := url.Values{}
+	query.Set("start", strconv.Itoa(start))
+	query.Set("count", strconv.Itoa(count))
+	if queue > 0 {
+		query.Set("queue", strconv.Itoa(queue))
 	}
+	path := fmt.Sprintf("/lol/match/v5/matches/by-puuid/%s/ids?%s", url.PathEscape(puuid), query.Encode())
 
-	// Create a summary string with key match information
-	var summary string
-	summary += fmt.Sprintf("Match ID: %s\n", match.Metadata.MatchID)
-	summary += fmt.Sprintf("Game Mode: %s\n", match.Info.GameMode)
-	summary += fmt.Sprintf("Game Duration: %d seconds (%.2f minutes)\n", match.Info.GameDuration, float64(match.Info.GameDuration)/60.0)
-	summary += fmt.Sprintf("Game Version: %s\n\n", match.Info.GameVersion)
+	var matchIDs []string
+	if err := c.get(c.routingRegion(region), "match-v5.getMatchIdsByPUUID", path, &matchIDs); err != nil {
+		return nil, err
+	}
+	return matchIDs, nil
+}
 
-	// Team summaries
-	summary += "Teams:\n"
-	for _, team := range match.Info.Teams {
-		teamName := "Blue"
-		if team.TeamID == 200 {
-			teamName = "Red"
-		}
-		result := "Lost"
-		if team.Win {
-			result = "Won"
-		}
-		summary += fmt.Sprintf("- Team %s (%s): %d turrets destroyed, %d dragons, %d barons\n",
-			teamName, result, team.Objectives.Tower.Kills, team.Objectives.Dragon.Kills, team.Objectives.Baron.Kills)
+// routingRegion returns the normalized routing region, falling back to the client default
+func (c *Client) routingRegion(region string) string {
+	routingRegion := NormalizeRoutingRegion(region)
+	if routingRegion == "" {
+		routingRegion = c.region
 	}
+	return routingRegion
+}
 
-	summary += "\nParticipants:\n"
-	var targetParticipant *types.RiotParticipant
-	filterProvided := strings.TrimSpace(championFilter) != "" || strings.TrimSpace(summonerFilter) != ""
-	filterMatched := false
-	for i := range match.Info.Participants {
-		participant := match.Info.Participants[i]
-		teamName := "Blue"
-		if participant.TeamID == 200 {
-			teamName = "Red"
-		}
-		result := "Lost"
-		if participant.Win {
-			result = "Won"
+// get performs an authenticated GET against the Riot API and decodes the JSON response into out
+// method names the endpoint for method rate limits; requests are throttled and retried on 429/5xx
+func (c *Client) get(host, method, path string, out interface{}) error {
+	body, err := c.getRaw(host, method, path)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// getRaw is get without decoding: it returns the response body as received
+func (c *Client) getRaw(host, method, path string) ([]byte, error) {
+	// Riot API v5 uses regional routing (americas, europe, asia, sea)
+	requestURL := strings.ReplaceAll(c.baseURL, "{region}", host) + path
+
+	for attempt := 0; ; attempt++ {
+		req, err := http.NewRequest("GET", requestURL, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
 		}
 
-		// Check if this is the target participant for deep dive
-		isTarget := false
-		if championFilter != "" && matchesFilter(participant.ChampionName, championFilter) {
-			isTarget = true
-			targetParticipant = &match.Info.Participants[i]
-			filterMatched = true
+		req.Header.Set("X-Riot-Token", c.apiKey)
+
+		c.limiter.Wait(host, method)
+		resp, err := c.client.Do(req)
+		if err != nil {
+			// Network failures and timeouts mean Riot could not be reached at all
+			return nil, fmt.Errorf("%w: failed to execute request: %v", ErrUnavailable, err)
 		}
-		if summonerFilter != "" && matchesFilter(participant.SummonerName, summonerFilter) {
-			isTarget = true
-			targetParticipant = &match.Info.Participants[i]
-			filterMatched = true
+		c.limiter.Update(host, method, resp.Header)
+
+		if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError {
+			delay := retryAfter(resp.Header)
+			if delay == 0 {
+				delay = backoff(attempt)
+			}
+			if attempt < maxRetries && delay <= maxRetryWait {
+				io.Copy(io.Discard, resp.Body)
+				resp.Body.Close()
+
+				log.Printf("Riot API %s returned %d, retrying in %s (attempt %d/%d)", method, resp.StatusCode, delay, attempt+1, maxRetries)
+				if resp.StatusCode == http.StatusTooManyRequests {
+					// Blocking the limiter holds back every caller sharing the limit, not just this one
+					c.limiter.Block(host, method, resp.Header.Get("X-Rate-Limit-Type"), delay)
+				} else {
+					time.Sleep(delay)
+				}
+				continue
+			}
 		}
 
-		marker := ""
-		if isTarget {
-			marker = " [TARGET FOR DEEP DIVE]"
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			bodyBytes, _ := io.ReadAll(resp.Body)
+			return nil, &APIError{
+				StatusCode: resp.StatusCode,
+				Method:     method,
+				Body:       string(bodyBytes),
+				RetryAfter: retryAfter(resp.Header),
+			}
 		}
 
-		summary += fmt.Sprintf("- %s (%s, %s, %s)%s: K/D/A: %d/%d/%d, CS: %d, Gold: %d, Damage: %d\n",
-			participant.SummonerName,
-			participant.ChampionName,
-			teamName,
-			result,
-			marker,
-			participant.Kills,
-			participant.Deaths,
-			participant.Assists,
-			participant.TotalMinionsKilled,
-			participant.GoldEarned,
-			participant.TotalDamageDealtToChampions,
-		)
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("%w: failed to read response: %v", ErrUnavailable, err)
+		}
+		return body, nil
 	}
+}
 
-	// If we have a target participant, add detailed stats
-	if targetParticipant != nil {
-		summary += "\n=== DETAILED STATS FOR TARGET PLAYER ===\n"
-		summary += FormatParticipantDeepDive(targetParticipant, match.Info.GameDuration)
+// FormatMatchForAnalysis converts Riot match data into a format suitable for OpenAI analysis
+// championFilter and summonerFilter are optional - if provided, detailed analysis will focus on that champion/summoner
+func FormatMatchForAnalysis(match *types.RiotMatch, championFilter, summonerFilter string) string {
+	return FormatMatchForAnalysisWithExtras(match, nil, championFilter, summonerFilter)
+}
 
-		// Add opponent composition analysis
-		summary += "\n=== OPPONENT COMPOSITION ===\n"
-		summary += FormatOpponentComposition(match, targetParticipant)
+// FormatMatchForAnalysisWithExtras is FormatMatchForAnalysis with optional enrichment data (e.g. the match timeline)
+// It builds the full summary without a token budget; use BuildMatchSummary to pick a verbosity and budget
+func FormatMatchForAnalysisWithExtras(match *types.RiotMatch, extras *MatchExtras, championFilter, summonerFilter string) string {
+	return BuildMatchSummary(match, extras, championFilter, summonerFilter, SummaryOptions{Verbosity: VerbosityFull})
+}
 
-		// Add item build timeline
-		summary += "\n=== ITEM BUILD TIMELINE ===\n"
-		summary += FormatItemBuildTimeline(targetParticipant, match.Info.GameDuration)
+// formatDerivedMetrics lists each team's derived metrics in lang and those of every participant, or only of target when it is non-nil
+func formatDerivedMetrics(match *types.RiotMatch, queue types.QueueInfo, target *types.RiotParticipant, static *staticdata.Bundle, lang Language) string {
+	m := metrics.Compute(match)
+	label := func(teamID int) string {
+		if queue.Format == types.FormatArena {
+			return lang.sprintf("Duo %d", teamID)
+		}
+		return lang.sprintf("Team %s", lang.label(teamLabel(teamID)))
 	}
 
-	if filterProvided && !filterMatched {
-		summary += "\nNOTE: No participant matched the provided champion/summoner filter. Deep dive details may be limited.\n"
+	var result string
+	for _, tm := range m.Teams {
+		result += fmt.Sprintf("- %s: %s\n", label(tm.TeamID), formatTeamMetrics(tm, lang))
 	}
+	for _, pm := range m.Participants {
+		if target != nil && pm.ParticipantID != target.ParticipantID {
+			continue
+		}
+		result += fmt.Sprintf("- %s (%s): %s\n", pm.SummonerName, championNameLabel(static, pm.ChampionName), formatParticipantMetrics(pm, lang))
+	}
+	return result
+}
 
-	summary += "\nDATA LIMITATIONS:\n"
-	summary += "- No event timeline or objective timestamps are available in this summary.\n"
-	summary += "- Item names and exact purchase times are not included (IDs only).\n"
-	summary += "- Do not infer exact timings unless explicitly provided above.\n"
+// formatParticipantMetrics renders one participant's metrics as a single prompt line
+func formatParticipantMetrics(pm types.ParticipantMetrics, lang Language) string {
+	return lang.sprintf("KP %.0f%%, Damage Share %.0f%%, Gold Share %.0f%%, CS/min %.1f, Vision/min %.2f, DPM %.0f, Damage/Gold %.2f, Deaths/10min %.1f",
+		pm.KillParticipation*100, pm.DamageShare*100, pm.GoldShare*100, pm.CSPerMinute,
+		pm.VisionPerMinute, pm.DamagePerMinute, pm.DamagePerGold, pm.DeathsPer10)
+}
 
-	return summary
+// formatTeamMetrics renders one team's metrics as a single prompt line
+func formatTeamMetrics(tm types.TeamMetrics, lang Language) string {
+	return lang.sprintf("%d/%d/%d, %d gold, CS/min %.1f, Vision/min %.2f, DPM %.0f, Gold/min %.0f, Damage/Gold %.2f, Deaths/10min %.1f",
+		tm.Kills, tm.Deaths, tm.Assists, tm.GoldEarned, tm.CSPerMinute,
+		tm.VisionPerMinute, tm.DamagePerMinute, tm.GoldPerMinute, tm.DamagePerGold, tm.DeathsPer10)
 }
 
-// FormatOpponentComposition provides opponent team composition analysis
-func FormatOpponentComposition(match *types.RiotMatch, targetParticipant *types.RiotParticipant) string {
-	var opponentTeam string
-	var allyTeam string
+// FormatOpponentComposition provides opponent team composition analysis with labels in lang
+// roles is ResolveRoles(match); static is optional - it adds localized champion names
+func FormatOpponentComposition(match *types.RiotMatch, roles map[int]types.RoleAssignment, targetParticipant *types.RiotParticipant, static *staticdata.Bundle, lang Language) string {
+	queue := MatchQueue(match)
+	if queue.Format == types.FormatArena {
+		return formatArenaComposition(match, targetParticipant, static, lang)
+	}
 
-	if targetParticipant.TeamID == 100 {
-		opponentTeam = "Red"
-		allyTeam = "Blue"
-	} else {
-		opponentTeam = "Blue"
-		allyTeam = "Red"
+	allyTeam := lang.label(teamLabel(targetParticipant.TeamID))
+	opponentTeam := lang.label(teamLabel(otherTeam(targetParticipant.TeamID)))
+
+	// Positions only mean something in modes with lanes (not ARAM or rotating modes)
+	position := func(p types.RiotParticipant) string {
+		if !queue.HasLanes {
+			return ""
+		}
+		return " - " + ResolvedPosition(roles, &p)
 	}
 
 	var comp string
-	comp += fmt.Sprintf("Your Team (%s):\n", allyTeam)
+	comp += lang.sprintf("Your Team (%s):", allyTeam) + "\n"
 	for _, p := range match.Info.Participants {
 		if p.TeamID == targetParticipant.TeamID {
-			comp += fmt.Sprintf("- %s (%s) - %s\n", p.SummonerName, p.ChampionName, p.TeamPosition)
+			comp += fmt.Sprintf("- %s (%s)%s\n", p.SummonerName, championLabel(static, &p), position(p))
 		}
 	}
 
-	comp += fmt.Sprintf("\nOpponent Team (%s):\n", opponentTeam)
+	opponent, laneOpponent, hasOpponent := FindLaneOpponent(match, roles, targetParticipant)
+	comp += "\n" + lang.sprintf("Opponent Team (%s):", opponentTeam) + "\n"
 	for _, p := range match.Info.Participants {
 		if p.TeamID != targetParticipant.TeamID {
-			comp += fmt.Sprintf("- %s (%s) - %s\n", p.SummonerName, p.ChampionName, p.TeamPosition)
+			comp += fmt.Sprintf("- %s (%s)%s\n", p.SummonerName, championLabel(static, &p), position(p))
 
-			// Find lane opponent
-			if p.TeamPosition == targetParticipant.TeamPosition && p.TeamPosition != "" {
-				comp += fmt.Sprintf("  -> LANE OPPONENT: %s vs %s\n", targetParticipant.ChampionName, p.ChampionName)
-				comp += fmt.Sprintf("     Result: %d/%d/%d (You) vs %d/%d/%d (Opponent)\n",
+			if hasOpponent && p.ParticipantID == opponent.ParticipantID {
+				comp += lang.sprintf("  -> LANE OPPONENT: %s vs %s (%s, %s confidence)",
+					championLabel(static, targetParticipant), championLabel(static, &p), laneOpponent.Position, laneOpponent.Confidence) + "\n"
+				comp += lang.sprintf("     Result: %d/%d/%d (You) vs %d/%d/%d (Opponent)",
 					targetParticipant.Kills, targetParticipant.Deaths, targetParticipant.Assists,
-					p.Kills, p.Deaths, p.Assists)
-				comp += fmt.Sprintf("     CS: %d (You) vs %d (Opponent)\n",
-					targetParticipant.TotalMinionsKilled, p.TotalMinionsKilled)
-				comp += fmt.Sprintf("     Gold: %d (You) vs %d (Opponent)\n",
-					targetParticipant.GoldEarned, p.GoldEarned)
+					p.Kills, p.Deaths, p.Assists) + "\n"
+				comp += lang.sprintf("     CS: %d (You) vs %d (Opponent)",
+					metrics.CreepScore(targetParticipant), metrics.CreepScore(&p)) + "\n"
+				comp += lang.sprintf("     Gold: %d (You) vs %d (Opponent)",
+					targetParticipant.GoldEarned, p.GoldEarned) + "\n"
 			}
 		}
 	}
 
+	if !queue.HasLanes {
+		comp += "\n" + lang.label("No lane opponents: this mode has no lanes.") + "\n"
+	} else if role, ok := roles[targetParticipant.ParticipantID]; ok && role.Confidence != types.ConfidenceHigh {
+		// teamPosition was missing or conflicting, so the matchup rests on weaker signals
+		comp += "\n" + lang.sprintf("NOTE: Roles were inferred (target: %s from %s, %s confidence); treat the lane matchup with care.",
+			role.Position, strings.ReplaceAll(role.Source, "_", " "), role.Confidence) + "\n"
+	}
+
+	return comp
+}
+
+// formatArenaComposition lists the target's duo partner and every opposing duo
+func formatArenaComposition(match *types.RiotMatch, targetParticipant *types.RiotParticipant, static *staticdata.Bundle, lang Language) string {
+	queue := MatchQueue(match)
+
+	var comp string
+	comp += lang.sprintf("Your Duo (Duo %d, %s):", targetParticipant.PlayerSubteamID, participantResult(queue, targetParticipant, lang)) + "\n"
+	for _, p := range match.Info.Participants {
+		if p.PlayerSubteamID == targetParticipant.PlayerSubteamID {
+			comp += lang.sprintf("- %s (%s): %d/%d/%d, %d damage", p.SummonerName, championLabel(static, &p),
+				p.Kills, p.Deaths, p.Assists, p.TotalDamageDealtToChampions) + "\n"
+		}
+	}
+
+	comp += "\n" + lang.label("Opposing Duos") + ":\n"
+	for i := range match.Info.Participants {
+		p := &match.Info.Participants[i]
+		if p.PlayerSubteamID != targetParticipant.PlayerSubteamID {
+			comp += lang.sprintf("- %s (%s), Duo %d, %s: %d/%d/%d", p.SummonerName, championLabel(static, p),
+				p.PlayerSubteamID, participantResult(queue, p, lang), p.Kills, p.Deaths, p.Assists) + "\n"
+		}
+	}
 	return comp
 }
 
-// FormatItemBuildTimeline creates a timeline of item purchases
-func FormatItemBuildTimeline(participant *types.RiotParticipant, gameDuration int64) string {
-	var timeline string
+// FormatItemBuildTimeline creates a timeline of item purchases with labels in lang
+// extras is optional - without a timeline only the final build and gold income are available
+func FormatItemBuildTimeline(participant *types.RiotParticipant, extras *MatchExtras, gameDuration int64, lang Language) string {
+	var result string
 	items := []struct {
 		slot int
 		id   int
@@ -232,85 +399,168 @@ func FormatItemBuildTimeline(participant *types.RiotParticipant, gameDuration in
 		{6, participant.Item6, "Trinket"},
 	}
 
-	timeline += fmt.Sprintf("Total Items Purchased: %d\n", participant.ItemsPurchased)
-	timeline += "Final Build:\n"
+	timeline := extras.timeline()
+	static := extras.static()
+	purchases := ItemPurchases(timeline, participant.ParticipantID)
+
+	result += lang.sprintf("Total Items Purchased: %d", participant.ItemsPurchased) + "\n"
+	result += lang.label("Final Build") + ":\n"
 
 	for _, item := range items {
 		if item.id != 0 {
-			timeline += fmt.Sprintf("- %s: Item ID %d", item.name, item.id)
+			result += fmt.Sprintf("- %s: %s", lang.label(item.name), itemLabel(static, item.id))
 			if item.slot == 6 {
-				timeline += " (Trinket)"
+				result += " (" + lang.label("Trinket") + ")"
+			}
+			if bought, ok := firstPurchase(purchases, item.id); ok {
+				result += lang.sprintf(" - first bought at %s", FormatGameTime(bought.Timestamp))
 			}
-			timeline += "\n"
+			result += "\n"
+		}
+	}
+
+	if len(purchases) > 0 {
+		// Completed items need static data to tell them apart from components
+		completed := 0
+		for _, p := range purchases {
+			if item, ok := static.Item(p.ItemID); ok && item.Completed() {
+				completed++
+				if completed == 1 {
+					result += "\n" + lang.sprintf("First Completed Item: %s at %s", item.Name, FormatGameTime(p.Timestamp)) + "\n"
+				} else if completed == 2 {
+					result += lang.sprintf("Second Completed Item: %s at %s", item.Name, FormatGameTime(p.Timestamp)) + "\n"
+					break
+				}
+			}
+		}
+
+		result += "\n" + lang.label("Purchase Order (from match timeline):") + "\n"
+		for _, p := range purchases {
+			result += fmt.Sprintf("- %s: %s\n", FormatGameTime(p.Timestamp), itemLabel(static, p.ItemID))
 		}
 	}
 
 	// Calculate approximate timing (rough estimate based on gold earned)
 	goldPerMinute := float64(participant.GoldEarned) / (float64(gameDuration) / 60.0)
-	timeline += fmt.Sprintf("\nGold Income: %.0f gold/minute\n", goldPerMinute)
-	timeline += "Note: Exact item purchase times require timeline data from Riot API match timeline endpoint\n"
+	result += "\n" + lang.sprintf("Gold Income: %.0f gold/minute", goldPerMinute) + "\n"
+	if timeline == nil {
+		result += lang.label("Note: Exact item purchase times require timeline data from Riot API match timeline endpoint") + "\n"
+	}
 
-	return timeline
+	return result
 }
 
-// FormatParticipantDeepDive creates a detailed analysis string for a specific participant
-func FormatParticipantDeepDive(participant *types.RiotParticipant, gameDuration int64) string {
-	if participant == nil {
+// FormatParticipantDeepDive creates a detailed analysis string for a specific participant with labels in lang
+// extras is optional - with static data, item and summoner spell IDs are resolved to names
+func FormatParticipantDeepDive(match *types.RiotMatch, participant *types.RiotParticipant, extras *MatchExtras, lang Language) string {
+	if match == nil || participant == nil {
 		return ""
 	}
+	static := extras.static()
+	gameDuration := match.Info.GameDuration
 
 	var detail string
-	detail += fmt.Sprintf("Summoner: %s (%s#%s)\n", participant.SummonerName, participant.RiotIDGameName, participant.RiotIDTagline)
-	detail += fmt.Sprintf("Champion: %s (Level %d)\n", participant.ChampionName, participant.ChampLevel)
-	detail += fmt.Sprintf("Team Position: %s (Lane: %s, Role: %s)\n", participant.TeamPosition, participant.Lane, participant.Role)
-	detail += fmt.Sprintf("Result: %s\n\n", map[bool]string{true: "Victory", false: "Defeat"}[participant.Win])
+	detail += lang.sprintf("Summoner: %s (%s#%s)", participant.SummonerName, participant.RiotIDGameName, participant.RiotIDTagline) + "\n"
+	detail += lang.sprintf("Champion: %s (Level %d)", championLabel(static, participant), participant.ChampLevel) + "\n"
+	if mastery := extras.mastery(); mastery != nil && mastery.Puuid == participant.Puuid && mastery.ChampionID == participant.ChampionID {
+		detail += lang.sprintf("Champion Mastery: %s", FormatChampionMastery(mastery)) + "\n"
+	}
+	switch {
+	case participant.PlayerSubteamID > 0:
+		detail += lang.sprintf("Arena Duo: %d, Placement: %s", participant.PlayerSubteamID, lang.ordinal(participant.Placement)) + "\n"
+		var augments []string
+		for _, augmentID := range []int{participant.PlayerAugment1, participant.PlayerAugment2, participant.PlayerAugment3, participant.PlayerAugment4} {
+			if augmentID != 0 {
+				augments = append(augments, strconv.Itoa(augmentID))
+			}
+		}
+		detail += lang.sprintf("Augments (IDs, in pick order): %s", lang.label(joinOrNone(augments))) + "\n"
+	case MatchQueue(match).HasLanes:
+		// Resolved like the lane opponent, so an empty teamPosition does not read as a mode without lanes
+		if role, ok := extras.roles(match)[participant.ParticipantID]; ok {
+			detail += lang.sprintf("Team Position: %s (resolved from %s, %s confidence; Lane: %s, Role: %s)",
+				role.Position, role.Source, role.Confidence, participant.Lane, participant.Role) + "\n"
+		} else {
+			detail += lang.label("Team Position: unknown") + "\n"
+		}
+	default:
+		detail += lang.label("Team Position: none (this mode has no lanes)") + "\n"
+	}
+	if participant.PlayerSubteamID == 0 {
+		detail += lang.sprintf("Result: %s", lang.label(map[bool]string{true: "Victory", false: "Defeat"}[participant.Win])) + "\n"
+	}
+	detail += "\n"
 
-	detail += "Performance Metrics:\n"
-	detail += fmt.Sprintf("- K/D/A: %d/%d/%d (KDA Ratio: %.2f)\n",
+	detail += lang.label("Performance Metrics") + ":\n"
+	detail += lang.sprintf("- K/D/A: %d/%d/%d (KDA Ratio: %.2f)",
 		participant.Kills, participant.Deaths, participant.Assists,
-		float64(participant.Kills+participant.Assists)/float64(max(participant.Deaths, 1)))
-	detail += fmt.Sprintf("- CS: %d (%.1f CS/min)\n", participant.TotalMinionsKilled,
-		float64(participant.TotalMinionsKilled)/(float64(gameDuration)/60.0))
-	detail += fmt.Sprintf("- Gold Earned: %d (Gold/min: %.0f)\n", participant.GoldEarned,
-		float64(participant.GoldEarned)/(float64(gameDuration)/60.0))
-	detail += fmt.Sprintf("- Gold Spent: %d\n", participant.GoldSpent)
-
-	detail += "\nCombat Stats:\n"
-	detail += fmt.Sprintf("- Total Damage to Champions: %d\n", participant.TotalDamageDealtToChampions)
-	detail += fmt.Sprintf("- Physical Damage: %d\n", participant.PhysicalDamageDealtToChampions)
-	detail += fmt.Sprintf("- Magic Damage: %d\n", participant.MagicDamageDealtToChampions)
-	detail += fmt.Sprintf("- True Damage: %d\n", participant.TrueDamageDealtToChampions)
-	detail += fmt.Sprintf("- Damage Taken: %d\n", participant.TotalDamageTaken)
-	detail += fmt.Sprintf("- Damage Self Mitigated: %d\n", participant.DamageSelfMitigated)
-	detail += fmt.Sprintf("- Total Heal: %d\n", participant.TotalHeal)
-	detail += fmt.Sprintf("- Total Shields on Teammates: %d\n", participant.TotalDamageShieldedOnTeammates)
-
-	detail += "\nObjective Control:\n"
-	detail += fmt.Sprintf("- Turret Kills: %d\n", participant.TurretKills)
-	detail += fmt.Sprintf("- Inhibitor Kills: %d\n", participant.InhibitorKills)
-	detail += fmt.Sprintf("- Dragon Kills: %d\n", participant.DragonKills)
-	detail += fmt.Sprintf("- Baron Kills: %d\n", participant.BaronKills)
-	detail += fmt.Sprintf("- First Blood: %s\n", map[bool]string{true: "Yes", false: "No"}[participant.FirstBloodKill])
-	detail += fmt.Sprintf("- First Tower: %s\n", map[bool]string{true: "Yes", false: "No"}[participant.FirstTowerKill])
-
-	detail += "\nVision & Map Control:\n"
-	detail += fmt.Sprintf("- Vision Score: %d\n", participant.VisionScore)
-	detail += fmt.Sprintf("- Wards Placed: %d\n", participant.WardsPlaced)
-	detail += fmt.Sprintf("- Wards Killed: %d\n", participant.WardsKilled)
-	detail += fmt.Sprintf("- Control Wards Purchased: %d\n", participant.VisionWardsBoughtInGame)
-	detail += fmt.Sprintf("- Detector Wards Placed: %d\n", participant.DetectorWardsPlaced)
-
-	detail += "\nSpecial Achievements:\n"
-	detail += fmt.Sprintf("- Largest Killing Spree: %d\n", participant.LargestKillingSpree)
-	detail += fmt.Sprintf("- Killing Sprees: %d\n", participant.KillingSprees)
-	detail += fmt.Sprintf("- Double Kills: %d\n", participant.DoubleKills)
-	detail += fmt.Sprintf("- Triple Kills: %d\n", participant.TripleKills)
-	detail += fmt.Sprintf("- Quadra Kills: %d\n", participant.QuadraKills)
-	detail += fmt.Sprintf("- Penta Kills: %d\n", participant.PentaKills)
-	detail += fmt.Sprintf("- Unreal Kills: %d\n", participant.UnrealKills)
-	detail += fmt.Sprintf("- Largest Multi Kill: %d\n", participant.LargestMultiKill)
-
-	detail += "\nItem Build:\n"
+		float64(participant.Kills+participant.Assists)/float64(max(participant.Deaths, 1))) + "\n"
+	cs := metrics.CreepScore(participant)
+	detail += lang.sprintf("- CS: %d (%d minions, %d monsters; %.1f CS/min)", cs,
+		participant.TotalMinionsKilled, participant.NeutralMinionsKilled, float64(cs)/(float64(gameDuration)/60.0)) + "\n"
+	detail += lang.sprintf("- Gold Earned: %d (Gold/min: %.0f)", participant.GoldEarned,
+		float64(participant.GoldEarned)/(float64(gameDuration)/60.0)) + "\n"
+	detail += lang.sprintf("- Gold Spent: %d", participant.GoldSpent) + "\n"
+	// Shares and rates come from the metrics package, the one source the derived metrics and benchmarks use too
+	pm, hasMetrics := metrics.ForParticipant(metrics.Compute(match), participant.ParticipantID)
+	if hasMetrics {
+		detail += lang.sprintf("- Kill Participation: %.0f%%", pm.KillParticipation*100) + "\n"
+		detail += lang.sprintf("- Damage per Minute: %.0f (%.0f%% of team damage)", pm.DamagePerMinute, pm.DamageShare*100) + "\n"
+	}
+
+	detail += "\n" + lang.label("Combat Stats") + ":\n"
+	detail += lang.sprintf("- Total Damage to Champions: %d", participant.TotalDamageDealtToChampions) + "\n"
+	detail += lang.sprintf("- Physical Damage: %d", participant.PhysicalDamageDealtToChampions) + "\n"
+	detail += lang.sprintf("- Magic Damage: %d", participant.MagicDamageDealtToChampions) + "\n"
+	detail += lang.sprintf("- True Damage: %d", participant.TrueDamageDealtToChampions) + "\n"
+	detail += lang.sprintf("- Damage Taken: %d", participant.TotalDamageTaken) + "\n"
+	detail += lang.sprintf("- Damage Self Mitigated: %d", participant.DamageSelfMitigated) + "\n"
+	detail += lang.sprintf("- Total Heal: %d", participant.TotalHeal) + "\n"
+	detail += lang.sprintf("- Total Shields on Teammates: %d", participant.TotalDamageShieldedOnTeammates) + "\n"
+
+	detail += "\n" + lang.label("Objective Control") + ":\n"
+	detail += lang.sprintf("- Turret Kills: %d", participant.TurretKills) + "\n"
+	detail += lang.sprintf("- Inhibitor Kills: %d", participant.InhibitorKills) + "\n"
+	detail += lang.sprintf("- Dragon Kills: %d", participant.DragonKills) + "\n"
+	detail += lang.sprintf("- Baron Kills: %d", participant.BaronKills) + "\n"
+	detail += lang.sprintf("- First Blood: %s", lang.label(yesNo(participant.FirstBloodKill))) + "\n"
+	detail += lang.sprintf("- First Tower: %s", lang.label(yesNo(participant.FirstTowerKill))) + "\n"
+
+	detail += "\n" + lang.label("Vision & Map Control") + ":\n"
+	if hasMetrics {
+		detail += lang.sprintf("- Vision Score: %d (%.2f per minute)", participant.VisionScore, pm.VisionPerMinute) + "\n"
+	} else {
+		detail += lang.sprintf("- Vision Score: %d", participant.VisionScore) + "\n"
+	}
+	detail += lang.sprintf("- Wards Placed: %d", participant.WardsPlaced) + "\n"
+	detail += lang.sprintf("- Wards Killed: %d", participant.WardsKilled) + "\n"
+	detail += lang.sprintf("- Control Wards Purchased: %d", participant.VisionWardsBoughtInGame) + "\n"
+	detail += lang.sprintf("- Detector Wards Placed: %d", participant.DetectorWardsPlaced) + "\n"
+
+	detail += "\n" + lang.label("Special Achievements") + ":\n"
+	detail += lang.sprintf("- Largest Killing Spree: %d", participant.LargestKillingSpree) + "\n"
+	detail += lang.sprintf("- Killing Sprees: %d", participant.KillingSprees) + "\n"
+	detail += lang.sprintf("- Double Kills: %d", participant.DoubleKills) + "\n"
+	detail += lang.sprintf("- Triple Kills: %d", participant.TripleKills) + "\n"
+	detail += lang.sprintf("- Quadra Kills: %d", participant.QuadraKills) + "\n"
+	detail += lang.sprintf("- Penta Kills: %d", participant.PentaKills) + "\n"
+	detail += lang.sprintf("- Unreal Kills: %d", participant.UnrealKills) + "\n"
+	detail += lang.sprintf("- Largest Multi Kill: %d", participant.LargestMultiKill) + "\n"
+
+	if report := extras.benchmarks(); report != nil && report.Puuid == participant.Puuid {
+		if section := benchmarks.Format(report); section != "" {
+			detail += "\n" + lang.sprintf("Role & Rank Benchmarks (vs %s in queue %d; dataset %s: %d games from %s, patches %s):",
+				benchmarks.Cohort(report), report.QueueID, report.Version, report.Samples, report.Source, report.Patches) + "\n"
+			detail += section
+		}
+	}
+
+	if metrics := FormatChallengeMetrics(participant, extras.roles(match), lang); metrics != "" {
+		detail += "\n" + lang.label("Challenge Metrics") + ":\n"
+		detail += metrics
+	}
+
+	detail += "\n" + lang.label("Item Build") + ":\n"
 	items := []int{participant.Item0, participant.Item1, participant.Item2, participant.Item3, participant.Item4, participant.Item5, participant.Item6}
 	for i, itemID := range items {
 		if itemID != 0 {
@@ -318,20 +568,26 @@ func FormatParticipantDeepDive(participant *types.RiotParticipant, gameDuration
 			if i == 6 {
 				slotName = "Trinket"
 			}
-			detail += fmt.Sprintf("- %s %d: %d\n", slotName, i+1, itemID)
+			detail += fmt.Sprintf("- %s %d: %s\n", lang.label(slotName), i+1, itemLabel(static, itemID))
 		}
 	}
-	detail += fmt.Sprintf("- Total Items Purchased: %d\n", participant.ItemsPurchased)
+	detail += lang.sprintf("- Total Items Purchased: %d", participant.ItemsPurchased) + "\n"
 
-	detail += "\nSummoner Spells:\n"
-	detail += fmt.Sprintf("- Summoner Spell 1 (ID %d): Used %d times\n", participant.Summoner1ID, participant.Summoner1Casts)
-	detail += fmt.Sprintf("- Summoner Spell 2 (ID %d): Used %d times\n", participant.Summoner2ID, participant.Summoner2Casts)
+	detail += "\n" + lang.label("Summoner Spells") + ":\n"
+	for _, u := range SpellUsages(participant, gameDuration, static) {
+		detail += "- " + formatSpellUsage(u, lang) + "\n"
+	}
+
+	if runes := FormatRunePage(BuildRunePage(participant, static), lang); runes != "" {
+		detail += "\n" + lang.label("Runes (values recorded by the game)") + ":\n"
+		detail += runes
+	}
 
-	detail += "\nGame Impact:\n"
-	detail += fmt.Sprintf("- Time Spent Dead: %d seconds\n", participant.TotalTimeSpentDead)
-	detail += fmt.Sprintf("- Longest Time Spent Living: %d seconds\n", participant.LongestTimeSpentLiving)
-	detail += fmt.Sprintf("- Time CC'd Others: %d seconds\n", participant.TimeCCingOthers)
-	detail += fmt.Sprintf("- Total Time CC'd: %d seconds\n", participant.TotalTimeCCDealt)
+	detail += "\n" + lang.label("Game Impact") + ":\n"
+	detail += lang.sprintf("- Time Spent Dead: %d seconds", participant.TotalTimeSpentDead) + "\n"
+	detail += lang.sprintf("- Longest Time Spent Living: %d seconds", participant.LongestTimeSpentLiving) + "\n"
+	detail += lang.sprintf("- CC Score (weighted, not seconds): %d", participant.TimeCCingOthers) + "\n"
+	detail += lang.sprintf("- Total CC Dealt: %d seconds", participant.TotalTimeCCDealt) + "\n"
 
 	return detail
 }
@@ -369,6 +625,75 @@ func SelectDefaultParticipant(match *types.RiotMatch) *types.RiotParticipant {
 	return best
 }
 
+// FindTargetParticipant returns the participant the champion/summoner filters select for the deep dive, or nil
+// It follows the same matching rules as FormatMatchForAnalysis
+func FindTargetParticipant(match *types.RiotMatch, championFilter, summonerFilter string) *types.RiotParticipant {
+	if match == nil {
+		return nil
+	}
+	var target *types.RiotParticipant
+	for i := range match.Info.Participants {
+		p := &match.Info.Participants[i]
+		if (championFilter != "" && matchesFilter(p.ChampionName, championFilter)) ||
+			(summonerFilter != "" && matchesFilter(p.SummonerName, summonerFilter)) {
+			target = p
+		}
+	}
+	return target
+}
+
+// MatchPlatform returns the lower-case platform a match was played on (e.g. euw1), for platform-routed endpoints
+func MatchPlatform(match *types.RiotMatch) string {
+	if match == nil {
+		return ""
+	}
+	platform := match.Info.PlatformID
+	if platform == "" {
+		platform = strings.Split(match.Metadata.MatchID, "_")[0]
+	}
+	return strings.ToLower(platform)
+}
+
+// FindParticipantByPUUID returns the participant with the given PUUID, or nil if they did not play in the match
+func FindParticipantByPUUID(match *types.RiotMatch, puuid string) *types.RiotParticipant {
+	if match == nil || puuid == "" {
+		return nil
+	}
+	for i := range match.Info.Participants {
+		if match.Info.Participants[i].Puuid == puuid {
+			return &match.Info.Participants[i]
+		}
+	}
+	return nil
+}
+
+// SummarizeMatchForPlayer builds a short match history entry from the given player's perspective
+func SummarizeMatchForPlayer(match *types.RiotMatch, puuid string) types.PlayerMatchSummary {
+	summary := types.PlayerMatchSummary{
+		MatchID:      match.Metadata.MatchID,
+		QueueID:      match.Info.QueueID,
+		GameMode:     match.Info.GameMode,
+		GameCreation: match.Info.GameCreation,
+		GameDuration: match.Info.GameDuration,
+	}
+
+	participant := FindParticipantByPUUID(match, puuid)
+	if participant == nil {
+		summary.Error = "Player not found in match"
+		return summary
+	}
+
+	summary.ChampionID = participant.ChampionID
+	summary.ChampionName = participant.ChampionName
+	summary.TeamPosition = participant.TeamPosition
+	summary.Kills = participant.Kills
+	summary.Deaths = participant.Deaths
+	summary.Assists = participant.Assists
+	summary.CS = metrics.CreepScore(participant)
+	summary.Win = participant.Win
+	return summary
+}
+
 // RoutingRegionFromMatchID derives routing region from a match ID prefix (e.g., EUW1_123 -> europe)
 func RoutingRegionFromMatchID(matchID string) string {
 	if matchID == "" {
@@ -381,20 +706,14 @@ func RoutingRegionFromMatchID(matchID string) string {
 	return RoutingRegionFromPlatform(parts[0])
 }
 
-// RoutingRegionFromPlatform maps platform codes to routing regions
+// RoutingRegionFromPlatform maps platform codes (or aliases such as EUW) to routing regions
+// Unknown platforms return ""; see Platforms for the full table
 func RoutingRegionFromPlatform(platform string) string {
-	switch strings.ToUpper(strings.TrimSpace(platform)) {
-	case "NA1", "BR1", "LA1", "LA2", "OC1":
-		return "americas"
-	case "EUW1", "EUN1", "TR1", "RU":
-		return "europe"
-	case "KR", "JP1":
-		return "asia"
-	case "PH2", "SG2", "TH2", "TW2", "VN2":
-		return "sea"
-	default:
+	p, ok := LookupPlatform(platform)
+	if !ok {
 		return ""
 	}
+	return p.Routing
 }
 
 // NormalizeRoutingRegion normalizes routing region names
@@ -0,0 +1,200 @@
+package riot_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"lol-ranked-new-meta/matchcache"
+	"lol-ranked-new-meta/riot"
+	"lol-ranked-new-meta/riottest"
+)
+
+const fixtureMatchPath = "/lol/match/v5/matches/" + riottest.FixtureMatchID
+
+func TestGetMatch(t *testing.T) {
+	server := riottest.NewServerWithFixtures()
+	defer server.Close()
+
+	match, err := server.Client("test-key", "europe").GetMatch(riottest.FixtureMatchID)
+	if err != nil {
+		t.Fatalf("GetMatch: %v", err)
+	}
+	if match.Metadata.MatchID != riottest.FixtureMatchID || len(match.Info.Participants) != 10 {
+		t.Errorf("got match %s with %d participants, want %s with 10", match.Metadata.MatchID, len(match.Info.Participants), riottest.FixtureMatchID)
+	}
+	calls := server.Calls()
+	if len(calls) != 1 || calls[0].Region != "europe" || calls[0].Token != "test-key" {
+		t.Errorf("calls = %+v, want one europe call with the API key", calls)
+	}
+}
+
+func TestGetMatchErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		setup      func(s *riottest.Server)
+		want       error
+		retryAfter time.Duration
+	}{
+		{
+			name:  "not found",
+			setup: func(s *riottest.Server) {},
+			want:  riot.ErrNotFound,
+		},
+		{
+			name:  "forbidden",
+			setup: func(s *riottest.Server) { s.RequireAPIKey("other-key") },
+			want:  riot.ErrForbidden,
+		},
+		{
+			// Riot asks for a longer wait than a caller is held, so the 429 is returned at once
+			name: "rate limited",
+			setup: func(s *riottest.Server) {
+				s.Fail(fixtureMatchPath, riottest.Failure{Status: http.StatusTooManyRequests, RetryAfter: 60})
+			},
+			want:       riot.ErrRateLimited,
+			retryAfter: 60 * time.Second,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := riottest.NewServer()
+			defer server.Close()
+			tt.setup(server)
+
+			_, err := server.Client("test-key", "europe").GetMatch(riottest.FixtureMatchID)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("err = %v, want %v", err, tt.want)
+			}
+			var apiErr *riot.APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("err = %T, want *riot.APIError", err)
+			}
+			if retryAfter, _ := riot.RetryAfter(err); retryAfter != tt.retryAfter {
+				t.Errorf("RetryAfter = %s, want %s", retryAfter, tt.retryAfter)
+			}
+			if n := server.CallCount(fixtureMatchPath); n != 1 {
+				t.Errorf("%d requests, want 1 (no retry)", n)
+			}
+		})
+	}
+}
+
+func TestGetMatchRetries(t *testing.T) {
+	tests := []struct {
+		name    string
+		failure riottest.Failure
+	}{
+		{name: "rate limited with Retry-After", failure: riottest.Failure{Status: http.StatusTooManyRequests, RetryAfter: 1, Times: 1}},
+		{name: "unavailable with backoff", failure: riottest.Failure{Status: http.StatusServiceUnavailable, Times: 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := riottest.NewServerWithFixtures()
+			defer server.Close()
+			server.Fail(fixtureMatchPath, tt.failure)
+
+			if _, err := server.Client("test-key", "europe").GetMatch(riottest.FixtureMatchID); err != nil {
+				t.Fatalf("GetMatch: %v", err)
+			}
+			if n := server.CallCount(fixtureMatchPath); n != 2 {
+				t.Errorf("%d requests, want 2 (one failure, one retry)", n)
+			}
+		})
+	}
+}
+
+func TestGetMatchRetryTiming(t *testing.T) {
+	server := riottest.NewServerWithFixtures()
+	defer server.Close()
+	client := server.Client("test-key", "europe")
+
+	// Retry-After is honoured before the retry
+	server.Fail(fixtureMatchPath, riottest.Failure{Status: http.StatusTooManyRequests, RetryAfter: 1, Times: 1})
+	start := time.Now()
+	if _, err := client.GetMatch(riottest.FixtureMatchID); err != nil {
+		t.Fatalf("GetMatch: %v", err)
+	}
+	if d := time.Since(start); d < 900*time.Millisecond {
+		t.Errorf("retried after %s, want the 1 s Retry-After", d)
+	}
+
+	// The limit reported in response headers throttles the next request
+	server.SetAppRateLimit("1:1")
+	if _, err := client.GetMatch(riottest.FixtureMatchID); err != nil {
+		t.Fatalf("GetMatch: %v", err)
+	}
+	start = time.Now()
+	if _, err := client.GetMatch(riottest.FixtureMatchID); err != nil {
+		t.Fatalf("GetMatch: %v", err)
+	}
+	if d := time.Since(start); d < 800*time.Millisecond {
+		t.Errorf("request inside a reported 1:1 limit waited %s, want about 1 s", d)
+	}
+}
+
+func TestGetMatchRetriesExhausted(t *testing.T) {
+	server := riottest.NewServer()
+	defer server.Close()
+	server.Fail(fixtureMatchPath, riottest.Failure{Status: http.StatusServiceUnavailable})
+
+	// Three retries back off 0.5 s, 1 s and 2 s before the error is returned
+	start := time.Now()
+	_, err := server.Client("test-key", "europe").GetMatch(riottest.FixtureMatchID)
+	var apiErr *riot.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("err = %v, want a 503 APIError", err)
+	}
+	if n := server.CallCount(fixtureMatchPath); n != 4 {
+		t.Errorf("%d requests, want 4 (one try and three retries)", n)
+	}
+	if d := time.Since(start); d < 3*time.Second {
+		t.Errorf("gave up after %s, want about 3.5 s of backoff", d)
+	}
+}
+
+func TestGetMatchCache(t *testing.T) {
+	server := riottest.NewServerWithFixtures()
+	defer server.Close()
+	dir := t.TempDir()
+
+	cache, err := matchcache.New(dir, 10)
+	if err != nil {
+		t.Fatalf("matchcache.New: %v", err)
+	}
+	client := server.Client("test-key", "europe")
+	client.SetCache(cache)
+	for i := 0; i < 2; i++ {
+		match, err := client.GetMatch(riottest.FixtureMatchID)
+		if err != nil {
+			t.Fatalf("GetMatch: %v", err)
+		}
+		if len(match.Info.Participants) != 10 {
+			t.Fatalf("cached match has %d participants, want 10", len(match.Info.Participants))
+		}
+	}
+	if n := server.CallCount(fixtureMatchPath); n != 1 {
+		t.Errorf("%d requests, want 1", n)
+	}
+	if stats := cache.Stats(); stats.MemoryHits != 1 || stats.Stores != 1 {
+		t.Errorf("stats = %+v, want 1 memory hit and 1 store", stats)
+	}
+
+	// A new cache on the same directory serves the match from disk
+	reloaded, err := matchcache.New(dir, 10)
+	if err != nil {
+		t.Fatalf("matchcache.New: %v", err)
+	}
+	client = server.Client("test-key", "europe")
+	client.SetCache(reloaded)
+	if _, err := client.GetMatch(riottest.FixtureMatchID); err != nil {
+		t.Fatalf("GetMatch: %v", err)
+	}
+	if n := server.CallCount(fixtureMatchPath); n != 1 {
+		t.Errorf("%d requests after reload, want 1", n)
+	}
+	if stats := reloaded.Stats(); stats.DiskHits != 1 {
+		t.Errorf("stats = %+v, want 1 disk hit", stats)
+	}
+}
@@ -0,0 +1,174 @@
+package riot
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"lol-ranked-new-meta/staticdata"
+	"lol-ranked-new-meta/types"
+)
+
+// Ping categories used for the communication profile
+const (
+	pingInformative  = "informative"
+	pingCallToAction = "call_to_action"
+	pingCaution      = "caution"
+)
+
+// Thresholds for the communication style
+const (
+	silentPingsPerMinute  = 0.2 // Below this a player barely pinged
+	negativeMinimumPings  = 15  // Heavy "?" pinging only reads as negative with some volume
+	negativeEnemyMissing  = 0.5 // Share of enemy missing ("?") pings that reads as flaming
+	dominantCategoryShare = 0.5 // Share a category needs to define the style
+)
+
+// pingType is one ping counter on RiotParticipant
+type pingType struct {
+	name     string
+	category string
+	count    func(p *types.RiotParticipant) int
+}
+
+var pingTypes = []pingType{
+	{"Enemy Missing", pingInformative, func(p *types.RiotParticipant) int { return p.EnemyMissingPings }},
+	{"Enemy Vision", pingInformative, func(p *types.RiotParticipant) int { return p.EnemyVisionPings }},
+	{"Need Vision", pingInformative, func(p *types.RiotParticipant) int { return p.NeedVisionPings }},
+	{"Vision Cleared", pingInformative, func(p *types.RiotParticipant) int { return p.VisionClearedPings }},
+	{"Danger", pingInformative, func(p *types.RiotParticipant) int { return p.DangerPings }},
+	{"All In", pingCallToAction, func(p *types.RiotParticipant) int { return p.AllInPings }},
+	{"Push", pingCallToAction, func(p *types.RiotParticipant) int { return p.PushPings }},
+	{"On My Way", pingCallToAction, func(p *types.RiotParticipant) int { return p.OnMyWayPings }},
+	{"Assist Me", pingCallToAction, func(p *types.RiotParticipant) int { return p.AssistMePings }},
+	{"Command", pingCallToAction, func(p *types.RiotParticipant) int { return p.CommandPings }},
+	{"Get Back", pingCaution, func(p *types.RiotParticipant) int { return p.GetBackPings }},
+	{"Retreat", pingCaution, func(p *types.RiotParticipant) int { return p.RetreatPings }},
+	{"Hold", pingCaution, func(p *types.RiotParticipant) int { return p.HoldPings }},
+}
+
+// BuildCommunicationProfiles profiles every participant's pings and compares them with their teammates
+// Basic pings are left out of the mix: they carry no intent
+func BuildCommunicationProfiles(match *types.RiotMatch) []types.CommunicationProfile {
+	if match == nil {
+		return nil
+	}
+	minutes := float64(match.Info.GameDuration) / 60.0
+
+	var profiles []types.CommunicationProfile
+	for i := range match.Info.Participants {
+		p := &match.Info.Participants[i]
+		profile := types.CommunicationProfile{
+			Puuid:        p.Puuid,
+			SummonerName: p.SummonerName,
+			ChampionName: p.ChampionName,
+			TeamID:       p.TeamID,
+		}
+		for _, pt := range pingTypes {
+			count := pt.count(p)
+			if count == 0 {
+				continue
+			}
+			profile.TotalPings += count
+			profile.Mix = append(profile.Mix, types.PingCount{Type: pt.name, Count: count})
+			switch pt.category {
+			case pingInformative:
+				profile.Informative += count
+			case pingCallToAction:
+				profile.CallToAction += count
+			case pingCaution:
+				profile.Caution += count
+			}
+		}
+		sort.SliceStable(profile.Mix, func(a, b int) bool { return profile.Mix[a].Count > profile.Mix[b].Count })
+		profile.PingsPerMinute = pingRate(profile.TotalPings, minutes)
+		profile.Style = communicationStyle(profile, p.EnemyMissingPings)
+		profiles = append(profiles, profile)
+	}
+
+	// Compare with teammates (Arena: the duo partner)
+	for i := range profiles {
+		total, teammates := 0, 0
+		for j := range profiles {
+			if i != j && sameSide(&match.Info.Participants[i], &match.Info.Participants[j]) {
+				total += profiles[j].TotalPings
+				teammates++
+			}
+		}
+		if teammates > 0 {
+			profiles[i].TeamAverage = pingRate(total, minutes*float64(teammates))
+		}
+	}
+	return profiles
+}
+
+// FormatCommunication lists each player's ping profile in lang; target's line is marked when it is non-nil
+// static is optional - it adds localized champion names
+func FormatCommunication(match *types.RiotMatch, profiles []types.CommunicationProfile, target *types.RiotParticipant, static *staticdata.Bundle, lang Language) string {
+	queue := MatchQueue(match)
+	var result string
+	for _, profile := range profiles {
+		side := lang.label(teamLabel(profile.TeamID))
+		if p := FindParticipantByPUUID(match, profile.Puuid); p != nil {
+			side = participantTeamLabel(queue, p, lang)
+		}
+		marker := ""
+		if target != nil && profile.Puuid == target.Puuid {
+			marker = " [" + lang.label("TARGET") + "]"
+		}
+		var mix []string
+		for _, pc := range profile.Mix {
+			mix = append(mix, fmt.Sprintf("%s %d", lang.label(pc.Type), pc.Count))
+		}
+		result += lang.sprintf("- %s (%s, %s)%s: %d pings (%.2f/min vs teammates %.2f/min), style %s; informative %d, call to action %d, caution %d; mix: %s",
+			profile.SummonerName, championNameLabel(static, profile.ChampionName), side, marker,
+			profile.TotalPings, profile.PingsPerMinute, profile.TeamAverage, lang.label(profile.Style),
+			profile.Informative, profile.CallToAction, profile.Caution, lang.label(joinOrNone(mix))) + "\n"
+	}
+	return result
+}
+
+// HasFocusArea reports whether focusAreas contains area (case-insensitive)
+func HasFocusArea(focusAreas []string, area string) bool {
+	for _, a := range focusAreas {
+		if strings.EqualFold(strings.TrimSpace(a), area) {
+			return true
+		}
+	}
+	return false
+}
+
+// communicationStyle labels a profile by volume and its dominant ping category
+func communicationStyle(profile types.CommunicationProfile, enemyMissing int) string {
+	total := float64(profile.TotalPings)
+	switch {
+	case profile.PingsPerMinute < silentPingsPerMinute:
+		return "silent"
+	case profile.TotalPings >= negativeMinimumPings && float64(enemyMissing)/total > negativeEnemyMissing:
+		return "negative"
+	case float64(profile.Informative)/total >= dominantCategoryShare:
+		return "informative"
+	case float64(profile.CallToAction)/total >= dominantCategoryShare:
+		return "directive"
+	case float64(profile.Caution)/total >= dominantCategoryShare:
+		return "cautious"
+	default:
+		return "balanced"
+	}
+}
+
+// sameSide reports whether two participants are teammates (or duo partners in Arena)
+func sameSide(a, b *types.RiotParticipant) bool {
+	if a.PlayerSubteamID > 0 || b.PlayerSubteamID > 0 {
+		return a.PlayerSubteamID == b.PlayerSubteamID
+	}
+	return a.TeamID == b.TeamID
+}
+
+func pingRate(pings int, minutes float64) float64 {
+	if minutes <= 0 {
+		return 0
+	}
+	return math.Round(float64(pings)/minutes*100) / 100
+}
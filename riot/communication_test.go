@@ -0,0 +1,120 @@
+package riot_test
+
+import (
+	"testing"
+
+	"lol-ranked-new-meta/riot"
+	"lol-ranked-new-meta/types"
+)
+
+// pingMatch returns a ten-minute match with the given participants
+func pingMatch(participants ...types.RiotParticipant) *types.RiotMatch {
+	match := &types.RiotMatch{}
+	match.Info.GameDuration = 600
+	match.Info.Participants = participants
+	return match
+}
+
+func TestCommunicationStyle(t *testing.T) {
+	tests := []struct {
+		name        string
+		participant types.RiotParticipant
+		style       string
+	}{
+		{name: "no pings", participant: types.RiotParticipant{}, style: "silent"},
+		// silentPingsPerMinute is 0.2: one ping in ten minutes is silent, two are not
+		{name: "below silent rate", participant: types.RiotParticipant{OnMyWayPings: 1}, style: "silent"},
+		{name: "at silent rate", participant: types.RiotParticipant{OnMyWayPings: 2}, style: "directive"},
+		// negativeMinimumPings is 15 and negativeEnemyMissing is more than half of them
+		{name: "negative", participant: types.RiotParticipant{EnemyMissingPings: 8, PushPings: 7}, style: "negative"},
+		{name: "too few to read as negative", participant: types.RiotParticipant{EnemyMissingPings: 13, PushPings: 1}, style: "informative"},
+		{name: "half enemy missing", participant: types.RiotParticipant{EnemyMissingPings: 8, PushPings: 8}, style: "informative"},
+		{name: "enemy missing with other info", participant: types.RiotParticipant{EnemyMissingPings: 7, DangerPings: 2, PushPings: 7}, style: "informative"},
+		{name: "informative", participant: types.RiotParticipant{EnemyVisionPings: 2, NeedVisionPings: 1, AllInPings: 3}, style: "informative"},
+		{name: "directive", participant: types.RiotParticipant{AssistMePings: 2, CommandPings: 2, HoldPings: 3}, style: "directive"},
+		{name: "cautious", participant: types.RiotParticipant{GetBackPings: 2, RetreatPings: 1, DangerPings: 2}, style: "cautious"},
+		{name: "balanced", participant: types.RiotParticipant{DangerPings: 2, PushPings: 2, HoldPings: 2}, style: "balanced"},
+		// Basic pings carry no intent and are not counted
+		{name: "basic pings only", participant: types.RiotParticipant{BasicPings: 30}, style: "silent"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profiles := riot.BuildCommunicationProfiles(pingMatch(tt.participant))
+			if len(profiles) != 1 {
+				t.Fatalf("%d profiles, want 1", len(profiles))
+			}
+			if profiles[0].Style != tt.style {
+				t.Errorf("style = %q, want %q (profile %+v)", profiles[0].Style, tt.style, profiles[0])
+			}
+		})
+	}
+}
+
+func TestBuildCommunicationProfiles(t *testing.T) {
+	profiles := riot.BuildCommunicationProfiles(pingMatch(types.RiotParticipant{
+		Puuid: "a", EnemyMissingPings: 3, DangerPings: 1, PushPings: 4, OnMyWayPings: 1, RetreatPings: 2, BasicPings: 9,
+	}))
+	p := profiles[0]
+	if p.TotalPings != 11 || p.PingsPerMinute != 1.1 || p.Informative != 4 || p.CallToAction != 5 || p.Caution != 2 {
+		t.Errorf("profile = %+v, want 11 pings at 1.1/min: 4 informative, 5 call to action, 2 caution", p)
+	}
+	// Most used first, ties in pingTypes order
+	want := []types.PingCount{
+		{Type: "Push", Count: 4}, {Type: "Enemy Missing", Count: 3}, {Type: "Retreat", Count: 2},
+		{Type: "Danger", Count: 1}, {Type: "On My Way", Count: 1},
+	}
+	if len(p.Mix) != len(want) {
+		t.Fatalf("mix = %v, want %v", p.Mix, want)
+	}
+	for i := range want {
+		if p.Mix[i] != want[i] {
+			t.Errorf("mix = %v, want %v", p.Mix, want)
+			break
+		}
+	}
+
+	if riot.BuildCommunicationProfiles(nil) != nil {
+		t.Error("BuildCommunicationProfiles(nil) returned profiles")
+	}
+}
+
+func TestCommunicationTeamAverage(t *testing.T) {
+	tests := []struct {
+		name         string
+		participants []types.RiotParticipant
+		want         []float64
+	}{
+		{
+			name: "teams",
+			participants: []types.RiotParticipant{
+				{TeamID: 100, PushPings: 10},
+				{TeamID: 100, PushPings: 20},
+				{TeamID: 100, PushPings: 30},
+				{TeamID: 200, PushPings: 40},
+			},
+			// Teammates only, without the player; a player alone on a team has no average
+			want: []float64{2.5, 2, 1.5, 0},
+		},
+		{
+			// Arena puts every player on one team ID; only the duo partner counts
+			name: "arena duos",
+			participants: []types.RiotParticipant{
+				{TeamID: 100, PlayerSubteamID: 1, PushPings: 10},
+				{TeamID: 100, PlayerSubteamID: 1, PushPings: 20},
+				{TeamID: 100, PlayerSubteamID: 2, PushPings: 30},
+				{TeamID: 100, PlayerSubteamID: 2},
+			},
+			want: []float64{2, 1, 0, 3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profiles := riot.BuildCommunicationProfiles(pingMatch(tt.participants...))
+			for i, p := range profiles {
+				if p.TeamAverage != tt.want[i] {
+					t.Errorf("participant %d team average = %.2f/min, want %.2f", i+1, p.TeamAverage, tt.want[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package riot
+
+import (
+	"encoding/json"
+	"strings"
+
+	"lol-ranked-new-meta/metrics"
+	"lol-ranked-new-meta/types"
+)
+
+// Participant table columns of CompactMatch: the scoreboard, plus the derived metrics from standard verbosity on
+var (
+	compactScoreColumns  = []string{"pid", "name", "champ", "team", "pos", "win", "k", "d", "a", "cs", "g", "dmg"}
+	compactMetricColumns = []string{"vs", "kp", "dsh", "gsh", "cspm", "dpm", "vspm", "dpg", "d10"}
+)
+
+// Key legends for the model, kept short since they are sent with every prompt
+const (
+	compactScoreLegend = `tgt target pid. team 100 blue/200 red/Arena duo, pos resolved role, cs minions+monsters, g gold, dmg champion damage. t teams: w win, k kills, tw turrets, dr dragons, bn barons. Missing numbers are 0.
+`
+	compactMetricLegend = `vs vision score, kp kill participation, dsh/gsh damage/gold share, cspm/vspm/dpm/gpm per minute, dpg damage/gold, d10 deaths/10min. t also: bld building damage, cc CC seconds, inh inhibitors, hr heralds. f first objective team: fb blood, ftw tower, finh inhib, fdr dragon, fhr herald, fbn baron.
+`
+)
+
+// BuildCompactMatch encodes the match scoreboard, team aggregates and, from standard verbosity on, derived metrics and firsts with short keys
+// It carries what the text format's team lines, participant list, TEAM COMPARISON and DERIVED METRICS sections do at that verbosity
+// roles is ResolveRoles(match); target is optional and marked by its participant ID
+func BuildCompactMatch(match *types.RiotMatch, roles map[int]types.RoleAssignment, target *types.RiotParticipant, verbosity Verbosity) *types.CompactMatch {
+	if match == nil {
+		return nil
+	}
+	withMetrics := verbosity >= VerbosityStandard
+	compact := &types.CompactMatch{Columns: compactScoreColumns}
+	if withMetrics {
+		compact.Columns = append(append([]string(nil), compactScoreColumns...), compactMetricColumns...)
+	}
+	if target != nil {
+		compact.Target = target.ParticipantID
+	}
+
+	m := metrics.Compute(match)
+	for i := range match.Info.Participants {
+		p := &match.Info.Participants[i]
+		row := []interface{}{
+			p.ParticipantID, p.SummonerName, p.ChampionName, metrics.TeamKey(p), ResolvedPosition(roles, p), p.Win,
+			p.Kills, p.Deaths, p.Assists, metrics.CreepScore(p), p.GoldEarned,
+			p.TotalDamageDealtToChampions,
+		}
+		if withMetrics {
+			pm, _ := metrics.ForParticipant(m, p.ParticipantID)
+			row = append(row, p.VisionScore, pm.KillParticipation, pm.DamageShare, pm.GoldShare, pm.CSPerMinute, pm.DamagePerMinute,
+				pm.VisionPerMinute, pm.DamagePerGold, pm.DeathsPer10)
+		}
+		compact.Participants = append(compact.Participants, row)
+	}
+
+	cmp := metrics.CompareTeams(match)
+	for _, tm := range m.Teams {
+		team := types.CompactTeam{TeamID: tm.TeamID, Win: tm.Win, Kills: tm.Kills}
+		if withMetrics {
+			team.Deaths = tm.Deaths
+			team.Assists = tm.Assists
+			team.GoldEarned = tm.GoldEarned
+			team.DamageToChampions = tm.DamageToChamps
+			team.VisionScore = tm.VisionScore
+			team.CSPerMinute = tm.CSPerMinute
+			team.VisionPerMinute = tm.VisionPerMinute
+			team.DamagePerMinute = tm.DamagePerMinute
+			team.GoldPerMinute = tm.GoldPerMinute
+			team.DamagePerGold = tm.DamagePerGold
+			team.DeathsPer10 = tm.DeathsPer10
+		}
+		if cmp != nil {
+			for _, t := range cmp.Teams {
+				if t.TeamID != tm.TeamID {
+					continue
+				}
+				team.Turrets = t.Turrets
+				team.Dragons = t.Dragons
+				team.Barons = t.Barons
+				if withMetrics {
+					team.DamageToBuildings = t.DamageToBuildings
+					team.CCTime = t.CCTime
+					team.Inhibitors = t.Inhibitors
+					team.Heralds = t.Heralds
+				}
+			}
+		}
+		compact.Teams = append(compact.Teams, team)
+	}
+	if cmp != nil && withMetrics {
+		f := cmp.Firsts
+		compact.Firsts = &types.CompactFirsts{
+			Blood:     f.FirstBlood,
+			Tower:     f.FirstTower,
+			Inhibitor: f.FirstInhibitor,
+			Dragon:    f.FirstDragon,
+			Herald:    f.FirstHerald,
+			Baron:     f.FirstBaron,
+		}
+	}
+	return compact
+}
+
+// FormatCompactMatch renders the compact match as one line of JSON followed by the key legend
+func FormatCompactMatch(compact *types.CompactMatch) string {
+	if compact == nil {
+		return ""
+	}
+	data, err := json.Marshal(compact)
+	if err != nil {
+		return ""
+	}
+	legend := "p rows: " + strings.Join(compact.Columns, ",") + ". " + compactScoreLegend
+	if len(compact.Columns) > len(compactScoreColumns) {
+		legend += compactMetricLegend
+	}
+	return string(data) + "\n" + legend
+}
@@ -0,0 +1,44 @@
+package riot_test
+
+import (
+	"strings"
+	"testing"
+
+	"lol-ranked-new-meta/riot"
+	"lol-ranked-new-meta/riottest"
+)
+
+func TestBuildCompactMatchVerbosity(t *testing.T) {
+	match := riottest.FixtureMatch()
+	roles := riot.ResolveRoles(match)
+	target := &match.Info.Participants[0]
+
+	compact := riot.BuildCompactMatch(match, roles, target, riot.VerbosityCompact)
+	if compact.Target != 1 {
+		t.Errorf("Target = %d, want 1", compact.Target)
+	}
+	if len(compact.Participants) != 10 || len(compact.Participants[0]) != len(compact.Columns) {
+		t.Fatalf("got %d rows of %d values for %d columns", len(compact.Participants), len(compact.Participants[0]), len(compact.Columns))
+	}
+	if compact.Firsts != nil || compact.Teams[0].DamageToChampions != 0 {
+		t.Error("compact verbosity should carry only the scoreboard and team totals")
+	}
+	if text := riot.FormatCompactMatch(compact); strings.Contains(text, "kill participation") {
+		t.Error("compact legend explains metric columns that are not sent")
+	}
+
+	standard := riot.BuildCompactMatch(match, roles, target, riot.VerbosityStandard)
+	if len(standard.Participants[0]) != len(standard.Columns) || len(standard.Columns) <= len(compact.Columns) {
+		t.Fatalf("standard verbosity has %d columns, want the metric columns after the %d score columns", len(standard.Columns), len(compact.Columns))
+	}
+	if standard.Firsts == nil || standard.Teams[0].DamageToChampions == 0 {
+		t.Error("standard verbosity is missing firsts or team metrics")
+	}
+	text := riot.FormatCompactMatch(standard)
+	if !strings.Contains(text, "p rows: pid,name,champ") || !strings.Contains(text, "kill participation") {
+		t.Errorf("legend does not name the columns and metrics:\n%s", text)
+	}
+	if strings.Contains(text, `"cols"`) || strings.Contains(text, `"dur"`) {
+		t.Error("JSON repeats the columns or the prose header")
+	}
+}
@@ -0,0 +1,161 @@
+package riot
+
+import (
+	"sort"
+	"strings"
+
+	"lol-ranked-new-meta/staticdata"
+	"lol-ranked-new-meta/types"
+)
+
+// classPositions maps a champion's primary Data Dragon class to the one position it is played in
+// Data Dragon has no role data, so only classes tied to a single position are listed; Mages, Assassins,
+// Fighters and Tanks spread over several positions and would flag most bans for any of them
+var classPositions = map[string]string{
+	"Marksman": types.PositionBottom,
+	"Support":  types.PositionUtility,
+}
+
+// BuildDraft collects each team's bans in pick order and its composition
+// roles is ResolveRoles(match); static may be nil (ban names fall back to IDs and no role-targeted bans are found); target may be nil.
+// Returns nil for Arena, where duos rather than two teams draft.
+func BuildDraft(match *types.RiotMatch, roles map[int]types.RoleAssignment, static *staticdata.Bundle, target *types.RiotParticipant) *types.Draft {
+	if match == nil || MatchQueue(match).Format == types.FormatArena {
+		return nil
+	}
+
+	draft := &types.Draft{}
+	for _, team := range match.Info.Teams {
+		td := types.TeamDraft{TeamID: team.TeamID}
+		for _, ban := range team.Bans {
+			if ban.ChampionID <= 0 {
+				continue
+			}
+			td.Bans = append(td.Bans, types.DraftBan{
+				TeamID:       team.TeamID,
+				PickTurn:     ban.PickTurn,
+				ChampionID:   ban.ChampionID,
+				ChampionName: static.ChampionName(ban.ChampionID),
+			})
+		}
+		sort.SliceStable(td.Bans, func(i, j int) bool { return td.Bans[i].PickTurn < td.Bans[j].PickTurn })
+
+		for i := range match.Info.Participants {
+			p := &match.Info.Participants[i]
+			if p.TeamID != team.TeamID {
+				continue
+			}
+			pick := types.DraftPick{
+				SummonerName: p.SummonerName,
+				ChampionID:   p.ChampionID,
+				ChampionName: p.ChampionName,
+			}
+			if role, ok := roles[p.ParticipantID]; ok {
+				pick.Position = role.Position
+			}
+			if champion, ok := static.Champion(p.ChampionID); ok {
+				pick.ChampionName = champion.Name
+				pick.Classes = champion.Tags
+			}
+			td.Composition = append(td.Composition, pick)
+		}
+		draft.Teams = append(draft.Teams, td)
+	}
+
+	if target != nil {
+		if role, ok := roles[target.ParticipantID]; ok {
+			draft.TargetPosition = role.Position
+		}
+	}
+	draft.RoleBansDeterminable = static != nil && positionHasClass(draft.TargetPosition)
+	if draft.RoleBansDeterminable {
+		for _, td := range draft.Teams {
+			// A ban by the target's own team is not aimed at them
+			if td.TeamID == target.TeamID {
+				continue
+			}
+			for _, ban := range td.Bans {
+				if champion, ok := static.Champion(ban.ChampionID); ok && playedInPosition(champion, draft.TargetPosition) {
+					draft.BansTargetingRole = append(draft.BansTargetingRole, ban)
+				}
+			}
+		}
+	}
+	return draft
+}
+
+// FormatDraft lists bans and compositions per team in lang; targetTeamID marks the analyzed player's team (0 for none)
+func FormatDraft(draft *types.Draft, targetTeamID int, lang Language) string {
+	if draft == nil {
+		return ""
+	}
+
+	var result string
+	for _, td := range draft.Teams {
+		label := lang.sprintf("Team %s", lang.label(teamLabel(td.TeamID)))
+		if targetTeamID != 0 {
+			if td.TeamID == targetTeamID {
+				label += " (" + lang.label("YOUR TEAM") + ")"
+			} else {
+				label += " (" + lang.label("ENEMY TEAM") + ")"
+			}
+		}
+		result += label + ":\n"
+
+		var bans []string
+		for _, ban := range td.Bans {
+			bans = append(bans, lang.sprintf("%s (turn %d)", ban.ChampionName, ban.PickTurn))
+		}
+		result += lang.sprintf("- Bans: %s", lang.label(joinOrNone(bans))) + "\n"
+
+		var picks []string
+		for _, pick := range td.Composition {
+			entry := pick.ChampionName
+			var details []string
+			if pick.Position != "" {
+				details = append(details, pick.Position)
+			}
+			if len(pick.Classes) > 0 {
+				details = append(details, strings.Join(pick.Classes, "/"))
+			}
+			if len(details) > 0 {
+				entry += " (" + strings.Join(details, ", ") + ")"
+			}
+			picks = append(picks, entry)
+		}
+		result += lang.sprintf("- Composition: %s", lang.label(joinOrNone(picks))) + "\n"
+	}
+
+	// When no bans can be matched to the role, say why instead of leaving the question unanswered
+	switch {
+	case draft.TargetPosition == "":
+	case !positionHasClass(draft.TargetPosition):
+		result += lang.sprintf("Enemy bans aimed at the target's role (%s): not determinable - champion classes only single out ADC and support champions",
+			draft.TargetPosition) + "\n"
+	case !draft.RoleBansDeterminable:
+		result += lang.sprintf("Enemy bans aimed at the target's role (%s): not determinable without static data", draft.TargetPosition) + "\n"
+	default:
+		var targeted []string
+		for _, ban := range draft.BansTargetingRole {
+			targeted = append(targeted, lang.sprintf("%s (by %s)", ban.ChampionName, lang.label(teamLabel(ban.TeamID))))
+		}
+		result += lang.sprintf("Enemy bans of champions played in the target's role (%s, by champion class): %s",
+			draft.TargetPosition, lang.label(joinOrNone(targeted))) + "\n"
+	}
+	return result
+}
+
+// positionHasClass reports whether some champion class is tied to position alone, so bans for it can be found
+func positionHasClass(position string) bool {
+	for _, p := range classPositions {
+		if p == position {
+			return true
+		}
+	}
+	return false
+}
+
+// playedInPosition reports whether the champion's primary class belongs to position
+func playedInPosition(champion staticdata.Champion, position string) bool {
+	return len(champion.Tags) > 0 && classPositions[champion.Tags[0]] == position
+}
@@ -0,0 +1,135 @@
+package riot_test
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"lol-ranked-new-meta/riot"
+	"lol-ranked-new-meta/riottest"
+	"lol-ranked-new-meta/staticdata"
+	"lol-ranked-new-meta/types"
+)
+
+// fixtureBundle loads the riottest Data Dragon fixture for the fixture match's patch
+func fixtureBundle(t *testing.T, locale string) *staticdata.Bundle {
+	t.Helper()
+	service, err := riottest.NewStaticData(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStaticData: %v", err)
+	}
+	bundle, err := service.BundleForLocale(riottest.FixtureMatch().Info.GameVersion, locale)
+	if err != nil {
+		t.Fatalf("BundleForLocale(%s): %v", locale, err)
+	}
+	return bundle
+}
+
+func TestBuildDraft(t *testing.T) {
+	match := riottest.FixtureMatch()
+	roles := riot.ResolveRoles(match)
+	draft := riot.BuildDraft(match, roles, nil, &match.Info.Participants[0])
+	if draft == nil || len(draft.Teams) != 2 {
+		t.Fatalf("draft = %+v, want two teams", draft)
+	}
+
+	// Blue's empty ban (champion -1 at turn 4) is left out; the rest stay in pick order
+	var turns [2][]int
+	for i, td := range draft.Teams {
+		for _, ban := range td.Bans {
+			turns[i] = append(turns[i], ban.PickTurn)
+			if ban.TeamID != td.TeamID {
+				t.Errorf("ban %+v is listed under team %d", ban, td.TeamID)
+			}
+		}
+		if len(td.Composition) != 5 {
+			t.Errorf("team %d has %d picks, want 5", td.TeamID, len(td.Composition))
+		}
+	}
+	if got := [2]string{joinInts(turns[0]), joinInts(turns[1])}; got != [2]string{"1,2,3,5", "6,7,8,9,10"} {
+		t.Errorf("ban turns = %v", got)
+	}
+	if got := draft.Teams[0].Bans[0]; got.ChampionID != 157 || got.ChampionName != "Champion 157" {
+		t.Errorf("first ban = %+v, want Champion 157 without static data", got)
+	}
+	if pick := draft.Teams[0].Composition[0]; pick.ChampionName != "Aatrox" || pick.Position != types.PositionTop {
+		t.Errorf("first pick = %+v", pick)
+	}
+	if draft.TargetPosition != types.PositionTop || draft.RoleBansDeterminable || draft.BansTargetingRole != nil {
+		t.Errorf("target %s: determinable %v, bans %+v", draft.TargetPosition, draft.RoleBansDeterminable, draft.BansTargetingRole)
+	}
+
+	match.Info.QueueID = 1700
+	if draft := riot.BuildDraft(match, roles, nil, nil); draft != nil {
+		t.Errorf("Arena draft = %+v, want nil", draft)
+	}
+}
+
+func TestBuildDraftBansTargetingRole(t *testing.T) {
+	match := riottest.FixtureMatch()
+	roles := riot.ResolveRoles(match)
+	static := fixtureBundle(t, staticdata.DefaultLocale)
+
+	tests := []struct {
+		participant  int
+		determinable bool
+		want         string
+	}{
+		{4, true, "Samira,Kai'Sa"}, // Jinx: red banned two Marksmen
+		{10, true, "Pyke"},         // Nautilus: blue banned Pyke; red's own bans do not count
+		{5, true, ""},              // Thresh: red banned no Support
+		{1, false, ""},             // Aatrox: no class belongs to top alone
+		{2, false, ""},
+		{3, false, ""},
+	}
+	for _, tt := range tests {
+		draft := riot.BuildDraft(match, roles, static, &match.Info.Participants[tt.participant-1])
+		var names []string
+		for _, ban := range draft.BansTargetingRole {
+			names = append(names, ban.ChampionName)
+		}
+		if draft.RoleBansDeterminable != tt.determinable || strings.Join(names, ",") != tt.want {
+			t.Errorf("participant %d: determinable %v, bans %q; want %v, %q", tt.participant, draft.RoleBansDeterminable, names, tt.determinable, tt.want)
+		}
+	}
+}
+
+func TestFormatDraft(t *testing.T) {
+	match := riottest.FixtureMatch()
+	roles := riot.ResolveRoles(match)
+	static := fixtureBundle(t, staticdata.DefaultLocale)
+
+	jinx := &match.Info.Participants[3]
+	text := riot.FormatDraft(riot.BuildDraft(match, roles, static, jinx), jinx.TeamID, riot.English)
+	for _, want := range []string{
+		"Team Blue (YOUR TEAM):\n- Bans: Yasuo (turn 1), Zed (turn 2), Pyke (turn 3), Kha'Zix (turn 5)\n",
+		"Team Red (ENEMY TEAM):",
+		"Jinx (BOTTOM, Marksman)",
+		"Enemy bans of champions played in the target's role (BOTTOM, by champion class): Samira (by Red), Kai'Sa (by Red)\n",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("draft is missing %q:\n%s", want, text)
+		}
+	}
+
+	thresh := &match.Info.Participants[4]
+	if text := riot.FormatDraft(riot.BuildDraft(match, roles, static, thresh), thresh.TeamID, riot.English); !strings.Contains(text, "(UTILITY, by champion class): none\n") {
+		t.Errorf("support with no targeted bans should read none:\n%s", text)
+	}
+
+	aatrox := &match.Info.Participants[0]
+	if text := riot.FormatDraft(riot.BuildDraft(match, roles, static, aatrox), aatrox.TeamID, riot.English); !strings.Contains(text, "(TOP): not determinable - champion classes only single out ADC and support champions") {
+		t.Errorf("top laner should get the not determinable line:\n%s", text)
+	}
+	if text := riot.FormatDraft(riot.BuildDraft(match, roles, nil, jinx), jinx.TeamID, riot.English); !strings.Contains(text, "(BOTTOM): not determinable without static data") {
+		t.Errorf("no static data should get the not determinable line:\n%s", text)
+	}
+}
+
+func joinInts(values []int) string {
+	var parts []string
+	for _, v := range values {
+		parts = append(parts, strconv.Itoa(v))
+	}
+	return strings.Join(parts, ",")
+}
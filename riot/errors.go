@@ -0,0 +1,53 @@
+package riot
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Errors returned by Client methods; check them with errors.Is
+var (
+	ErrNotFound    = errors.New("riot API: not found")
+	ErrRateLimited = errors.New("riot API: rate limited")
+	ErrForbidden   = errors.New("riot API: forbidden (missing, invalid or expired API key)")
+	ErrUnavailable = errors.New("riot API: unavailable")
+)
+
+// APIError is a non-200 response from the Riot API
+// errors.Is matches it against ErrNotFound, ErrRateLimited, ErrForbidden or ErrUnavailable depending on the status
+type APIError struct {
+	StatusCode int
+	Method     string        // Riot endpoint, e.g. match-v5.getMatch
+	Body       string        // Raw response body, for logs only
+	RetryAfter time.Duration // Set on 429 responses when Riot sent Retry-After
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("riot API error: %s returned status %d, body: %s", e.Method, e.StatusCode, e.Body)
+}
+
+// Is reports whether the status code belongs to one of the sentinel errors
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrForbidden:
+		return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden
+	case ErrUnavailable:
+		return e.StatusCode >= http.StatusInternalServerError
+	}
+	return false
+}
+
+// RetryAfter returns how long to wait before retrying a rate limited request, if Riot said so
+func RetryAfter(err error) (time.Duration, bool) {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.RetryAfter > 0 {
+		return apiErr.RetryAfter, true
+	}
+	return 0, false
+}
@@ -0,0 +1,113 @@
+package riot
+
+import (
+	"fmt"
+
+	"lol-ranked-new-meta/staticdata"
+	"lol-ranked-new-meta/types"
+)
+
+// MatchExtras carries optional data that enriches the match summary beyond the match-v5 payload
+// Any field may be nil; the formatters fall back to match-only output
+type MatchExtras struct {
+	Timeline   *types.RiotMatchTimeline
+	Static     *staticdata.Bundle           // Data Dragon names for the match's patch
+	Ranks      []types.PlayerRank           // Current ranked standing of each participant
+	Mastery    *types.RiotChampionMastery   // Deep dive target's mastery on the champion they played
+	Benchmarks *types.BenchmarkReport       // Deep dive target's stats rated against their role and tier
+	Findings   []types.CoachingFinding      // Deep dive target's rule-based findings
+	Roles      map[int]types.RoleAssignment // ResolveRoles(match), resolved once and shared by every section
+}
+
+// timeline returns the timeline from extras, tolerating a nil receiver
+func (e *MatchExtras) timeline() *types.RiotMatchTimeline {
+	if e == nil {
+		return nil
+	}
+	return e.Timeline
+}
+
+// static returns the static data bundle from extras, tolerating a nil receiver
+// The returned bundle may be nil; its lookup methods handle that
+func (e *MatchExtras) static() *staticdata.Bundle {
+	if e == nil {
+		return nil
+	}
+	return e.Static
+}
+
+// ranks returns the lobby ranks from extras, tolerating a nil receiver
+func (e *MatchExtras) ranks() []types.PlayerRank {
+	if e == nil {
+		return nil
+	}
+	return e.Ranks
+}
+
+// roles returns the resolved lane positions from extras, resolving them only when the caller did not
+func (e *MatchExtras) roles(match *types.RiotMatch) map[int]types.RoleAssignment {
+	if e != nil && e.Roles != nil {
+		return e.Roles
+	}
+	return ResolveRoles(match)
+}
+
+// mastery returns the target's champion mastery from extras, tolerating a nil receiver
+func (e *MatchExtras) mastery() *types.RiotChampionMastery {
+	if e == nil {
+		return nil
+	}
+	return e.Mastery
+}
+
+// benchmarks returns the target's benchmark report from extras, tolerating a nil receiver
+func (e *MatchExtras) benchmarks() *types.BenchmarkReport {
+	if e == nil {
+		return nil
+	}
+	return e.Benchmarks
+}
+
+// findings returns the target's rule-based findings from extras, tolerating a nil receiver
+func (e *MatchExtras) findings() []types.CoachingFinding {
+	if e == nil {
+		return nil
+	}
+	return e.Findings
+}
+
+// championLabel returns the champion's Data Dragon display name in the bundle's locale, falling back to the match's championName
+func championLabel(static *staticdata.Bundle, p *types.RiotParticipant) string {
+	if champion, ok := static.Champion(p.ChampionID); ok {
+		return champion.Name
+	}
+	return p.ChampionName
+}
+
+// championNameLabel returns the display name of the champion with the given Data Dragon ID (the match's championName), falling back to the ID
+func championNameLabel(static *staticdata.Bundle, championName string) string {
+	if champion, ok := static.ChampionByName(championName); ok {
+		return champion.Name
+	}
+	return championName
+}
+
+// itemLabel formats an item as "Name (ID 3031)", or "Item ID 3031" when the name is unknown
+func itemLabel(static *staticdata.Bundle, itemID int) string {
+	if item, ok := static.Item(itemID); ok {
+		return fmt.Sprintf("%s (ID %d)", item.Name, itemID)
+	}
+	return fmt.Sprintf("Item ID %d", itemID)
+}
+
+// spellLabel formats a summoner spell as "Flash (ID 4)", falling back to the known spells without static data
+// and to "Summoner Spell (ID 4)" when the name is unknown
+func spellLabel(static *staticdata.Bundle, spellID int) string {
+	if spell, ok := static.SummonerSpell(spellID); ok {
+		return fmt.Sprintf("%s (ID %d)", spell.Name, spellID)
+	}
+	if name, ok := knownSpellNames[spellID]; ok {
+		return fmt.Sprintf("%s (ID %d)", name, spellID)
+	}
+	return fmt.Sprintf("Summoner Spell (ID %d)", spellID)
+}
@@ -0,0 +1,31 @@
+package riot
+
+import (
+	"fmt"
+	"strings"
+
+	"lol-ranked-new-meta/types"
+)
+
+// FormatRuleFindings lists the coaching findings for the prompt
+// Threshold findings are facts the model must not contradict; findings judged against the benchmark dataset are
+// listed apart as comparisons with that dataset, since they are only as good as its percentiles
+func FormatRuleFindings(findings []types.CoachingFinding, lang Language) string {
+	var facts, benchmarked string
+	for _, f := range findings {
+		line := fmt.Sprintf("- [%s] %s: %s (%s)\n", f.Severity, f.Title, f.Description, strings.Join(f.Data, "; "))
+		if f.Benchmarked {
+			benchmarked += line
+		} else {
+			facts += line
+		}
+	}
+	var section string
+	if facts != "" {
+		section += "(" + lang.label("computed from the match data, do not contradict") + ")\n" + facts
+	}
+	if benchmarked != "" {
+		section += "(" + lang.label("judged against the role and rank benchmark dataset; present them as comparisons with that dataset") + ")\n" + benchmarked
+	}
+	return section
+}
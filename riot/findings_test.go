@@ -0,0 +1,32 @@
+package riot_test
+
+import (
+	"strings"
+	"testing"
+
+	"lol-ranked-new-meta/riot"
+	"lol-ranked-new-meta/types"
+)
+
+func TestFormatRuleFindings(t *testing.T) {
+	findings := []types.CoachingFinding{
+		{Severity: types.SeverityIssue, Title: "Low CS", Description: "150 CS", Data: []string{"CS: 150", "CS/min: 12th percentile for Gold Top"}, Benchmarked: true},
+		{Severity: types.SeverityIssue, Title: "Few Control Wards", Description: "Bought 0 control wards", Data: []string{"Control Wards Purchased: 0"}},
+	}
+	section := riot.FormatRuleFindings(findings, riot.English)
+	facts := strings.Index(section, "do not contradict")
+	benchmarked := strings.Index(section, "comparisons with that dataset")
+	if facts < 0 || benchmarked < facts {
+		t.Fatalf("want the threshold facts first, then the benchmark comparisons:\n%s", section)
+	}
+	if i := strings.Index(section, "Few Control Wards"); i < facts || i > benchmarked {
+		t.Errorf("threshold finding is not under the facts note:\n%s", section)
+	}
+	if i := strings.Index(section, "[issue] Low CS: 150 CS (CS: 150; CS/min: 12th percentile for Gold Top)"); i < benchmarked {
+		t.Errorf("benchmarked finding is not under the comparison note:\n%s", section)
+	}
+
+	if section := riot.FormatRuleFindings(findings[1:], riot.English); strings.Contains(section, "benchmark") {
+		t.Errorf("no benchmarked findings, but the comparison note is present:\n%s", section)
+	}
+}
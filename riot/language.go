@@ -0,0 +1,900 @@
+package riot
+
+import (
+	"fmt"
+	"strings"
+
+	"lol-ranked-new-meta/ordinal"
+	"lol-ranked-new-meta/staticdata"
+)
+
+// Language is an analysis output language
+type Language struct {
+	Code   string // ISO 639-1 code, e.g. "de"
+	Name   string // English name the model is told to write in, e.g. "German"
+	Locale string // Data Dragon locale for champion, item, rune and spell names, e.g. "de_DE"
+}
+
+// English is the default analysis language
+var English = Language{Code: "en", Name: "English", Locale: staticdata.DefaultLocale}
+
+// Languages lists the supported analysis languages
+var Languages = []Language{
+	English,
+	{Code: "de", Name: "German", Locale: "de_DE"},
+	{Code: "pl", Name: "Polish", Locale: "pl_PL"},
+	{Code: "es", Name: "Spanish", Locale: "es_ES"},
+}
+
+// ParseLanguage parses a language code ("de"), locale ("de_DE", "de-DE") or English name ("German"), case-insensitive
+func ParseLanguage(value string) (Language, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return English, false
+	}
+	code := strings.ToLower(strings.SplitN(strings.ReplaceAll(value, "-", "_"), "_", 2)[0])
+	for _, l := range Languages {
+		if l.Code == code || strings.EqualFold(l.Name, value) {
+			return l, true
+		}
+	}
+	return English, false
+}
+
+// summaryLabels translates the labels and format strings of the summary, keyed by language code and English text
+// Section headings keep the English name alongside, because the prompts refer to sections by it
+var summaryLabels = map[string]map[string]string{
+	"de": {
+		"Match ID":                         "Match-ID",
+		"Game Mode":                        "Spielmodus",
+		"Game Duration":                    "Spieldauer",
+		"seconds":                          "Sekunden",
+		"minutes":                          "Minuten",
+		"Game Version":                     "Spielversion",
+		"Queue":                            "Warteschlange",
+		"Teams":                            "Teams",
+		"Duo Standings":                    "Duo-Platzierungen",
+		"Participants":                     "Teilnehmer",
+		"TARGET FOR DEEP DIVE":             "ZIEL DER DETAILANALYSE",
+		"TEAM COMPARISON":                  "TEAMVERGLEICH",
+		"DRAFT":                            "DRAFT",
+		"DERIVED METRICS":                  "ABGELEITETE KENNZAHLEN",
+		"LOBBY RANKS":                      "LOBBY-RÄNGE",
+		"DETAILED STATS FOR TARGET PLAYER": "DETAILSTATISTIKEN DES ZIELSPIELERS",
+		"OPPONENT COMPOSITION":             "GEGNERISCHE ZUSAMMENSTELLUNG",
+		"RULE-BASED FINDINGS":              "REGELBASIERTE BEFUNDE",
+		"ITEM BUILD TIMELINE":              "ITEM-BUILD-ZEITLEISTE",
+		"SUMMONER SPELL USAGE":             "NUTZUNG DER BESCHWÖRERZAUBER",
+		"COMMUNICATION":                    "KOMMUNIKATION",
+		"TIMELINE HIGHLIGHTS":              "HÖHEPUNKTE DER ZEITLEISTE",
+		"DATA LIMITATIONS":                 "DATENEINSCHRÄNKUNGEN",
+
+		"computed from the match data, do not contradict":                                                   "aus den Matchdaten berechnet, nicht widersprechen",
+		"judged against the role and rank benchmark dataset; present them as comparisons with that dataset": "am Datensatz der Rollen- und Rang-Richtwerte gemessen; als Vergleich mit diesem Datensatz darstellen",
+
+		// Teams, results and the participant list
+		"Blue":     "Blau",
+		"Red":      "Rot",
+		"Won":      "Gewonnen",
+		"Lost":     "Verloren",
+		"Victory":  "Sieg",
+		"Defeat":   "Niederlage",
+		"Yes":      "Ja",
+		"No":       "Nein",
+		"none":     "keine",
+		"Duo %d":   "Duo %d",
+		"%s place": "%s Platz",
+		"- Team %s (%s): %d turrets destroyed, %d dragons, %d barons":                                           "- Team %s (%s): %d Türme zerstört, %d Drachen, %d Barone",
+		"- Team %s (%s): %d turrets destroyed, %d champion kills":                                               "- Team %s (%s): %d Türme zerstört, %d Champion-Kills",
+		"- %s (%s, %s, %s)%s: K/D/A: %d/%d/%d, CS: %d, Gold: %d, Damage: %d":                                    "- %s (%s, %s, %s)%s: K/D/A: %d/%d/%d, CS: %d, Gold: %d, Schaden: %d",
+		"- Omitted to fit the token budget: %s. Do not guess their contents.":                                   "- Wegen des Token-Budgets ausgelassen: %s. Rate ihren Inhalt nicht.",
+		"NOTE: No participant matched the provided champion/summoner filter. Deep dive details may be limited.": "HINWEIS: Kein Teilnehmer passt zum angegebenen Champion-/Beschwörerfilter. Die Detailanalyse kann eingeschränkt sein.",
+
+		// DATA LIMITATIONS
+		"- No event timeline or objective timestamps are available in this summary.":                    "- Diese Zusammenfassung enthält keine Ereigniszeitleiste und keine Zeitstempel für Objectives.",
+		"- Exact item purchase times are not included.":                                                 "- Genaue Kaufzeitpunkte der Items sind nicht enthalten.",
+		"- Timestamps above come from the Riot match timeline (mm:ss game time).":                       "- Die Zeitstempel oben stammen aus der Riot-Match-Zeitleiste (Spielzeit mm:ss).",
+		"- Derived metrics are computed exactly from the match data; cite them rather than estimating.": "- Abgeleitete Kennzahlen sind exakt aus den Matchdaten berechnet; zitiere sie, statt zu schätzen.",
+		"- Item and summoner spell names are not included (IDs only).":                                  "- Namen von Items und Beschwörerzaubern sind nicht enthalten (nur IDs).",
+		"- Item, rune and summoner spell names come from Data Dragon patch %s.":                         "- Namen von Items, Runen und Beschwörerzaubern stammen aus Data Dragon, Patch %s.",
+		"- The match was played on patch %s, but item, rune and summoner spell names and descriptions come from Data Dragon patch %s; items and runes changed since then may be described as they are now.": "- Das Match wurde auf Patch %s gespielt, aber Namen und Beschreibungen von Items, Runen und Beschwörerzaubern stammen aus Data Dragon, Patch %s; seitdem geänderte Items und Runen werden womöglich so beschrieben, wie sie jetzt sind.",
+		"- Draft pick order is not available, only ban order; role-targeted bans are inferred from champion class.":                                                                                         "- Die Pick-Reihenfolge im Draft ist nicht verfügbar, nur die Bann-Reihenfolge; auf Rollen zielende Banns werden aus der Champion-Klasse abgeleitet.",
+		"- Lobby ranks are current standings (fetched now), not the ranks at the time of the match.":                                                                                                        "- Die Lobby-Ränge sind aktuelle Platzierungen (jetzt abgerufen), nicht die Ränge zum Zeitpunkt des Matches.",
+		"- Champion mastery is the player's current total, including any games played after this match.":                                                                                                    "- Die Champion-Meisterschaft ist der aktuelle Gesamtwert des Spielers, einschließlich später gespielter Matches.",
+		"- This is an ARAM game: there are no lanes, lane opponents, jungle or vision objectives.":                                                                                                          "- Dies ist ein ARAM-Spiel: Es gibt keine Lanes, Lane-Gegner, keinen Dschungel und keine Sicht-Objectives.",
+		"- This is an Arena game: duos are ranked by placement; there are no lanes, minions or objectives. Augment names are not available.":                                                                "- Dies ist ein Arena-Spiel: Duos werden nach Platzierung gewertet; es gibt keine Lanes, Vasallen oder Objectives. Namen der Augments sind nicht verfügbar.",
+		"- This is a featured mode (%s); Summoner's Rift benchmarks may not apply.":                                                                                                                         "- Dies ist ein Sondermodus (%s); Richtwerte aus der Kluft der Beschwörer gelten womöglich nicht.",
+		"- This is a %s summary; some sections are left out at this verbosity.":                                                                                                                             "- Dies ist eine %s-Zusammenfassung; bei dieser Detailstufe fehlen einige Abschnitte.",
+		"- Do not infer exact timings unless explicitly provided above.":                                                                                                                                    "- Leite keine genauen Zeitpunkte ab, wenn sie oben nicht ausdrücklich angegeben sind.",
+
+		// Deep dive
+		"Summoner: %s (%s#%s)":              "Beschwörer: %s (%s#%s)",
+		"Champion: %s (Level %d)":           "Champion: %s (Stufe %d)",
+		"Champion Mastery: %s":              "Champion-Meisterschaft: %s",
+		"Arena Duo: %d, Placement: %s":      "Arena-Duo: %d, Platzierung: %s",
+		"Augments (IDs, in pick order): %s": "Augments (IDs, in Wahlreihenfolge): %s",
+		"Team Position: %s (resolved from %s, %s confidence; Lane: %s, Role: %s)": "Teamposition: %s (ermittelt aus %s, Konfidenz %s; Lane: %s, Rolle: %s)",
+		"Team Position: unknown":                       "Teamposition: unbekannt",
+		"Team Position: none (this mode has no lanes)": "Teamposition: keine (dieser Modus hat keine Lanes)",
+		"Result: %s":                                        "Ergebnis: %s",
+		"Performance Metrics":                               "Leistungskennzahlen",
+		"- K/D/A: %d/%d/%d (KDA Ratio: %.2f)":               "- K/D/A: %d/%d/%d (KDA-Verhältnis: %.2f)",
+		"- CS: %d (%d minions, %d monsters; %.1f CS/min)":   "- CS: %d (%d Vasallen, %d Monster; %.1f CS/Min.)",
+		"- Gold Earned: %d (Gold/min: %.0f)":                "- Verdientes Gold: %d (Gold/Min.: %.0f)",
+		"- Gold Spent: %d":                                  "- Ausgegebenes Gold: %d",
+		"- Kill Participation: %.0f%%":                      "- Kill-Beteiligung: %.0f%%",
+		"- Damage per Minute: %.0f (%.0f%% of team damage)": "- Schaden pro Minute: %.0f (%.0f%% des Teamschadens)",
+		"Combat Stats":                                      "Kampfwerte",
+		"- Total Damage to Champions: %d":                   "- Gesamtschaden an Champions: %d",
+		"- Physical Damage: %d":                             "- Physischer Schaden: %d",
+		"- Magic Damage: %d":                                "- Magischer Schaden: %d",
+		"- True Damage: %d":                                 "- Absoluter Schaden: %d",
+		"- Damage Taken: %d":                                "- Erlittener Schaden: %d",
+		"- Damage Self Mitigated: %d":                       "- Selbst abgewehrter Schaden: %d",
+		"- Total Heal: %d":                                  "- Gesamtheilung: %d",
+		"- Total Shields on Teammates: %d":                  "- Schilde auf Teammitglieder: %d",
+		"Objective Control":                                 "Objective-Kontrolle",
+		"- Turret Kills: %d":                                "- Zerstörte Türme: %d",
+		"- Inhibitor Kills: %d":                             "- Zerstörte Inhibitoren: %d",
+		"- Dragon Kills: %d":                                "- Getötete Drachen: %d",
+		"- Baron Kills: %d":                                 "- Getötete Barone: %d",
+		"- First Blood: %s":                                 "- Erstes Blut: %s",
+		"- First Tower: %s":                                 "- Erster Turm: %s",
+		"Vision & Map Control":                              "Sicht & Kartenkontrolle",
+		"- Vision Score: %d (%.2f per minute)":              "- Sichtwert: %d (%.2f pro Minute)",
+		"- Vision Score: %d":                                "- Sichtwert: %d",
+		"- Wards Placed: %d":                                "- Platzierte Wards: %d",
+		"- Wards Killed: %d":                                "- Zerstörte Wards: %d",
+		"- Control Wards Purchased: %d":                     "- Gekaufte Kontrollwards: %d",
+		"- Detector Wards Placed: %d":                       "- Platzierte Kontrollwards: %d",
+		"Special Achievements":                              "Besondere Leistungen",
+		"- Largest Killing Spree: %d":                       "- Längste Killing-Spree: %d",
+		"- Killing Sprees: %d":                              "- Killing-Sprees: %d",
+		"- Double Kills: %d":                                "- Doppel-Kills: %d",
+		"- Triple Kills: %d":                                "- Dreifach-Kills: %d",
+		"- Quadra Kills: %d":                                "- Vierfach-Kills: %d",
+		"- Penta Kills: %d":                                 "- Pentakills: %d",
+		"- Unreal Kills: %d":                                "- Unreal-Kills: %d",
+		"- Largest Multi Kill: %d":                          "- Größter Multi-Kill: %d",
+		"Role & Rank Benchmarks (vs %s in queue %d; dataset %s: %d games from %s, patches %s):": "Rollen- & Rang-Richtwerte (gegen %s in Warteschlange %d; Datensatz %s: %d Spiele aus %s, Patches %s):",
+		"Challenge Metrics":             "Challenge-Kennzahlen",
+		"Item Build":                    "Item-Build",
+		"Item":                          "Item",
+		"Trinket":                       "Schmuckstück",
+		"- Total Items Purchased: %d":   "- Insgesamt gekaufte Items: %d",
+		"%s: Used %d times (%.2f/min":   "%s: %d-mal benutzt (%.2f/Min.",
+		", at most %d at base cooldown": ", höchstens %d bei Grundabklingzeit",
+		"- Primary: %s (Keystone: %s), Secondary: %s": "- Primär: %s (Schlüsselstein: %s), Sekundär: %s",
+		"KEYSTONE":                                "SCHLÜSSELSTEIN",
+		"- Stat Shards: %s":                       "- Wertesplitter: %s",
+		"Summoner Spells":                         "Beschwörerzauber",
+		"Runes (values recorded by the game)":     "Runen (vom Spiel erfasste Werte)",
+		"Game Impact":                             "Einfluss aufs Spiel",
+		"- Time Spent Dead: %d seconds":           "- Zeit tot: %d Sekunden",
+		"- Longest Time Spent Living: %d seconds": "- Längste Zeit am Leben: %d Sekunden",
+		"- CC Score (weighted, not seconds): %d":  "- CC-Wert (gewichtet, keine Sekunden): %d",
+		"- Total CC Dealt: %d seconds":            "- Verursachte Massenkontrolle: %d Sekunden",
+
+		// Challenge metrics
+		"- Damage Taken Share: %.0f%% of team damage taken":                    "- Anteil am erlittenen Schaden: %.0f%% des vom Team erlittenen Schadens",
+		"- Takedowns Before 10:00: %d":                                         "- Takedowns vor 10:00: %d",
+		"- Solo Kills: %d, Outnumbered Kills: %d, Picks With an Ally: %d":      "- Solo-Kills: %d, Kills in Unterzahl: %d, Picks mit einem Verbündeten: %d",
+		"- Skillshots Hit: %d, Skillshots Dodged: %d":                          "- Getroffene Skillshots: %d, ausgewichene Skillshots: %d",
+		"- Enemy Champion Immobilizations: %d":                                 "- Gegnerische Champions immobilisiert: %d",
+		"- Saved an Ally From Death: %d, Survived on Single-Digit HP: %d":      "- Verbündete vor dem Tod gerettet: %d, mit einstelligen LP überlebt: %d",
+		"- Jungle CS Before 10:00: %.0f":                                       "- Dschungel-CS vor 10:00: %.0f",
+		"- Enemy Jungle Monsters Killed: %.0f, Buffs Stolen: %d":               "- Getötete Monster im gegnerischen Dschungel: %.0f, gestohlene Buffs: %d",
+		"- Lane Minions by 10:00: %d":                                          "- Lane-Vasallen bis 10:00: %d",
+		"- Turret Plates Taken: %d":                                            "- Eingenommene Turmplatten: %d",
+		"- Max CS Lead on Lane Opponent: %.0f, Max Level Lead: %d":             "- Größter CS-Vorsprung auf den Lane-Gegner: %.0f, größter Stufenvorsprung: %d",
+		"- Ahead in Gold and XP at 7:00: %s, at 14:00: %s":                     "- Vorn bei Gold und EP bei 7:00: %s, bei 14:00: %s",
+		"- Vision Score Advantage vs Lane Opponent: %+.0f%%":                   "- Sichtwert-Vorsprung gegenüber dem Lane-Gegner: %+.0f%%",
+		"- Objective Takedowns: %d turrets, %d dragons, %d barons, %d heralds": "- Objective-Beteiligungen: %d Türme, %d Drachen, %d Barone, %d Herolde",
+		"- Control Wards Placed: %d, Wards Cleared: %d":                        "- Platzierte Kontrollwards: %d, entfernte Wards: %d",
+
+		// Opponent composition
+		"Your Team (%s):":     "Dein Team (%s):",
+		"Opponent Team (%s):": "Gegnerisches Team (%s):",
+		"  -> LANE OPPONENT: %s vs %s (%s, %s confidence)":                                                 "  -> LANE-GEGNER: %s gegen %s (%s, Konfidenz %s)",
+		"     Result: %d/%d/%d (You) vs %d/%d/%d (Opponent)":                                               "     Ergebnis: %d/%d/%d (du) gegen %d/%d/%d (Gegner)",
+		"     CS: %d (You) vs %d (Opponent)":                                                               "     CS: %d (du) gegen %d (Gegner)",
+		"     Gold: %d (You) vs %d (Opponent)":                                                             "     Gold: %d (du) gegen %d (Gegner)",
+		"No lane opponents: this mode has no lanes.":                                                       "Keine Lane-Gegner: Dieser Modus hat keine Lanes.",
+		"NOTE: Roles were inferred (target: %s from %s, %s confidence); treat the lane matchup with care.": "HINWEIS: Die Rollen wurden abgeleitet (Ziel: %s aus %s, Konfidenz %s); bewerte das Lane-Matchup mit Vorsicht.",
+		"Your Duo (Duo %d, %s):":                                                                           "Dein Duo (Duo %d, %s):",
+		"- %s (%s): %d/%d/%d, %d damage":                                                                   "- %s (%s): %d/%d/%d, %d Schaden",
+		"Opposing Duos":                                                                                    "Gegnerische Duos",
+		"- %s (%s), Duo %d, %s: %d/%d/%d":                                                                  "- %s (%s), Duo %d, %s: %d/%d/%d",
+
+		// Timeline highlights
+		"%s - %s (%s) killed %s":                         "%s - %s (%s) tötete %s",
+		"%s (killed %s)":                                 "%s (tötete %s)",
+		"%s (killed by %s)":                              "%s (getötet von %s)",
+		"minions/turret":                                 "Vasallen/Turm",
+		"%s - %s team took %s":                           "%s - Team %s holte %s",
+		"%s - %s team destroyed %s":                      "%s - Team %s zerstörte %s",
+		"First Blood: %s":                                "Erstes Blut: %s",
+		"Objective Timeline":                             "Objective-Zeitleiste",
+		"%s Kill Times: %s":                              "Kill-Zeitpunkte von %s: %s",
+		"%s Death Times: %s":                             "Todeszeitpunkte von %s: %s",
+		"At %d:00 - Gold: %d, CS: %d, XP: %d, Level: %d": "Bei %d:00 - Gold: %d, CS: %d, EP: %d, Stufe: %d",
+		" | vs %s - Gold: %d, CS: %d, XP: %d (Gold diff: %+d, CS diff: %+d)": " | gegen %s - Gold: %d, CS: %d, EP: %d (Gold-Differenz: %+d, CS-Differenz: %+d)",
+
+		// Compact JSON
+		"MATCH DATA (compact JSON):": "MATCHDATEN (kompaktes JSON):",
+
+		// Team comparison
+		"(%s vs %s, differential from %s's side)": "(%s gegen %s, Differenz aus Sicht von %s)",
+		"vs":                  "gegen",
+		"Kills":               "Kills",
+		"Gold Earned":         "Verdientes Gold",
+		"Damage to Champions": "Schaden an Champions",
+		"Damage to Buildings": "Schaden an Gebäuden",
+		"Vision Score":        "Sichtwert",
+		"CC Time (seconds)":   "Massenkontrolle (Sekunden)",
+		"Turrets":             "Türme",
+		"Inhibitors":          "Inhibitoren",
+		"Dragons":             "Drachen",
+		"Rift Heralds":        "Herolde",
+		"Barons":              "Barone",
+		"- Firsts: Blood %s, Tower %s, Inhibitor %s, Dragon %s, Herald %s, Baron %s": "- Erste: Blut %s, Turm %s, Inhibitor %s, Drache %s, Herold %s, Baron %s",
+
+		// Draft
+		"Team %s":           "Team %s",
+		"YOUR TEAM":         "DEIN TEAM",
+		"ENEMY TEAM":        "GEGNERISCHES TEAM",
+		"%s (turn %d)":      "%s (Zug %d)",
+		"- Bans: %s":        "- Banns: %s",
+		"- Composition: %s": "- Zusammenstellung: %s",
+		"%s (by %s)":        "%s (von %s)",
+		"Enemy bans aimed at the target's role (%s): not determinable - champion classes only single out ADC and support champions": "Gegnerische Banns gegen die Rolle des Ziels (%s): nicht bestimmbar - Champion-Klassen grenzen nur ADC- und Support-Champions ab",
+		"Enemy bans aimed at the target's role (%s): not determinable without static data":                                          "Gegnerische Banns gegen die Rolle des Ziels (%s): ohne statische Daten nicht bestimmbar",
+		"Enemy bans of champions played in the target's role (%s, by champion class): %s":                                           "Gegnerische Banns von Champions aus der Rolle des Ziels (%s, nach Champion-Klasse): %s",
+
+		// Derived metrics
+		"%d/%d/%d, %d gold, CS/min %.1f, Vision/min %.2f, DPM %.0f, Gold/min %.0f, Damage/Gold %.2f, Deaths/10min %.1f":                  "%d/%d/%d, %d Gold, CS/Min. %.1f, Sicht/Min. %.2f, DPM %.0f, Gold/Min. %.0f, Schaden/Gold %.2f, Tode/10 Min. %.1f",
+		"KP %.0f%%, Damage Share %.0f%%, Gold Share %.0f%%, CS/min %.1f, Vision/min %.2f, DPM %.0f, Damage/Gold %.2f, Deaths/10min %.1f": "KB %.0f%%, Schadensanteil %.0f%%, Goldanteil %.0f%%, CS/Min. %.1f, Sicht/Min. %.2f, DPM %.0f, Schaden/Gold %.2f, Tode/10 Min. %.1f",
+
+		// Lobby ranks
+		"Average Solo/Duo Rank: %s (%d of %d players ranked)":      "Durchschnittlicher Solo/Duo-Rang: %s (%d von %d Spielern platziert)",
+		"Average Solo/Duo Rank: unknown (no ranked players found)": "Durchschnittlicher Solo/Duo-Rang: unbekannt (keine platzierten Spieler gefunden)",
+		"Team %s: %s":                          "Team %s: %s",
+		"Players":                              "Spieler",
+		"- %s (%s, %s): rank unavailable":      "- %s (%s, %s): Rang nicht verfügbar",
+		"- %s (%s, %s): Solo/Duo %s | Flex %s": "- %s (%s, %s): Solo/Duo %s | Flex %s",
+		"Unranked":                             "Unplatziert",
+		"%s %d LP (%dW/%dL, %.0f%% WR)":        "%s %d LP (%dS/%dN, %.0f%% Siegquote)",
+
+		// Item build timeline
+		"Total Items Purchased: %d":             "Insgesamt gekaufte Items: %d",
+		"Final Build":                           "Finaler Build",
+		"Item 1":                                "Item 1",
+		"Item 2":                                "Item 2",
+		"Item 3":                                "Item 3",
+		"Item 4":                                "Item 4",
+		"Item 5":                                "Item 5",
+		"Item 6":                                "Item 6",
+		" - first bought at %s":                 " - zuerst gekauft bei %s",
+		"First Completed Item: %s at %s":        "Erstes fertiges Item: %s bei %s",
+		"Second Completed Item: %s at %s":       "Zweites fertiges Item: %s bei %s",
+		"Purchase Order (from match timeline):": "Kaufreihenfolge (aus der Match-Zeitleiste):",
+		"Gold Income: %.0f gold/minute":         "Goldeinkommen: %.0f Gold/Minute",
+		"Note: Exact item purchase times require timeline data from Riot API match timeline endpoint": "Hinweis: Genaue Kaufzeitpunkte der Items erfordern Zeitleistendaten vom Match-Timeline-Endpunkt der Riot-API",
+
+		// Summoner spell usage
+		"Lane Opponent %s (%s):":     "Lane-Gegner %s (%s):",
+		"- %s: %d casts vs %d by %s": "- %s: %d Einsätze gegen %d von %s",
+		"- %s: %d casts":             "- %s: %d Einsätze",
+		"- %s Kill Conversion: %d of %d casts hit a champion who then died (%s)": "- %s-Kill-Verwertung: %d von %d Einsätzen trafen einen Champion, der dann starb (%s)",
+		"- %s: %d casts; kill conversion needs timeline damage data":             "- %s: %d Einsätze; die Kill-Verwertung braucht Schadensdaten der Zeitleiste",
+		"- %s: %s used it %d times; %s did not take it":                          "- %s: %s nutzte ihn %d-mal; %s hatte ihn nicht dabei",
+		"Spell Economy": "Zauberökonomie",
+
+		// Communication
+		"TARGET": "ZIEL",
+		"- %s (%s, %s)%s: %d pings (%.2f/min vs teammates %.2f/min), style %s; informative %d, call to action %d, caution %d; mix: %s": "- %s (%s, %s)%s: %d Pings (%.2f/Min. gegen Teammitglieder %.2f/Min.), Stil %s; informativ %d, Aufforderung %d, Warnung %d; Mix: %s",
+		"Enemy Missing":  "Gegner fehlt",
+		"Enemy Vision":   "Gegnerische Sicht",
+		"Need Vision":    "Sicht benötigt",
+		"Vision Cleared": "Sicht entfernt",
+		"Danger":         "Gefahr",
+		"All In":         "Alles rein",
+		"Push":           "Pushen",
+		"On My Way":      "Bin unterwegs",
+		"Assist Me":      "Hilf mir",
+		"Command":        "Befehl",
+		"Get Back":       "Zurück",
+		"Retreat":        "Rückzug",
+		"Hold":           "Halten",
+		"silent":         "still",
+		"negative":       "negativ",
+		"informative":    "informativ",
+		"directive":      "anweisend",
+		"cautious":       "vorsichtig",
+		"balanced":       "ausgewogen",
+
+		// Arena standings
+		"- %s: Duo %d - %s - %d kills, %d deaths": "- %s: Duo %d - %s - %d Kills, %d Tode",
+	},
+	"pl": {
+		"Match ID":                         "ID meczu",
+		"Game Mode":                        "Tryb gry",
+		"Game Duration":                    "Czas gry",
+		"seconds":                          "sekund",
+		"minutes":                          "minut",
+		"Game Version":                     "Wersja gry",
+		"Queue":                            "Kolejka",
+		"Teams":                            "Drużyny",
+		"Duo Standings":                    "Klasyfikacja duetów",
+		"Participants":                     "Uczestnicy",
+		"TARGET FOR DEEP DIVE":             "CEL SZCZEGÓŁOWEJ ANALIZY",
+		"TEAM COMPARISON":                  "PORÓWNANIE DRUŻYN",
+		"DRAFT":                            "DRAFT",
+		"DERIVED METRICS":                  "WSKAŹNIKI POCHODNE",
+		"LOBBY RANKS":                      "RANGI W LOBBY",
+		"DETAILED STATS FOR TARGET PLAYER": "SZCZEGÓŁOWE STATYSTYKI GRACZA",
+		"OPPONENT COMPOSITION":             "SKŁAD PRZECIWNIKA",
+		"RULE-BASED FINDINGS":              "USTALENIA OPARTE NA REGUŁACH",
+		"ITEM BUILD TIMELINE":              "OŚ CZASU BUILDU PRZEDMIOTÓW",
+		"SUMMONER SPELL USAGE":             "UŻYCIE CZARÓW PRZYWOŁYWACZA",
+		"COMMUNICATION":                    "KOMUNIKACJA",
+		"TIMELINE HIGHLIGHTS":              "NAJWAŻNIEJSZE MOMENTY",
+		"DATA LIMITATIONS":                 "OGRANICZENIA DANYCH",
+
+		"computed from the match data, do not contradict":                                                   "obliczone z danych meczu, nie zaprzeczaj im",
+		"judged against the role and rank benchmark dataset; present them as comparisons with that dataset": "ocenione względem zbioru wartości odniesienia dla roli i rangi; przedstawiaj je jako porównanie z tym zbiorem",
+
+		// Teams, results and the participant list
+		"Blue":     "Niebieska",
+		"Red":      "Czerwona",
+		"Won":      "Wygrana",
+		"Lost":     "Przegrana",
+		"Victory":  "Zwycięstwo",
+		"Defeat":   "Porażka",
+		"Yes":      "Tak",
+		"No":       "Nie",
+		"none":     "brak",
+		"Duo %d":   "Duet %d",
+		"%s place": "%s miejsce",
+		"- Team %s (%s): %d turrets destroyed, %d dragons, %d barons":                                           "- Drużyna %s (%s): %d zniszczonych wież, %d smoków, %d baronów",
+		"- Team %s (%s): %d turrets destroyed, %d champion kills":                                               "- Drużyna %s (%s): %d zniszczonych wież, %d zabójstw bohaterów",
+		"- %s (%s, %s, %s)%s: K/D/A: %d/%d/%d, CS: %d, Gold: %d, Damage: %d":                                    "- %s (%s, %s, %s)%s: K/D/A: %d/%d/%d, CS: %d, złoto: %d, obrażenia: %d",
+		"- Omitted to fit the token budget: %s. Do not guess their contents.":                                   "- Pominięto ze względu na limit tokenów: %s. Nie zgaduj ich zawartości.",
+		"NOTE: No participant matched the provided champion/summoner filter. Deep dive details may be limited.": "UWAGA: Żaden uczestnik nie pasuje do podanego filtra bohatera/przywoływacza. Szczegółowa analiza może być ograniczona.",
+
+		// DATA LIMITATIONS
+		"- No event timeline or objective timestamps are available in this summary.":                    "- To podsumowanie nie zawiera osi czasu zdarzeń ani znaczników czasu celów.",
+		"- Exact item purchase times are not included.":                                                 "- Dokładne czasy zakupu przedmiotów nie są uwzględnione.",
+		"- Timestamps above come from the Riot match timeline (mm:ss game time).":                       "- Powyższe znaczniki czasu pochodzą z osi czasu meczu Riot (czas gry mm:ss).",
+		"- Derived metrics are computed exactly from the match data; cite them rather than estimating.": "- Wskaźniki pochodne są obliczone dokładnie z danych meczu; cytuj je zamiast szacować.",
+		"- Item and summoner spell names are not included (IDs only).":                                  "- Nazwy przedmiotów i czarów przywoływacza nie są uwzględnione (tylko ID).",
+		"- Item, rune and summoner spell names come from Data Dragon patch %s.":                         "- Nazwy przedmiotów, run i czarów przywoływacza pochodzą z Data Dragon, patch %s.",
+		"- The match was played on patch %s, but item, rune and summoner spell names and descriptions come from Data Dragon patch %s; items and runes changed since then may be described as they are now.": "- Mecz rozegrano na patchu %s, ale nazwy i opisy przedmiotów, run i czarów przywoływacza pochodzą z Data Dragon, patch %s; zmienione od tego czasu przedmioty i runy mogą być opisane w obecnej wersji.",
+		"- Draft pick order is not available, only ban order; role-targeted bans are inferred from champion class.":                                                                                         "- Kolejność wyborów w drafcie nie jest dostępna, tylko kolejność banów; bany wymierzone w rolę są wnioskowane z klasy bohatera.",
+		"- Lobby ranks are current standings (fetched now), not the ranks at the time of the match.":                                                                                                        "- Rangi w lobby to obecne pozycje (pobrane teraz), a nie rangi z czasu meczu.",
+		"- Champion mastery is the player's current total, including any games played after this match.":                                                                                                    "- Maestria bohatera to obecna suma gracza, łącznie z meczami rozegranymi po tym meczu.",
+		"- This is an ARAM game: there are no lanes, lane opponents, jungle or vision objectives.":                                                                                                          "- To gra ARAM: nie ma alej, przeciwników na alei, dżungli ani celów związanych z wizją.",
+		"- This is an Arena game: duos are ranked by placement; there are no lanes, minions or objectives. Augment names are not available.":                                                                "- To gra na Arenie: duety są klasyfikowane według miejsca; nie ma alej, stworów ani celów. Nazwy ulepszeń nie są dostępne.",
+		"- This is a featured mode (%s); Summoner's Rift benchmarks may not apply.":                                                                                                                         "- To tryb specjalny (%s); wartości odniesienia z Summoner's Rift mogą nie mieć zastosowania.",
+		"- This is a %s summary; some sections are left out at this verbosity.":                                                                                                                             "- To podsumowanie w trybie %s; przy tym poziomie szczegółowości niektóre sekcje są pominięte.",
+		"- Do not infer exact timings unless explicitly provided above.":                                                                                                                                    "- Nie wnioskuj dokładnych czasów, jeśli nie podano ich wyraźnie powyżej.",
+
+		// Deep dive
+		"Summoner: %s (%s#%s)":              "Przywoływacz: %s (%s#%s)",
+		"Champion: %s (Level %d)":           "Bohater: %s (poziom %d)",
+		"Champion Mastery: %s":              "Maestria bohatera: %s",
+		"Arena Duo: %d, Placement: %s":      "Duet na Arenie: %d, miejsce: %s",
+		"Augments (IDs, in pick order): %s": "Ulepszenia (ID, w kolejności wyboru): %s",
+		"Team Position: %s (resolved from %s, %s confidence; Lane: %s, Role: %s)": "Pozycja w drużynie: %s (ustalona z %s, pewność %s; aleja: %s, rola: %s)",
+		"Team Position: unknown":                       "Pozycja w drużynie: nieznana",
+		"Team Position: none (this mode has no lanes)": "Pozycja w drużynie: brak (ten tryb nie ma alej)",
+		"Result: %s":                                        "Wynik: %s",
+		"Performance Metrics":                               "Wskaźniki wyników",
+		"- K/D/A: %d/%d/%d (KDA Ratio: %.2f)":               "- K/D/A: %d/%d/%d (współczynnik KDA: %.2f)",
+		"- CS: %d (%d minions, %d monsters; %.1f CS/min)":   "- CS: %d (%d stworów, %d potworów; %.1f CS/min)",
+		"- Gold Earned: %d (Gold/min: %.0f)":                "- Zdobyte złoto: %d (złoto/min: %.0f)",
+		"- Gold Spent: %d":                                  "- Wydane złoto: %d",
+		"- Kill Participation: %.0f%%":                      "- Udział w zabójstwach: %.0f%%",
+		"- Damage per Minute: %.0f (%.0f%% of team damage)": "- Obrażenia na minutę: %.0f (%.0f%% obrażeń drużyny)",
+		"Combat Stats":                                      "Statystyki walki",
+		"- Total Damage to Champions: %d":                   "- Łączne obrażenia zadane bohaterom: %d",
+		"- Physical Damage: %d":                             "- Obrażenia fizyczne: %d",
+		"- Magic Damage: %d":                                "- Obrażenia magiczne: %d",
+		"- True Damage: %d":                                 "- Obrażenia nieuchronne: %d",
+		"- Damage Taken: %d":                                "- Otrzymane obrażenia: %d",
+		"- Damage Self Mitigated: %d":                       "- Obrażenia zredukowane: %d",
+		"- Total Heal: %d":                                  "- Łączne leczenie: %d",
+		"- Total Shields on Teammates: %d":                  "- Łączne tarcze na sojuszników: %d",
+		"Objective Control":                                 "Kontrola celów",
+		"- Turret Kills: %d":                                "- Zniszczone wieże: %d",
+		"- Inhibitor Kills: %d":                             "- Zniszczone inhibitory: %d",
+		"- Dragon Kills: %d":                                "- Zabite smoki: %d",
+		"- Baron Kills: %d":                                 "- Zabici baronowie: %d",
+		"- First Blood: %s":                                 "- Pierwsza krew: %s",
+		"- First Tower: %s":                                 "- Pierwsza wieża: %s",
+		"Vision & Map Control":                              "Wizja i kontrola mapy",
+		"- Vision Score: %d (%.2f per minute)":              "- Wynik wizji: %d (%.2f na minutę)",
+		"- Vision Score: %d":                                "- Wynik wizji: %d",
+		"- Wards Placed: %d":                                "- Postawione totemy: %d",
+		"- Wards Killed: %d":                                "- Zniszczone totemy: %d",
+		"- Control Wards Purchased: %d":                     "- Kupione totemy kontrolne: %d",
+		"- Detector Wards Placed: %d":                       "- Postawione totemy kontrolne: %d",
+		"Special Achievements":                              "Specjalne osiągnięcia",
+		"- Largest Killing Spree: %d":                       "- Najdłuższa seria zabójstw: %d",
+		"- Killing Sprees: %d":                              "- Serie zabójstw: %d",
+		"- Double Kills: %d":                                "- Podwójne zabójstwa: %d",
+		"- Triple Kills: %d":                                "- Potrójne zabójstwa: %d",
+		"- Quadra Kills: %d":                                "- Poczwórne zabójstwa: %d",
+		"- Penta Kills: %d":                                 "- Pentakille: %d",
+		"- Unreal Kills: %d":                                "- Zabójstwa unreal: %d",
+		"- Largest Multi Kill: %d":                          "- Największe wielokrotne zabójstwo: %d",
+		"Role & Rank Benchmarks (vs %s in queue %d; dataset %s: %d games from %s, patches %s):": "Wartości odniesienia dla roli i rangi (względem %s w kolejce %d; zbiór danych %s: %d gier z %s, patche %s):",
+		"Challenge Metrics":             "Wskaźniki wyzwań",
+		"Item Build":                    "Build przedmiotów",
+		"Item":                          "Przedmiot",
+		"Trinket":                       "Talizman",
+		"- Total Items Purchased: %d":   "- Łącznie kupione przedmioty: %d",
+		"%s: Used %d times (%.2f/min":   "%s: użyty %d razy (%.2f/min",
+		", at most %d at base cooldown": ", najwyżej %d przy bazowym czasie odnowienia",
+		"- Primary: %s (Keystone: %s), Secondary: %s": "- Główna: %s (kamień węgielny: %s), dodatkowa: %s",
+		"KEYSTONE":                                "KAMIEŃ WĘGIELNY",
+		"- Stat Shards: %s":                       "- Odłamki statystyk: %s",
+		"Summoner Spells":                         "Czary przywoływacza",
+		"Runes (values recorded by the game)":     "Runy (wartości zapisane przez grę)",
+		"Game Impact":                             "Wpływ na grę",
+		"- Time Spent Dead: %d seconds":           "- Czas martwy: %d sekund",
+		"- Longest Time Spent Living: %d seconds": "- Najdłuższy czas przy życiu: %d sekund",
+		"- CC Score (weighted, not seconds): %d":  "- Wynik kontroli tłumu (ważony, nie sekundy): %d",
+		"- Total CC Dealt: %d seconds":            "- Zadana kontrola tłumu: %d sekund",
+
+		// Challenge metrics
+		"- Damage Taken Share: %.0f%% of team damage taken":                    "- Udział w otrzymanych obrażeniach: %.0f%% obrażeń otrzymanych przez drużynę",
+		"- Takedowns Before 10:00: %d":                                         "- Udziały w zabójstwach przed 10:00: %d",
+		"- Solo Kills: %d, Outnumbered Kills: %d, Picks With an Ally: %d":      "- Zabójstwa solo: %d, zabójstwa w przewadze liczebnej wroga: %d, pickowanie z sojusznikiem: %d",
+		"- Skillshots Hit: %d, Skillshots Dodged: %d":                          "- Trafione umiejętności kierunkowe: %d, uniknięte umiejętności kierunkowe: %d",
+		"- Enemy Champion Immobilizations: %d":                                 "- Unieruchomienia wrogich bohaterów: %d",
+		"- Saved an Ally From Death: %d, Survived on Single-Digit HP: %d":      "- Uratowanie sojusznika przed śmiercią: %d, przeżycie z jednocyfrowym HP: %d",
+		"- Jungle CS Before 10:00: %.0f":                                       "- CS w dżungli przed 10:00: %.0f",
+		"- Enemy Jungle Monsters Killed: %.0f, Buffs Stolen: %d":               "- Zabite potwory w dżungli wroga: %.0f, skradzione wzmocnienia: %d",
+		"- Lane Minions by 10:00: %d":                                          "- Stwory z alei do 10:00: %d",
+		"- Turret Plates Taken: %d":                                            "- Zdobyte płyty wież: %d",
+		"- Max CS Lead on Lane Opponent: %.0f, Max Level Lead: %d":             "- Największa przewaga CS nad przeciwnikiem z alei: %.0f, największa przewaga poziomów: %d",
+		"- Ahead in Gold and XP at 7:00: %s, at 14:00: %s":                     "- Przewaga w złocie i PD w 7:00: %s, w 14:00: %s",
+		"- Vision Score Advantage vs Lane Opponent: %+.0f%%":                   "- Przewaga wyniku wizji nad przeciwnikiem z alei: %+.0f%%",
+		"- Objective Takedowns: %d turrets, %d dragons, %d barons, %d heralds": "- Udział w celach: %d wież, %d smoków, %d baronów, %d heroldów",
+		"- Control Wards Placed: %d, Wards Cleared: %d":                        "- Postawione totemy kontrolne: %d, usunięte totemy: %d",
+
+		// Opponent composition
+		"Your Team (%s):":     "Twoja drużyna (%s):",
+		"Opponent Team (%s):": "Drużyna przeciwna (%s):",
+		"  -> LANE OPPONENT: %s vs %s (%s, %s confidence)":                                                 "  -> PRZECIWNIK NA ALEI: %s kontra %s (%s, pewność %s)",
+		"     Result: %d/%d/%d (You) vs %d/%d/%d (Opponent)":                                               "     Wynik: %d/%d/%d (ty) kontra %d/%d/%d (przeciwnik)",
+		"     CS: %d (You) vs %d (Opponent)":                                                               "     CS: %d (ty) kontra %d (przeciwnik)",
+		"     Gold: %d (You) vs %d (Opponent)":                                                             "     Złoto: %d (ty) kontra %d (przeciwnik)",
+		"No lane opponents: this mode has no lanes.":                                                       "Brak przeciwników na alei: ten tryb nie ma alej.",
+		"NOTE: Roles were inferred (target: %s from %s, %s confidence); treat the lane matchup with care.": "UWAGA: Role zostały wywnioskowane (cel: %s z %s, pewność %s); traktuj pojedynek na alei ostrożnie.",
+		"Your Duo (Duo %d, %s):":                                                                           "Twój duet (duet %d, %s):",
+		"- %s (%s): %d/%d/%d, %d damage":                                                                   "- %s (%s): %d/%d/%d, %d obrażeń",
+		"Opposing Duos":                                                                                    "Przeciwne duety",
+		"- %s (%s), Duo %d, %s: %d/%d/%d":                                                                  "- %s (%s), duet %d, %s: %d/%d/%d",
+
+		// Timeline highlights
+		"%s - %s (%s) killed %s":                         "%s - %s (%s) zabił %s",
+		"%s (killed %s)":                                 "%s (zabił %s)",
+		"%s (killed by %s)":                              "%s (zabity przez %s)",
+		"minions/turret":                                 "stwory/wieża",
+		"%s - %s team took %s":                           "%s - drużyna %s zdobyła %s",
+		"%s - %s team destroyed %s":                      "%s - drużyna %s zniszczyła %s",
+		"First Blood: %s":                                "Pierwsza krew: %s",
+		"Objective Timeline":                             "Oś czasu celów",
+		"%s Kill Times: %s":                              "Czasy zabójstw (%s): %s",
+		"%s Death Times: %s":                             "Czasy śmierci (%s): %s",
+		"At %d:00 - Gold: %d, CS: %d, XP: %d, Level: %d": "W %d:00 - złoto: %d, CS: %d, PD: %d, poziom: %d",
+		" | vs %s - Gold: %d, CS: %d, XP: %d (Gold diff: %+d, CS diff: %+d)": " | kontra %s - złoto: %d, CS: %d, PD: %d (różnica złota: %+d, różnica CS: %+d)",
+
+		// Compact JSON
+		"MATCH DATA (compact JSON):": "DANE MECZU (kompaktowy JSON):",
+
+		// Team comparison
+		"(%s vs %s, differential from %s's side)": "(%s kontra %s, różnica z perspektywy drużyny %s)",
+		"vs":                  "kontra",
+		"Kills":               "Zabójstwa",
+		"Gold Earned":         "Zdobyte złoto",
+		"Damage to Champions": "Obrażenia zadane bohaterom",
+		"Damage to Buildings": "Obrażenia zadane budowlom",
+		"Vision Score":        "Wynik wizji",
+		"CC Time (seconds)":   "Czas kontroli tłumu (sekundy)",
+		"Turrets":             "Wieże",
+		"Inhibitors":          "Inhibitory",
+		"Dragons":             "Smoki",
+		"Rift Heralds":        "Heroldowie",
+		"Barons":              "Barony",
+		"- Firsts: Blood %s, Tower %s, Inhibitor %s, Dragon %s, Herald %s, Baron %s": "- Pierwsze: krew %s, wieża %s, inhibitor %s, smok %s, herold %s, baron %s",
+
+		// Draft
+		"Team %s":           "Drużyna %s",
+		"YOUR TEAM":         "TWOJA DRUŻYNA",
+		"ENEMY TEAM":        "DRUŻYNA PRZECIWNA",
+		"%s (turn %d)":      "%s (tura %d)",
+		"- Bans: %s":        "- Bany: %s",
+		"- Composition: %s": "- Skład: %s",
+		"%s (by %s)":        "%s (przez drużynę %s)",
+		"Enemy bans aimed at the target's role (%s): not determinable - champion classes only single out ADC and support champions": "Bany przeciwnika wymierzone w rolę celu (%s): nie do ustalenia - klasy bohaterów wyróżniają tylko strzelców i wsparcie",
+		"Enemy bans aimed at the target's role (%s): not determinable without static data":                                          "Bany przeciwnika wymierzone w rolę celu (%s): nie do ustalenia bez danych statycznych",
+		"Enemy bans of champions played in the target's role (%s, by champion class): %s":                                           "Bany przeciwnika na bohaterów grających w roli celu (%s, według klasy bohatera): %s",
+
+		// Derived metrics
+		"%d/%d/%d, %d gold, CS/min %.1f, Vision/min %.2f, DPM %.0f, Gold/min %.0f, Damage/Gold %.2f, Deaths/10min %.1f":                  "%d/%d/%d, %d złota, CS/min %.1f, wizja/min %.2f, DPM %.0f, złoto/min %.0f, obrażenia/złoto %.2f, śmierci/10 min %.1f",
+		"KP %.0f%%, Damage Share %.0f%%, Gold Share %.0f%%, CS/min %.1f, Vision/min %.2f, DPM %.0f, Damage/Gold %.2f, Deaths/10min %.1f": "UZ %.0f%%, udział w obrażeniach %.0f%%, udział w złocie %.0f%%, CS/min %.1f, wizja/min %.2f, DPM %.0f, obrażenia/złoto %.2f, śmierci/10 min %.1f",
+
+		// Lobby ranks
+		"Average Solo/Duo Rank: %s (%d of %d players ranked)":      "Średnia ranga solo/duet: %s (%d z %d graczy z rangą)",
+		"Average Solo/Duo Rank: unknown (no ranked players found)": "Średnia ranga solo/duet: nieznana (nie znaleziono graczy z rangą)",
+		"Team %s: %s":                          "Drużyna %s: %s",
+		"Players":                              "Gracze",
+		"- %s (%s, %s): rank unavailable":      "- %s (%s, %s): ranga niedostępna",
+		"- %s (%s, %s): Solo/Duo %s | Flex %s": "- %s (%s, %s): solo/duet %s | elastyczna %s",
+		"Unranked":                             "Bez rangi",
+		"%s %d LP (%dW/%dL, %.0f%% WR)":        "%s %d PL (%dW/%dP, %.0f%% zwycięstw)",
+
+		// Item build timeline
+		"Total Items Purchased: %d":             "Łącznie kupione przedmioty: %d",
+		"Final Build":                           "Końcowy zestaw",
+		"Item 1":                                "Przedmiot 1",
+		"Item 2":                                "Przedmiot 2",
+		"Item 3":                                "Przedmiot 3",
+		"Item 4":                                "Przedmiot 4",
+		"Item 5":                                "Przedmiot 5",
+		"Item 6":                                "Przedmiot 6",
+		" - first bought at %s":                 " - pierwszy zakup w %s",
+		"First Completed Item: %s at %s":        "Pierwszy ukończony przedmiot: %s w %s",
+		"Second Completed Item: %s at %s":       "Drugi ukończony przedmiot: %s w %s",
+		"Purchase Order (from match timeline):": "Kolejność zakupów (z osi czasu meczu):",
+		"Gold Income: %.0f gold/minute":         "Przychód złota: %.0f złota/minutę",
+		"Note: Exact item purchase times require timeline data from Riot API match timeline endpoint": "Uwaga: dokładne czasy zakupu przedmiotów wymagają danych z endpointu osi czasu meczu w API Riot",
+
+		// Summoner spell usage
+		"Lane Opponent %s (%s):":     "Przeciwnik z alei %s (%s):",
+		"- %s: %d casts vs %d by %s": "- %s: %d użyć kontra %d przez %s",
+		"- %s: %d casts":             "- %s: %d użyć",
+		"- %s Kill Conversion: %d of %d casts hit a champion who then died (%s)": "- Konwersja %s na zabójstwa: %d z %d użyć trafiło bohatera, który potem zginął (%s)",
+		"- %s: %d casts; kill conversion needs timeline damage data":             "- %s: %d użyć; konwersja na zabójstwa wymaga danych o obrażeniach z osi czasu",
+		"- %s: %s used it %d times; %s did not take it":                          "- %s: %s użył go %d razy; %s go nie wziął",
+		"Spell Economy": "Ekonomia czarów",
+
+		// Communication
+		"TARGET": "CEL",
+		"- %s (%s, %s)%s: %d pings (%.2f/min vs teammates %.2f/min), style %s; informative %d, call to action %d, caution %d; mix: %s": "- %s (%s, %s)%s: %d pingów (%.2f/min kontra sojusznicy %.2f/min), styl %s; informacyjne %d, wezwania do działania %d, ostrzeżenia %d; mieszanka: %s",
+		"Enemy Missing":  "Brak przeciwnika",
+		"Enemy Vision":   "Wizja przeciwnika",
+		"Need Vision":    "Potrzebna wizja",
+		"Vision Cleared": "Wizja usunięta",
+		"Danger":         "Niebezpieczeństwo",
+		"All In":         "Wszyscy do ataku",
+		"Push":           "Pchaj",
+		"On My Way":      "W drodze",
+		"Assist Me":      "Pomóż mi",
+		"Command":        "Rozkaz",
+		"Get Back":       "Wycofaj się",
+		"Retreat":        "Odwrót",
+		"Hold":           "Czekaj",
+		"silent":         "cichy",
+		"negative":       "negatywny",
+		"informative":    "informacyjny",
+		"directive":      "dyrektywny",
+		"cautious":       "ostrożny",
+		"balanced":       "zrównoważony",
+
+		// Arena standings
+		"- %s: Duo %d - %s - %d kills, %d deaths": "- %s: Duet %d - %s - %d zabójstw, %d śmierci",
+	},
+	"es": {
+		"Match ID":                         "ID de la partida",
+		"Game Mode":                        "Modo de juego",
+		"Game Duration":                    "Duración",
+		"seconds":                          "segundos",
+		"minutes":                          "minutos",
+		"Game Version":                     "Versión del juego",
+		"Queue":                            "Cola",
+		"Teams":                            "Equipos",
+		"Duo Standings":                    "Clasificación de dúos",
+		"Participants":                     "Participantes",
+		"TARGET FOR DEEP DIVE":             "OBJETIVO DEL ANÁLISIS DETALLADO",
+		"TEAM COMPARISON":                  "COMPARACIÓN DE EQUIPOS",
+		"DRAFT":                            "DRAFT",
+		"DERIVED METRICS":                  "MÉTRICAS DERIVADAS",
+		"LOBBY RANKS":                      "RANGOS DE LA SALA",
+		"DETAILED STATS FOR TARGET PLAYER": "ESTADÍSTICAS DETALLADAS DEL JUGADOR",
+		"OPPONENT COMPOSITION":             "COMPOSICIÓN RIVAL",
+		"RULE-BASED FINDINGS":              "HALLAZGOS BASADOS EN REGLAS",
+		"ITEM BUILD TIMELINE":              "CRONOLOGÍA DE OBJETOS",
+		"SUMMONER SPELL USAGE":             "USO DE HECHIZOS DE INVOCADOR",
+		"COMMUNICATION":                    "COMUNICACIÓN",
+		"TIMELINE HIGHLIGHTS":              "MOMENTOS CLAVE",
+		"DATA LIMITATIONS":                 "LIMITACIONES DE LOS DATOS",
+
+		"computed from the match data, do not contradict":                                                   "calculados a partir de los datos de la partida, no los contradigas",
+		"judged against the role and rank benchmark dataset; present them as comparisons with that dataset": "evaluados frente al conjunto de referencias por rol y rango; preséntalos como comparaciones con ese conjunto",
+
+		// Teams, results and the participant list
+		"Blue":     "Azul",
+		"Red":      "Rojo",
+		"Won":      "Ganó",
+		"Lost":     "Perdió",
+		"Victory":  "Victoria",
+		"Defeat":   "Derrota",
+		"Yes":      "Sí",
+		"No":       "No",
+		"none":     "ninguno",
+		"Duo %d":   "Dúo %d",
+		"%s place": "%s puesto",
+		"- Team %s (%s): %d turrets destroyed, %d dragons, %d barons":                                           "- Equipo %s (%s): %d torres destruidas, %d dragones, %d barones",
+		"- Team %s (%s): %d turrets destroyed, %d champion kills":                                               "- Equipo %s (%s): %d torres destruidas, %d asesinatos de campeones",
+		"- %s (%s, %s, %s)%s: K/D/A: %d/%d/%d, CS: %d, Gold: %d, Damage: %d":                                    "- %s (%s, %s, %s)%s: K/D/A: %d/%d/%d, CS: %d, oro: %d, daño: %d",
+		"- Omitted to fit the token budget: %s. Do not guess their contents.":                                   "- Omitido para ajustarse al presupuesto de tokens: %s. No adivines su contenido.",
+		"NOTE: No participant matched the provided champion/summoner filter. Deep dive details may be limited.": "NOTA: Ningún participante coincide con el filtro de campeón/invocador indicado. El análisis detallado puede ser limitado.",
+
+		// DATA LIMITATIONS
+		"- No event timeline or objective timestamps are available in this summary.":                    "- Este resumen no incluye una cronología de eventos ni marcas de tiempo de objetivos.",
+		"- Exact item purchase times are not included.":                                                 "- No se incluyen los momentos exactos de compra de objetos.",
+		"- Timestamps above come from the Riot match timeline (mm:ss game time).":                       "- Las marcas de tiempo de arriba proceden de la cronología de la partida de Riot (tiempo de juego mm:ss).",
+		"- Derived metrics are computed exactly from the match data; cite them rather than estimating.": "- Las métricas derivadas se calculan con exactitud a partir de los datos de la partida; cítalas en lugar de estimar.",
+		"- Item and summoner spell names are not included (IDs only).":                                  "- No se incluyen los nombres de objetos ni de hechizos de invocador (solo ID).",
+		"- Item, rune and summoner spell names come from Data Dragon patch %s.":                         "- Los nombres de objetos, runas y hechizos de invocador proceden de Data Dragon, parche %s.",
+		"- The match was played on patch %s, but item, rune and summoner spell names and descriptions come from Data Dragon patch %s; items and runes changed since then may be described as they are now.": "- La partida se jugó en el parche %s, pero los nombres y descripciones de objetos, runas y hechizos de invocador proceden de Data Dragon, parche %s; los objetos y runas cambiados desde entonces pueden describirse tal como son ahora.",
+		"- Draft pick order is not available, only ban order; role-targeted bans are inferred from champion class.":                                                                                         "- El orden de selección del draft no está disponible, solo el de bloqueos; los bloqueos dirigidos a un rol se deducen de la clase del campeón.",
+		"- Lobby ranks are current standings (fetched now), not the ranks at the time of the match.":                                                                                                        "- Los rangos de la sala son las clasificaciones actuales (consultadas ahora), no los rangos en el momento de la partida.",
+		"- Champion mastery is the player's current total, including any games played after this match.":                                                                                                    "- La maestría de campeón es el total actual del jugador, incluidas las partidas jugadas después de esta.",
+		"- This is an ARAM game: there are no lanes, lane opponents, jungle or vision objectives.":                                                                                                          "- Esta es una partida de ARAM: no hay calles, rivales de calle, jungla ni objetivos de visión.",
+		"- This is an Arena game: duos are ranked by placement; there are no lanes, minions or objectives. Augment names are not available.":                                                                "- Esta es una partida de Arena: los dúos se clasifican por puesto; no hay calles, súbditos ni objetivos. Los nombres de los aumentos no están disponibles.",
+		"- This is a featured mode (%s); Summoner's Rift benchmarks may not apply.":                                                                                                                         "- Este es un modo destacado (%s); las referencias de la Grieta del Invocador pueden no aplicarse.",
+		"- This is a %s summary; some sections are left out at this verbosity.":                                                                                                                             "- Este es un resumen %s; algunas secciones se omiten en este nivel de detalle.",
+		"- Do not infer exact timings unless explicitly provided above.":                                                                                                                                    "- No deduzcas tiempos exactos si no se indican explícitamente arriba.",
+
+		// Deep dive
+		"Summoner: %s (%s#%s)":              "Invocador: %s (%s#%s)",
+		"Champion: %s (Level %d)":           "Campeón: %s (nivel %d)",
+		"Champion Mastery: %s":              "Maestría de campeón: %s",
+		"Arena Duo: %d, Placement: %s":      "Dúo de Arena: %d, puesto: %s",
+		"Augments (IDs, in pick order): %s": "Aumentos (ID, en orden de elección): %s",
+		"Team Position: %s (resolved from %s, %s confidence; Lane: %s, Role: %s)": "Posición en el equipo: %s (deducida de %s, confianza %s; calle: %s, rol: %s)",
+		"Team Position: unknown":                       "Posición en el equipo: desconocida",
+		"Team Position: none (this mode has no lanes)": "Posición en el equipo: ninguna (este modo no tiene calles)",
+		"Result: %s":                                        "Resultado: %s",
+		"Performance Metrics":                               "Métricas de rendimiento",
+		"- K/D/A: %d/%d/%d (KDA Ratio: %.2f)":               "- K/D/A: %d/%d/%d (ratio KDA: %.2f)",
+		"- CS: %d (%d minions, %d monsters; %.1f CS/min)":   "- CS: %d (%d súbditos, %d monstruos; %.1f CS/min)",
+		"- Gold Earned: %d (Gold/min: %.0f)":                "- Oro obtenido: %d (oro/min: %.0f)",
+		"- Gold Spent: %d":                                  "- Oro gastado: %d",
+		"- Kill Participation: %.0f%%":                      "- Participación en asesinatos: %.0f%%",
+		"- Damage per Minute: %.0f (%.0f%% of team damage)": "- Daño por minuto: %.0f (%.0f%% del daño del equipo)",
+		"Combat Stats":                                      "Estadísticas de combate",
+		"- Total Damage to Champions: %d":                   "- Daño total a campeones: %d",
+		"- Physical Damage: %d":                             "- Daño físico: %d",
+		"- Magic Damage: %d":                                "- Daño mágico: %d",
+		"- True Damage: %d":                                 "- Daño verdadero: %d",
+		"- Damage Taken: %d":                                "- Daño recibido: %d",
+		"- Damage Self Mitigated: %d":                       "- Daño mitigado: %d",
+		"- Total Heal: %d":                                  "- Curación total: %d",
+		"- Total Shields on Teammates: %d":                  "- Escudos totales a aliados: %d",
+		"Objective Control":                                 "Control de objetivos",
+		"- Turret Kills: %d":                                "- Torres destruidas: %d",
+		"- Inhibitor Kills: %d":                             "- Inhibidores destruidos: %d",
+		"- Dragon Kills: %d":                                "- Dragones asesinados: %d",
+		"- Baron Kills: %d":                                 "- Barones asesinados: %d",
+		"- First Blood: %s":                                 "- Primera sangre: %s",
+		"- First Tower: %s":                                 "- Primera torre: %s",
+		"Vision & Map Control":                              "Visión y control del mapa",
+		"- Vision Score: %d (%.2f per minute)":              "- Puntuación de visión: %d (%.2f por minuto)",
+		"- Vision Score: %d":                                "- Puntuación de visión: %d",
+		"- Wards Placed: %d":                                "- Guardianes colocados: %d",
+		"- Wards Killed: %d":                                "- Guardianes destruidos: %d",
+		"- Control Wards Purchased: %d":                     "- Guardianes de control comprados: %d",
+		"- Detector Wards Placed: %d":                       "- Guardianes de control colocados: %d",
+		"Special Achievements":                              "Logros especiales",
+		"- Largest Killing Spree: %d":                       "- Mayor racha de asesinatos: %d",
+		"- Killing Sprees: %d":                              "- Rachas de asesinatos: %d",
+		"- Double Kills: %d":                                "- Asesinatos dobles: %d",
+		"- Triple Kills: %d":                                "- Asesinatos triples: %d",
+		"- Quadra Kills: %d":                                "- Asesinatos cuádruples: %d",
+		"- Penta Kills: %d":                                 "- Pentakills: %d",
+		"- Unreal Kills: %d":                                "- Asesinatos irreales: %d",
+		"- Largest Multi Kill: %d":                          "- Mayor asesinato múltiple: %d",
+		"Role & Rank Benchmarks (vs %s in queue %d; dataset %s: %d games from %s, patches %s):": "Referencias por rol y rango (frente a %s en la cola %d; conjunto de datos %s: %d partidas de %s, parches %s):",
+		"Challenge Metrics":             "Métricas de desafíos",
+		"Item Build":                    "Build de objetos",
+		"Item":                          "Objeto",
+		"Trinket":                       "Abalorio",
+		"- Total Items Purchased: %d":   "- Objetos comprados en total: %d",
+		"%s: Used %d times (%.2f/min":   "%s: usado %d veces (%.2f/min",
+		", at most %d at base cooldown": ", como máximo %d con el enfriamiento base",
+		"- Primary: %s (Keystone: %s), Secondary: %s": "- Principal: %s (piedra angular: %s), secundaria: %s",
+		"KEYSTONE":                                "PIEDRA ANGULAR",
+		"- Stat Shards: %s":                       "- Fragmentos de estadísticas: %s",
+		"Summoner Spells":                         "Hechizos de invocador",
+		"Runes (values recorded by the game)":     "Runas (valores registrados por el juego)",
+		"Game Impact":                             "Impacto en la partida",
+		"- Time Spent Dead: %d seconds":           "- Tiempo muerto: %d segundos",
+		"- Longest Time Spent Living: %d seconds": "- Mayor tiempo con vida: %d segundos",
+		"- CC Score (weighted, not seconds): %d":  "- Puntuación de control de masas (ponderada, no segundos): %d",
+		"- Total CC Dealt: %d seconds":            "- Control de masas infligido: %d segundos",
+
+		// Challenge metrics
+		"- Damage Taken Share: %.0f%% of team damage taken":                    "- Proporción de daño recibido: %.0f%% del daño recibido por el equipo",
+		"- Takedowns Before 10:00: %d":                                         "- Participaciones en asesinatos antes del 10:00: %d",
+		"- Solo Kills: %d, Outnumbered Kills: %d, Picks With an Ally: %d":      "- Asesinatos en solitario: %d, asesinatos en inferioridad numérica: %d, eliminaciones con un aliado: %d",
+		"- Skillshots Hit: %d, Skillshots Dodged: %d":                          "- Habilidades direccionales acertadas: %d, esquivadas: %d",
+		"- Enemy Champion Immobilizations: %d":                                 "- Inmovilizaciones de campeones enemigos: %d",
+		"- Saved an Ally From Death: %d, Survived on Single-Digit HP: %d":      "- Aliados salvados de la muerte: %d, supervivencias con vida de un dígito: %d",
+		"- Jungle CS Before 10:00: %.0f":                                       "- CS de jungla antes del 10:00: %.0f",
+		"- Enemy Jungle Monsters Killed: %.0f, Buffs Stolen: %d":               "- Monstruos de la jungla enemiga asesinados: %.0f, mejoras robadas: %d",
+		"- Lane Minions by 10:00: %d":                                          "- Súbditos de calle hasta el 10:00: %d",
+		"- Turret Plates Taken: %d":                                            "- Placas de torre obtenidas: %d",
+		"- Max CS Lead on Lane Opponent: %.0f, Max Level Lead: %d":             "- Mayor ventaja de CS sobre el rival de calle: %.0f, mayor ventaja de nivel: %d",
+		"- Ahead in Gold and XP at 7:00: %s, at 14:00: %s":                     "- Por delante en oro y experiencia en el 7:00: %s, en el 14:00: %s",
+		"- Vision Score Advantage vs Lane Opponent: %+.0f%%":                   "- Ventaja de puntuación de visión frente al rival de calle: %+.0f%%",
+		"- Objective Takedowns: %d turrets, %d dragons, %d barons, %d heralds": "- Participación en objetivos: %d torres, %d dragones, %d barones, %d heraldos",
+		"- Control Wards Placed: %d, Wards Cleared: %d":                        "- Guardianes de control colocados: %d, guardianes eliminados: %d",
+
+		// Opponent composition
+		"Your Team (%s):":     "Tu equipo (%s):",
+		"Opponent Team (%s):": "Equipo rival (%s):",
+		"  -> LANE OPPONENT: %s vs %s (%s, %s confidence)":                                                 "  -> RIVAL DE CALLE: %s contra %s (%s, confianza %s)",
+		"     Result: %d/%d/%d (You) vs %d/%d/%d (Opponent)":                                               "     Resultado: %d/%d/%d (tú) contra %d/%d/%d (rival)",
+		"     CS: %d (You) vs %d (Opponent)":                                                               "     CS: %d (tú) contra %d (rival)",
+		"     Gold: %d (You) vs %d (Opponent)":                                                             "     Oro: %d (tú) contra %d (rival)",
+		"No lane opponents: this mode has no lanes.":                                                       "Sin rivales de calle: este modo no tiene calles.",
+		"NOTE: Roles were inferred (target: %s from %s, %s confidence); treat the lane matchup with care.": "NOTA: Los roles se dedujeron (objetivo: %s a partir de %s, confianza %s); trata el enfrentamiento de calle con cautela.",
+		"Your Duo (Duo %d, %s):":                                                                           "Tu dúo (dúo %d, %s):",
+		"- %s (%s): %d/%d/%d, %d damage":                                                                   "- %s (%s): %d/%d/%d, %d de daño",
+		"Opposing Duos":                                                                                    "Dúos rivales",
+		"- %s (%s), Duo %d, %s: %d/%d/%d":                                                                  "- %s (%s), dúo %d, %s: %d/%d/%d",
+
+		// Timeline highlights
+		"%s - %s (%s) killed %s":                         "%s - %s (%s) asesinó a %s",
+		"%s (killed %s)":                                 "%s (asesinó a %s)",
+		"%s (killed by %s)":                              "%s (asesinado por %s)",
+		"minions/turret":                                 "súbditos/torre",
+		"%s - %s team took %s":                           "%s - el equipo %s consiguió %s",
+		"%s - %s team destroyed %s":                      "%s - el equipo %s destruyó %s",
+		"First Blood: %s":                                "Primera sangre: %s",
+		"Objective Timeline":                             "Cronología de objetivos",
+		"%s Kill Times: %s":                              "Momentos de asesinatos de %s: %s",
+		"%s Death Times: %s":                             "Momentos de muerte de %s: %s",
+		"At %d:00 - Gold: %d, CS: %d, XP: %d, Level: %d": "En el %d:00 - oro: %d, CS: %d, experiencia: %d, nivel: %d",
+		" | vs %s - Gold: %d, CS: %d, XP: %d (Gold diff: %+d, CS diff: %+d)": " | contra %s - oro: %d, CS: %d, experiencia: %d (diferencia de oro: %+d, diferencia de CS: %+d)",
+
+		// Compact JSON
+		"MATCH DATA (compact JSON):": "DATOS DE LA PARTIDA (JSON compacto):",
+
+		// Team comparison
+		"(%s vs %s, differential from %s's side)": "(%s contra %s, diferencia desde el lado %s)",
+		"vs":                  "contra",
+		"Kills":               "Asesinatos",
+		"Gold Earned":         "Oro obtenido",
+		"Damage to Champions": "Daño a campeones",
+		"Damage to Buildings": "Daño a estructuras",
+		"Vision Score":        "Puntuación de visión",
+		"CC Time (seconds)":   "Tiempo de control de masas (segundos)",
+		"Turrets":             "Torretas",
+		"Inhibitors":          "Inhibidores",
+		"Dragons":             "Dragones",
+		"Rift Heralds":        "Heraldos",
+		"Barons":              "Barones",
+		"- Firsts: Blood %s, Tower %s, Inhibitor %s, Dragon %s, Herald %s, Baron %s": "- Primeros: sangre %s, torreta %s, inhibidor %s, dragón %s, heraldo %s, barón %s",
+
+		// Draft
+		"Team %s":           "Equipo %s",
+		"YOUR TEAM":         "TU EQUIPO",
+		"ENEMY TEAM":        "EQUIPO ENEMIGO",
+		"%s (turn %d)":      "%s (turno %d)",
+		"- Bans: %s":        "- Baneos: %s",
+		"- Composition: %s": "- Composición: %s",
+		"%s (by %s)":        "%s (por %s)",
+		"Enemy bans aimed at the target's role (%s): not determinable - champion classes only single out ADC and support champions": "Baneos enemigos dirigidos al rol del objetivo (%s): no determinable - las clases de campeón solo distinguen a tiradores y soportes",
+		"Enemy bans aimed at the target's role (%s): not determinable without static data":                                          "Baneos enemigos dirigidos al rol del objetivo (%s): no determinable sin datos estáticos",
+		"Enemy bans of champions played in the target's role (%s, by champion class): %s":                                           "Baneos enemigos de campeones del rol del objetivo (%s, según la clase de campeón): %s",
+
+		// Derived metrics
+		"%d/%d/%d, %d gold, CS/min %.1f, Vision/min %.2f, DPM %.0f, Gold/min %.0f, Damage/Gold %.2f, Deaths/10min %.1f":                  "%d/%d/%d, %d de oro, CS/min %.1f, visión/min %.2f, DPM %.0f, oro/min %.0f, daño/oro %.2f, muertes/10 min %.1f",
+		"KP %.0f%%, Damage Share %.0f%%, Gold Share %.0f%%, CS/min %.1f, Vision/min %.2f, DPM %.0f, Damage/Gold %.2f, Deaths/10min %.1f": "PA %.0f%%, cuota de daño %.0f%%, cuota de oro %.0f%%, CS/min %.1f, visión/min %.2f, DPM %.0f, daño/oro %.2f, muertes/10 min %.1f",
+
+		// Lobby ranks
+		"Average Solo/Duo Rank: %s (%d of %d players ranked)":      "Rango medio en solo/dúo: %s (%d de %d jugadores clasificados)",
+		"Average Solo/Duo Rank: unknown (no ranked players found)": "Rango medio en solo/dúo: desconocido (no se encontraron jugadores clasificados)",
+		"Team %s: %s":                          "Equipo %s: %s",
+		"Players":                              "Jugadores",
+		"- %s (%s, %s): rank unavailable":      "- %s (%s, %s): rango no disponible",
+		"- %s (%s, %s): Solo/Duo %s | Flex %s": "- %s (%s, %s): solo/dúo %s | flexible %s",
+		"Unranked":                             "Sin clasificar",
+		"%s %d LP (%dW/%dL, %.0f%% WR)":        "%s %d PL (%dV/%dD, %.0f%% de victorias)",
+
+		// Item build timeline
+		"Total Items Purchased: %d":             "Objetos comprados en total: %d",
+		"Final Build":                           "Build final",
+		"Item 1":                                "Objeto 1",
+		"Item 2":                                "Objeto 2",
+		"Item 3":                                "Objeto 3",
+		"Item 4":                                "Objeto 4",
+		"Item 5":                                "Objeto 5",
+		"Item 6":                                "Objeto 6",
+		" - first bought at %s":                 " - comprado por primera vez en el %s",
+		"First Completed Item: %s at %s":        "Primer objeto completo: %s en el %s",
+		"Second Completed Item: %s at %s":       "Segundo objeto completo: %s en el %s",
+		"Purchase Order (from match timeline):": "Orden de compra (de la cronología de la partida):",
+		"Gold Income: %.0f gold/minute":         "Ingresos de oro: %.0f de oro/minuto",
+		"Note: Exact item purchase times require timeline data from Riot API match timeline endpoint": "Nota: los momentos exactos de compra requieren datos del endpoint de cronología de partidas de la API de Riot",
+
+		// Summoner spell usage
+		"Lane Opponent %s (%s):":     "Rival de línea %s (%s):",
+		"- %s: %d casts vs %d by %s": "- %s: %d usos contra %d de %s",
+		"- %s: %d casts":             "- %s: %d usos",
+		"- %s Kill Conversion: %d of %d casts hit a champion who then died (%s)": "- Conversión de %s en asesinatos: %d de %d usos alcanzaron a un campeón que luego murió (%s)",
+		"- %s: %d casts; kill conversion needs timeline damage data":             "- %s: %d usos; la conversión en asesinatos necesita datos de daño de la cronología",
+		"- %s: %s used it %d times; %s did not take it":                          "- %s: %s lo usó %d veces; %s no lo llevaba",
+		"Spell Economy": "Economía de hechizos",
+
+		// Communication
+		"TARGET": "OBJETIVO",
+		"- %s (%s, %s)%s: %d pings (%.2f/min vs teammates %.2f/min), style %s; informative %d, call to action %d, caution %d; mix: %s": "- %s (%s, %s)%s: %d pings (%.2f/min contra compañeros %.2f/min), estilo %s; informativos %d, llamadas a la acción %d, precaución %d; mezcla: %s",
+		"Enemy Missing":  "Enemigo desaparecido",
+		"Enemy Vision":   "Visión enemiga",
+		"Need Vision":    "Se necesita visión",
+		"Vision Cleared": "Visión despejada",
+		"Danger":         "Peligro",
+		"All In":         "Todo o nada",
+		"Push":           "Empujar",
+		"On My Way":      "De camino",
+		"Assist Me":      "Ayúdame",
+		"Command":        "Orden",
+		"Get Back":       "Retrocede",
+		"Retreat":        "Retirada",
+		"Hold":           "Espera",
+		"silent":         "silencioso",
+		"negative":       "negativo",
+		"informative":    "informativo",
+		"directive":      "directivo",
+		"cautious":       "cauteloso",
+		"balanced":       "equilibrado",
+
+		// Arena standings
+		"- %s: Duo %d - %s - %d kills, %d deaths": "- %s: Dúo %d - %s - %d asesinatos, %d muertes",
+	},
+}
+
+// ordinalFormats writes placements in languages that do not use English suffixes
+var ordinalFormats = map[string]string{
+	"de": "%d.",
+	"pl": "%d.",
+	"es": "%dº",
+}
+
+// label translates a summary label, falling back to English
+func (l Language) label(english string) string {
+	if translated, ok := summaryLabels[l.Code][english]; ok {
+		return translated
+	}
+	return english
+}
+
+// sprintf formats with the translation of format; translations reorder arguments with explicit indexes (%[2]d) where needed
+func (l Language) sprintf(format string, args ...interface{}) string {
+	return fmt.Sprintf(l.label(format), args...)
+}
+
+// ordinal formats a placement, e.g. "3rd" in English and "3." in German
+func (l Language) ordinal(n int) string {
+	if format, ok := ordinalFormats[l.Code]; ok {
+		return fmt.Sprintf(format, n)
+	}
+	return ordinal.English(n)
+}
+
+// heading translates a section heading and keeps the English name the prompts refer to, e.g. "TEAMVERGLEICH (TEAM COMPARISON)"
+func (l Language) heading(english string) string {
+	translated := l.label(english)
+	if translated == english {
+		return english
+	}
+	return translated + " (" + english + ")"
+}
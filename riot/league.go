@@ -0,0 +1,234 @@
+package riot
+
+import (
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"lol-ranked-new-meta/staticdata"
+	"lol-ranked-new-meta/types"
+)
+
+// leagueCacheTTL is how long league-v4 entries are reused; LP moves every game, so keep it short
+const leagueCacheTTL = 30 * time.Minute
+
+// rankedTiers lists tiers from lowest to highest
+var rankedTiers = []string{"IRON", "BRONZE", "SILVER", "GOLD", "PLATINUM", "EMERALD", "DIAMOND", "MASTER", "GRANDMASTER", "CHALLENGER"}
+
+// rankedDivisions lists divisions from lowest to highest
+var rankedDivisions = []string{"IV", "III", "II", "I"}
+
+// GetLeagueEntriesByPUUID fetches a player's ranked entries (one per ranked queue) from league-v4
+// platform is the platform the player plays on (e.g. euw1, na1), not a routing region
+func (c *Client) GetLeagueEntriesByPUUID(puuid, platform string) ([]types.RiotLeagueEntry, error) {
+	platform = strings.ToLower(strings.TrimSpace(platform))
+	if platform == "" {
+		return nil, fmt.Errorf("platform is required for league-v4")
+	}
+
+	cacheKey := platform + "/" + puuid
+	var entries []types.RiotLeagueEntry
+	if c.leagueCache.get(cacheKey, &entries) {
+		return entries, nil
+	}
+
+	path := "/lol/league/v4/entries/by-puuid/" + url.PathEscape(puuid)
+	if err := c.get(platform, "league-v4.getLeagueEntriesByPUUID", path, &entries); err != nil {
+		return nil, err
+	}
+	c.leagueCache.put(cacheKey, entries)
+	return entries, nil
+}
+
+// GetLobbyRanks fetches the solo/duo and flex standing of every participant, in participant order
+// Lookups that fail are reported in PlayerRank.Error rather than failing the whole lobby
+func (c *Client) GetLobbyRanks(match *types.RiotMatch) []types.PlayerRank {
+	if match == nil {
+		return nil
+	}
+
+	platform := MatchPlatform(match)
+
+	ranks := make([]types.PlayerRank, len(match.Info.Participants))
+	var wg sync.WaitGroup
+	for i, p := range match.Info.Participants {
+		ranks[i] = types.PlayerRank{
+			Puuid:        p.Puuid,
+			SummonerName: p.SummonerName,
+			ChampionName: p.ChampionName,
+			TeamID:       p.TeamID,
+		}
+		if p.Puuid == "" || p.Puuid == "BOT" {
+			ranks[i].Error = "no puuid for this participant"
+			continue
+		}
+
+		wg.Add(1)
+		go func(rank *types.PlayerRank) {
+			defer wg.Done()
+			entries, err := c.GetLeagueEntriesByPUUID(rank.Puuid, platform)
+			if err != nil {
+				log.Printf("Warning: failed to fetch rank for %s: %v", rank.SummonerName, err)
+				rank.Error = "rank unavailable"
+				return
+			}
+			rank.SoloDuo = standingFor(entries, types.QueueTypeSolo)
+			rank.Flex = standingFor(entries, types.QueueTypeFlex)
+		}(&ranks[i])
+	}
+	wg.Wait()
+
+	return ranks
+}
+
+// standingFor returns the standing in queueType, or nil when the player is unranked there
+func standingFor(entries []types.RiotLeagueEntry, queueType string) *types.RankedStanding {
+	for _, e := range entries {
+		if e.QueueType != queueType {
+			continue
+		}
+		standing := &types.RankedStanding{
+			Tier:         e.Tier,
+			Division:     e.Rank,
+			LeaguePoints: e.LeaguePoints,
+			Wins:         e.Wins,
+			Losses:       e.Losses,
+		}
+		if rankScore(e.Tier, "", 0) >= rankScore("MASTER", "", 0) {
+			standing.Division = ""
+		}
+		return standing
+	}
+	return nil
+}
+
+// FormatRankedStanding formats a standing as e.g. "Gold II 45 LP (60W/55L, 52% WR)", or "Unranked" for nil
+func FormatRankedStanding(standing *types.RankedStanding) string {
+	return formatRankedStanding(standing, English)
+}
+
+// formatRankedStanding formats a standing in lang
+func formatRankedStanding(standing *types.RankedStanding, lang Language) string {
+	if standing == nil {
+		return lang.label("Unranked")
+	}
+	label := rankLabel(standing.Tier, standing.Division)
+	games := standing.Wins + standing.Losses
+	if games == 0 {
+		return fmt.Sprintf("%s %d LP", label, standing.LeaguePoints)
+	}
+	return lang.sprintf("%s %d LP (%dW/%dL, %.0f%% WR)", label, standing.LeaguePoints,
+		standing.Wins, standing.Losses, float64(standing.Wins)/float64(games)*100)
+}
+
+// FormatLobbyRanks lists every participant's ranked standing in lang and the average solo/duo rank per team
+// static is optional - it adds localized champion names
+func FormatLobbyRanks(ranks []types.PlayerRank, static *staticdata.Bundle, lang Language) string {
+	if len(ranks) == 0 {
+		return ""
+	}
+
+	var section string
+	if average, ranked := AverageSoloRank(ranks, 0); ranked > 0 {
+		section += lang.sprintf("Average Solo/Duo Rank: %s (%d of %d players ranked)", average, ranked, len(ranks)) + "\n"
+		var teams []string
+		for _, teamID := range []int{100, 200} {
+			if teamAverage, teamRanked := AverageSoloRank(ranks, teamID); teamRanked > 0 {
+				teams = append(teams, lang.sprintf("Team %s: %s", lang.label(teamLabel(teamID)), teamAverage))
+			}
+		}
+		if len(teams) > 0 {
+			section += strings.Join(teams, " | ") + "\n"
+		}
+	} else {
+		section += lang.label("Average Solo/Duo Rank: unknown (no ranked players found)") + "\n"
+	}
+
+	section += "\n" + lang.label("Players") + ":\n"
+	for _, r := range ranks {
+		champion := championNameLabel(static, r.ChampionName)
+		if r.Error != "" {
+			section += lang.sprintf("- %s (%s, %s): rank unavailable", r.SummonerName, champion, lang.label(teamLabel(r.TeamID))) + "\n"
+			continue
+		}
+		section += lang.sprintf("- %s (%s, %s): Solo/Duo %s | Flex %s", r.SummonerName, champion, lang.label(teamLabel(r.TeamID)),
+			formatRankedStanding(r.SoloDuo, lang), formatRankedStanding(r.Flex, lang)) + "\n"
+	}
+	return section
+}
+
+// AverageSoloRank returns the average solo/duo rank (e.g. "Gold II") of the ranked players on teamID (0 = whole lobby)
+// and how many players it is based on
+func AverageSoloRank(ranks []types.PlayerRank, teamID int) (string, int) {
+	total, count := 0, 0
+	for _, r := range ranks {
+		if r.SoloDuo == nil || (teamID != 0 && r.TeamID != teamID) {
+			continue
+		}
+		score := rankScore(r.SoloDuo.Tier, r.SoloDuo.Division, r.SoloDuo.LeaguePoints)
+		if score < 0 {
+			continue
+		}
+		total += score
+		count++
+	}
+	if count == 0 {
+		return "", 0
+	}
+
+	average := total / count
+	if average >= rankScore("MASTER", "", 0) {
+		return "Master+", count
+	}
+	tier := rankedTiers[average/400]
+	division := rankedDivisions[(average%400)/100]
+	return rankLabel(tier, division), count
+}
+
+// rankScore maps a rank onto a single scale (100 points per division) so ranks can be averaged
+// Master and above have no divisions and share one LP ladder; -1 means the tier is unknown
+func rankScore(tier, division string, leaguePoints int) int {
+	tierIndex := -1
+	for i, t := range rankedTiers {
+		if strings.EqualFold(t, tier) {
+			tierIndex = i
+			break
+		}
+	}
+	if tierIndex < 0 {
+		return -1
+	}
+
+	masterIndex := len(rankedTiers) - 3
+	if tierIndex >= masterIndex {
+		return masterIndex*400 + leaguePoints
+	}
+
+	divisionIndex := 0
+	for i, d := range rankedDivisions {
+		if strings.EqualFold(d, division) {
+			divisionIndex = i
+			break
+		}
+	}
+	if leaguePoints > 99 {
+		leaguePoints = 99
+	}
+	return tierIndex*400 + divisionIndex*100 + leaguePoints
+}
+
+// rankLabel formats a tier and division as e.g. "Gold II" or "Grandmaster"
+func rankLabel(tier, division string) string {
+	tier = strings.ToLower(tier)
+	if tier == "" {
+		return "Unranked"
+	}
+	label := strings.ToUpper(tier[:1]) + tier[1:]
+	if division != "" {
+		label += " " + division
+	}
+	return label
+}
@@ -0,0 +1,321 @@
+package riot
+
+import (
+	"fmt"
+	"log"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+
+	"lol-ranked-new-meta/staticdata"
+	"lol-ranked-new-meta/types"
+)
+
+// smiteSpellID is the summoner spell ID of Smite, which marks the jungler in a live game
+const smiteSpellID = 11
+
+// positionOrder is the order lanes are listed in the live game report
+var positionOrder = []string{"TOP", "JUNGLE", "MIDDLE", "BOTTOM", "UTILITY"}
+
+// GetActiveGameByPUUID fetches the player's current game from spectator-v5
+// Returns ErrNotFound when the player is not in a game
+func (c *Client) GetActiveGameByPUUID(puuid, platform string) (*types.RiotCurrentGameInfo, error) {
+	platform = strings.ToLower(strings.TrimSpace(platform))
+	if platform == "" {
+		return nil, fmt.Errorf("platform is required for spectator-v5")
+	}
+
+	var game types.RiotCurrentGameInfo
+	path := "/lol/spectator/v5/active-games/by-summoner/" + url.PathEscape(puuid)
+	if err := c.get(platform, "spectator-v5.getCurrentGameInfoByPuuid", path, &game); err != nil {
+		return nil, err
+	}
+	return &game, nil
+}
+
+// GetRecentForm summarizes a player's last count matches in queue (0 = all queues)
+// championID is the champion they are playing now, counted in RecentForm.ChampionGames
+func (c *Client) GetRecentForm(puuid, region string, count, queue, championID int) (*types.RecentForm, error) {
+	matchIDs, err := c.GetMatchIDsByPUUID(puuid, region, 0, count, queue)
+	if err != nil {
+		return nil, err
+	}
+
+	summaries := make([]types.PlayerMatchSummary, 0, len(matchIDs))
+	for _, matchID := range matchIDs {
+		match, err := c.GetMatchWithRegion(matchID, region)
+		if err != nil {
+			log.Printf("Warning: failed to fetch match %s for recent form: %v", matchID, err)
+			continue
+		}
+		summaries = append(summaries, SummarizeMatchForPlayer(match, puuid))
+	}
+	return SummarizeRecentForm(summaries, championID), nil
+}
+
+// LiveRecentGamesBudget lowers recentGames so scouting players stays inside the app rate limit on region
+// Each player costs one match list call plus one call per recent game; cached matches make it cheaper in practice
+func (c *Client) LiveRecentGamesBudget(region string, players, recentGames int) int {
+	if players <= 0 || recentGames <= 0 {
+		return recentGames
+	}
+	remaining, ok := c.limiter.Remaining(c.routingRegion(region))
+	if !ok {
+		return recentGames
+	}
+	perPlayer := remaining/players - 1
+	if perPlayer < 0 {
+		perPlayer = 0
+	}
+	if recentGames > perPlayer {
+		return perPlayer
+	}
+	return recentGames
+}
+
+// ScoutActiveGame builds a report for every participant of an active game: champion, spells, ranks and recent form
+// recentGames is how many past matches (in the game's queue) are used for recent form; 0 skips match history
+// static is optional - without it champions and spells are reported by ID
+func (c *Client) ScoutActiveGame(game *types.RiotCurrentGameInfo, requestedPUUID string, recentGames int, static *staticdata.Bundle) []types.LivePlayerReport {
+	if game == nil {
+		return nil
+	}
+	platform := strings.ToLower(game.PlatformID)
+	region := RoutingRegionFromPlatform(game.PlatformID)
+
+	reports := make([]types.LivePlayerReport, len(game.Participants))
+	var wg sync.WaitGroup
+	for i, p := range game.Participants {
+		reports[i] = types.LivePlayerReport{
+			Puuid:        p.Puuid,
+			RiotID:       p.RiotID,
+			ChampionID:   p.ChampionID,
+			ChampionName: static.ChampionName(p.ChampionID),
+			TeamID:       p.TeamID,
+			Spells:       []string{static.SummonerSpellName(p.Spell1ID), static.SummonerSpellName(p.Spell2ID)},
+			IsRequested:  p.Puuid != "" && p.Puuid == requestedPUUID,
+		}
+		if p.Bot || p.Puuid == "" {
+			reports[i].Error = "bot or hidden player"
+			continue
+		}
+
+		wg.Add(1)
+		go func(report *types.LivePlayerReport, p types.RiotCurrentGameParticipant) {
+			defer wg.Done()
+
+			entries, err := c.GetLeagueEntriesByPUUID(report.Puuid, platform)
+			if err != nil {
+				log.Printf("Warning: failed to fetch rank for %s: %v", report.RiotID, err)
+				report.Error = "rank unavailable"
+			} else {
+				report.SoloDuo = standingFor(entries, types.QueueTypeSolo)
+				report.Flex = standingFor(entries, types.QueueTypeFlex)
+			}
+
+			if recentGames > 0 {
+				// Matched by ID: names differ between match history (MonkeyKing) and Data Dragon (Wukong), and are unknown without static data
+				form, err := c.GetRecentForm(report.Puuid, region, recentGames, game.GameQueueConfigID, p.ChampionID)
+				if err != nil {
+					log.Printf("Warning: failed to fetch recent form for %s: %v", report.RiotID, err)
+				} else {
+					report.RecentForm = form
+				}
+			}
+
+			report.LikelyPosition = likelyPosition(p, report.RecentForm)
+		}(&reports[i], p)
+	}
+	wg.Wait()
+
+	return reports
+}
+
+// likelyPosition guesses a live participant's role: Smite means jungle, otherwise their most played recent position
+func likelyPosition(p types.RiotCurrentGameParticipant, form *types.RecentForm) string {
+	if p.Spell1ID == smiteSpellID || p.Spell2ID == smiteSpellID {
+		return "JUNGLE"
+	}
+	if form != nil && form.MainPosition != "JUNGLE" {
+		return form.MainPosition
+	}
+	return ""
+}
+
+// SummarizeRecentForm aggregates match history entries (newest first) into a recent form summary
+// championID (0 = none) is the champion counted in RecentForm.ChampionGames
+func SummarizeRecentForm(summaries []types.PlayerMatchSummary, championID int) *types.RecentForm {
+	form := &types.RecentForm{}
+	championCounts := make(map[string]int)
+	positionCounts := make(map[string]int)
+	var kills, deaths, assists int
+	streak, streakWin, streakOpen := 0, false, true
+
+	for _, s := range summaries {
+		if s.Error != "" {
+			continue
+		}
+		form.Games++
+		if s.Win {
+			form.Wins++
+		} else {
+			form.Losses++
+		}
+		kills += s.Kills
+		deaths += s.Deaths
+		assists += s.Assists
+		championCounts[s.ChampionName]++
+		if s.TeamPosition != "" {
+			positionCounts[s.TeamPosition]++
+		}
+		if championID != 0 && s.ChampionID == championID {
+			form.ChampionGames++
+		}
+
+		// The streak is the unbroken run of results starting from the newest game
+		if streakOpen {
+			if streak == 0 || s.Win == streakWin {
+				streak++
+				streakWin = s.Win
+			} else {
+				streakOpen = false
+			}
+		}
+	}
+
+	if form.Games == 0 {
+		return form
+	}
+	form.Kills = float64(kills) / float64(form.Games)
+	form.Deaths = float64(deaths) / float64(form.Games)
+	form.Assists = float64(assists) / float64(form.Games)
+	form.MostPlayed = mostFrequent(championCounts)
+	form.MainPosition = mostFrequent(positionCounts)
+	form.CurrentStreak = fmt.Sprintf("%dL", streak)
+	if streakWin {
+		form.CurrentStreak = fmt.Sprintf("%dW", streak)
+	}
+	return form
+}
+
+// mostFrequent returns the key with the highest count, breaking ties alphabetically
+func mostFrequent(counts map[string]int) string {
+	best, bestCount := "", 0
+	for key, count := range counts {
+		if count > bestCount || (count == bestCount && key < best) {
+			best, bestCount = key, count
+		}
+	}
+	return best
+}
+
+// FormatLiveGame converts a live game and its player reports into a scouting summary for the game plan prompt
+func FormatLiveGame(game *types.RiotCurrentGameInfo, reports []types.LivePlayerReport, static *staticdata.Bundle) string {
+	if game == nil {
+		return ""
+	}
+
+	requestedTeam := 0
+	for _, r := range reports {
+		if r.IsRequested {
+			requestedTeam = r.TeamID
+		}
+	}
+
+	var summary string
+	summary += fmt.Sprintf("Game Mode: %s (Queue %d, Platform %s)\n", game.GameMode, game.GameQueueConfigID, game.PlatformID)
+	if game.GameLength > 0 {
+		summary += fmt.Sprintf("Game Time: %s\n", FormatGameTime(game.GameLength*1000))
+	} else {
+		summary += "Game Time: loading screen\n"
+	}
+
+	var bans []string
+	for _, ban := range game.BannedChampions {
+		if ban.ChampionID > 0 {
+			bans = append(bans, fmt.Sprintf("%s (%s)", static.ChampionName(ban.ChampionID), teamLabel(ban.TeamID)))
+		}
+	}
+	summary += fmt.Sprintf("Bans: %s\n", joinOrNone(bans))
+
+	for _, teamID := range []int{100, 200} {
+		label := fmt.Sprintf("Team %s", teamLabel(teamID))
+		if requestedTeam != 0 {
+			if teamID == requestedTeam {
+				label += " (YOUR TEAM)"
+			} else {
+				label += " (ENEMY TEAM)"
+			}
+		}
+		summary += "\n" + label + ":\n"
+		for _, r := range reports {
+			if r.TeamID != teamID {
+				continue
+			}
+			summary += "- " + formatLivePlayer(r) + "\n"
+		}
+	}
+
+	summary += "\nLane Matchups (positions inferred from Smite and recent games; may be wrong):\n"
+	for _, position := range positionOrder {
+		var sides []string
+		for _, teamID := range []int{100, 200} {
+			champion := "unknown"
+			for _, r := range reports {
+				if r.TeamID == teamID && r.LikelyPosition == position {
+					champion = r.ChampionName
+					break
+				}
+			}
+			sides = append(sides, champion)
+		}
+		summary += fmt.Sprintf("- %s: %s (Blue) vs %s (Red)\n", position, sides[0], sides[1])
+	}
+
+	summary += "\nDATA LIMITATIONS:\n"
+	summary += "- Ranks and recent form are current; spectator-v5 does not report roles, and runes are not included.\n"
+	summary += "- Recent form covers only the games listed; do not infer anything beyond them.\n"
+	return summary
+}
+
+// formatLivePlayer formats one line of the live game report
+func formatLivePlayer(r types.LivePlayerReport) string {
+	line := fmt.Sprintf("%s (%s)", r.RiotID, r.ChampionName)
+	if r.IsRequested {
+		line += " [REQUESTED PLAYER]"
+	}
+	if r.LikelyPosition != "" {
+		line += " - likely " + r.LikelyPosition
+	}
+	line += fmt.Sprintf(" | Spells: %s", strings.Join(r.Spells, ", "))
+	if r.Error != "" && r.SoloDuo == nil && r.Flex == nil {
+		line += " | Rank: unavailable"
+	} else {
+		line += fmt.Sprintf(" | Solo/Duo: %s | Flex: %s", FormatRankedStanding(r.SoloDuo), FormatRankedStanding(r.Flex))
+	}
+	if form := r.RecentForm; form != nil && form.Games > 0 {
+		line += fmt.Sprintf(" | Last %d games: %dW/%dL, %.1f/%.1f/%.1f avg KDA, streak %s, %d on %s, most played %s",
+			form.Games, form.Wins, form.Losses, form.Kills, form.Deaths, form.Assists,
+			form.CurrentStreak, form.ChampionGames, r.ChampionName, form.MostPlayed)
+	}
+	return line
+}
+
+// SortLiveReports orders reports by team, then by likely position
+func SortLiveReports(reports []types.LivePlayerReport) {
+	positionIndex := func(position string) int {
+		for i, p := range positionOrder {
+			if p == position {
+				return i
+			}
+		}
+		return len(positionOrder)
+	}
+	sort.SliceStable(reports, func(i, j int) bool {
+		if reports[i].TeamID != reports[j].TeamID {
+			return reports[i].TeamID < reports[j].TeamID
+		}
+		return positionIndex(reports[i].LikelyPosition) < positionIndex(reports[j].LikelyPosition)
+	})
+}
@@ -0,0 +1,118 @@
+package riot_test
+
+import (
+	"strings"
+	"testing"
+
+	"lol-ranked-new-meta/riot"
+	"lol-ranked-new-meta/riottest"
+)
+
+func TestScoutActiveGame(t *testing.T) {
+	server := riottest.NewServerWithFixtures()
+	defer server.Close()
+
+	game := riottest.FixtureActiveGame()
+	// Vi leaves Smite at home, so her jungle history alone does not place her; Syndra has no match history;
+	// Nautilus is a bot
+	game.Participants[6].Spell1ID = 14
+	game.Participants[9].Puuid = ""
+	game.Participants[9].Bot = true
+	for _, p := range game.Participants {
+		if p.Puuid != "" && p.Puuid != "fixture-puuid-08" {
+			server.AddJSON("/lol/match/v5/matches/by-puuid/"+p.Puuid+"/ids", []string{riottest.FixtureMatchID})
+		}
+	}
+
+	reports := server.Client("test-key", "europe").ScoutActiveGame(game, "fixture-puuid-01", 2, fixtureBundle(t, "en_US"))
+	if len(reports) != 10 {
+		t.Fatalf("%d reports, want 10", len(reports))
+	}
+
+	positions := make([]string, len(reports))
+	for i, r := range reports {
+		positions[i] = r.LikelyPosition
+	}
+	if got := strings.Join(positions, ","); got != "TOP,JUNGLE,MIDDLE,BOTTOM,UTILITY,TOP,,,BOTTOM," {
+		t.Errorf("likely positions = %s, want TOP,JUNGLE,MIDDLE,BOTTOM,UTILITY,TOP,,,BOTTOM,", got)
+	}
+
+	aatrox := reports[0]
+	if !aatrox.IsRequested || aatrox.ChampionName != "Aatrox" || strings.Join(aatrox.Spells, ",") != "Flash,Teleport" {
+		t.Errorf("requested player = %+v, want Aatrox with Flash and Teleport", aatrox)
+	}
+	if aatrox.SoloDuo == nil || aatrox.Error != "" {
+		t.Errorf("requested player rank = %+v (error %q), want the fixture's solo/duo entry", aatrox.SoloDuo, aatrox.Error)
+	}
+	if form := aatrox.RecentForm; form == nil || form.Games != 1 || form.ChampionGames != 1 || form.MostPlayed != "Aatrox" || form.CurrentStreak != "1W" {
+		t.Errorf("recent form = %+v, want one won Aatrox game", form)
+	}
+	if reports[7].RecentForm != nil {
+		t.Errorf("Syndra's recent form = %+v, want none without match history", reports[7].RecentForm)
+	}
+	if nautilus := reports[9]; nautilus.Error != "bot or hidden player" || nautilus.RecentForm != nil {
+		t.Errorf("bot report = %+v, want an error and no recent form", nautilus)
+	}
+
+	// Recent form asks for the game's queue only, and nothing is fetched for the bot
+	for _, call := range server.Calls() {
+		if strings.HasSuffix(call.Path, "/ids") && call.Query != "count=2&queue=420&start=0" {
+			t.Errorf("match history query = %q, want count=2&queue=420&start=0", call.Query)
+		}
+		if strings.Contains(call.Path, "fixture-puuid-10") {
+			t.Errorf("bot was looked up at %s", call.Path)
+		}
+	}
+}
+
+func TestScoutActiveGameWithoutHistory(t *testing.T) {
+	server := riottest.NewServerWithFixtures()
+	defer server.Close()
+
+	// With no recent games only Smite places a player, and champions and spells fall back to IDs without static data
+	reports := server.Client("test-key", "europe").ScoutActiveGame(riottest.FixtureActiveGame(), "", 0, nil)
+	for i, r := range reports {
+		want := ""
+		if i == 1 || i == 6 {
+			want = "JUNGLE"
+		}
+		if r.LikelyPosition != want || r.RecentForm != nil || r.IsRequested {
+			t.Errorf("report %d = %+v, want position %q, no recent form and not requested", i, r, want)
+		}
+	}
+	if r := reports[0]; r.ChampionName != "Champion 266" || r.Spells[0] != "Summoner Spell 4" {
+		t.Errorf("report = %+v, want ID fallbacks", r)
+	}
+	for _, call := range server.Calls() {
+		if strings.HasSuffix(call.Path, "/ids") {
+			t.Errorf("match history was requested at %s", call.Path)
+		}
+	}
+}
+
+func TestLiveRecentGamesBudget(t *testing.T) {
+	tests := []struct {
+		name        string
+		appLimit    string
+		players     int
+		recentGames int
+		want        int
+	}{
+		// Each player costs one match list call plus one per game: 100/10 - 1 = 9 games fit
+		{name: "development key", appLimit: riot.DefaultAppRateLimit, players: 10, recentGames: 5, want: 5},
+		{name: "longest window caps", appLimit: "20:1,50:120", players: 10, recentGames: 5, want: 4},
+		{name: "tight limit", appLimit: "30:120", players: 10, recentGames: 3, want: 2},
+		{name: "no room for history", appLimit: "15:120", players: 10, recentGames: 3, want: 0},
+		{name: "no players", appLimit: "15:120", players: 0, recentGames: 3, want: 3},
+		{name: "no games asked", appLimit: "15:120", players: 10, recentGames: 0, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := riot.NewClient("test-key", "europe")
+			client.SetAppRateLimit(tt.appLimit)
+			if got := client.LiveRecentGamesBudget("europe", tt.players, tt.recentGames); got != tt.want {
+				t.Errorf("LiveRecentGamesBudget(%d players, %d games) = %d, want %d", tt.players, tt.recentGames, got, tt.want)
+			}
+		})
+	}
+}
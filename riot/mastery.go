@@ -0,0 +1,67 @@
+package riot
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"lol-ranked-new-meta/types"
+)
+
+// masteryCacheTTL is how long champion-mastery-v4 entries are reused
+const masteryCacheTTL = 30 * time.Minute
+
+// GetChampionMastery fetches a player's mastery on one champion from champion-mastery-v4
+// platform is the platform the player plays on (e.g. euw1, na1); a champion the player never played returns level 0
+func (c *Client) GetChampionMastery(puuid, platform string, championID int) (*types.RiotChampionMastery, error) {
+	platform = strings.ToLower(strings.TrimSpace(platform))
+	if platform == "" {
+		return nil, fmt.Errorf("platform is required for champion-mastery-v4")
+	}
+
+	cacheKey := fmt.Sprintf("%s/%s/%d", platform, puuid, championID)
+	var mastery types.RiotChampionMastery
+	if c.masteryCache.get(cacheKey, &mastery) {
+		return &mastery, nil
+	}
+
+	path := fmt.Sprintf("/lol/champion-mastery/v4/champion-masteries/by-puuid/%s/by-champion/%d", url.PathEscape(puuid), championID)
+	err := c.get(platform, "champion-mastery-v4.getChampionMasteryByPUUID", path, &mastery)
+	if errors.Is(err, ErrNotFound) {
+		// Riot answers 404 when the player has no mastery on the champion yet
+		mastery = types.RiotChampionMastery{Puuid: puuid, ChampionID: championID}
+	} else if err != nil {
+		return nil, err
+	}
+	c.masteryCache.put(cacheKey, &mastery)
+	return &mastery, nil
+}
+
+// FormatChampionMastery describes a mastery entry with a rough experience label for the coach
+// Points are current totals, so they include games played after the analyzed match
+func FormatChampionMastery(mastery *types.RiotChampionMastery) string {
+	if mastery == nil {
+		return ""
+	}
+	if mastery.ChampionPoints == 0 {
+		return "None recorded (likely one of the player's first games on this champion)"
+	}
+	return fmt.Sprintf("Level %d, %d points (%s)", mastery.ChampionLevel, mastery.ChampionPoints, masteryExperience(mastery.ChampionPoints))
+}
+
+// masteryExperience buckets mastery points into an experience label
+// A game is worth very roughly 1000-1500 points, so 10k is a handful of games and 200k+ is hundreds
+func masteryExperience(points int) string {
+	switch {
+	case points < 10000:
+		return "new to this champion"
+	case points < 50000:
+		return "some experience on this champion"
+	case points < 200000:
+		return "experienced on this champion"
+	default:
+		return "very experienced on this champion - likely a main or one-trick"
+	}
+}
@@ -0,0 +1,244 @@
+package riot
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Platform is a League of Legends server and the match-v5 routing region that serves its matches
+type Platform struct {
+	ID      string   // Platform ID used in match IDs, e.g. EUW1
+	Name    string   // e.g. Europe West
+	Routing string   // americas, europe, asia or sea
+	Aliases []string // Short names used by the client and stat sites, lower-case
+}
+
+// Platforms lists every platform served by the Riot API
+var Platforms = []Platform{
+	{ID: "NA1", Name: "North America", Routing: "americas", Aliases: []string{"na"}},
+	{ID: "BR1", Name: "Brazil", Routing: "americas", Aliases: []string{"br"}},
+	{ID: "LA1", Name: "Latin America North", Routing: "americas", Aliases: []string{"lan"}},
+	{ID: "LA2", Name: "Latin America South", Routing: "americas", Aliases: []string{"las"}},
+	{ID: "EUW1", Name: "Europe West", Routing: "europe", Aliases: []string{"euw"}},
+	{ID: "EUN1", Name: "Europe Nordic & East", Routing: "europe", Aliases: []string{"eune", "eun"}},
+	{ID: "TR1", Name: "Turkey", Routing: "europe", Aliases: []string{"tr"}},
+	{ID: "RU", Name: "Russia", Routing: "europe", Aliases: []string{"ru1"}},
+	{ID: "ME1", Name: "Middle East", Routing: "europe", Aliases: []string{"me"}},
+	{ID: "KR", Name: "Korea", Routing: "asia", Aliases: []string{"kr1"}},
+	{ID: "JP1", Name: "Japan", Routing: "asia", Aliases: []string{"jp"}},
+	{ID: "OC1", Name: "Oceania", Routing: "sea", Aliases: []string{"oce", "oc"}},
+	{ID: "PH2", Name: "Philippines", Routing: "sea", Aliases: []string{"ph"}},
+	{ID: "SG2", Name: "Singapore, Malaysia & Indonesia", Routing: "sea", Aliases: []string{"sg"}},
+	{ID: "TH2", Name: "Thailand", Routing: "sea", Aliases: []string{"th"}},
+	{ID: "TW2", Name: "Taiwan, Hong Kong & Macao", Routing: "sea", Aliases: []string{"tw"}},
+	{ID: "VN2", Name: "Vietnam", Routing: "sea", Aliases: []string{"vn"}},
+}
+
+// SupportedMatchFormats describes the match references ParseMatchReference accepts
+var SupportedMatchFormats = []string{
+	"Match ID: EUW1_7123456789 (case-insensitive; EUW_7123456789 and EUW1-7123456789 also work)",
+	"Game ID with a platform: 7123456789 plus platform=EUW1",
+	"Match links that contain the platform and game ID, e.g. https://www.leagueofgraphs.com/match/euw/7123456789",
+}
+
+// MatchReference is a validated match: the canonical match ID and where to fetch it
+type MatchReference struct {
+	MatchID       string // e.g. EUW1_7123456789
+	Platform      string // e.g. EUW1
+	GameID        int64
+	RoutingRegion string // e.g. europe
+}
+
+// MatchReferenceError explains why a match reference could not be parsed
+type MatchReferenceError struct {
+	Input            string
+	Reason           string
+	SupportedFormats []string
+}
+
+func (e *MatchReferenceError) Error() string {
+	return fmt.Sprintf("invalid match reference %q: %s", e.Input, e.Reason)
+}
+
+var (
+	matchIDPattern = regexp.MustCompile(`^([A-Za-z]{2,4}[0-9]?)[_\-: ]([0-9]{6,})$`)
+	gameIDPattern  = regexp.MustCompile(`^[0-9]{6,}$`)
+	tokenSeparator = regexp.MustCompile(`[/#?&=._\-\s]+`)
+)
+
+// LookupPlatform finds a platform by ID or alias (case-insensitive, e.g. "euw1", "EUW" or "oce")
+func LookupPlatform(value string) (Platform, bool) {
+	key := strings.ToLower(strings.TrimSpace(value))
+	if key == "" {
+		return Platform{}, false
+	}
+	for _, p := range Platforms {
+		if strings.ToLower(p.ID) == key {
+			return p, true
+		}
+		for _, alias := range p.Aliases {
+			if alias == key {
+				return p, true
+			}
+		}
+	}
+	return Platform{}, false
+}
+
+// SupportedPlatformIDs returns every platform ID, e.g. for error messages
+func SupportedPlatformIDs() []string {
+	ids := make([]string, len(Platforms))
+	for i, p := range Platforms {
+		ids[i] = p.ID
+	}
+	return ids
+}
+
+// ParseMatchReference turns user input into a canonical match ID
+// input may be a match ID, a bare game ID (platformHint is then required) or a link from the client or a stat site
+func ParseMatchReference(input, platformHint string) (MatchReference, error) {
+	raw := strings.TrimSpace(input)
+	if raw == "" {
+		return MatchReference{}, matchReferenceError(input, "a match ID is required")
+	}
+
+	// Match ID, e.g. EUW1_123 or euw-123
+	if m := matchIDPattern.FindStringSubmatch(raw); m != nil {
+		platform, ok := LookupPlatform(m[1])
+		if !ok {
+			return MatchReference{}, matchReferenceError(input, fmt.Sprintf("unknown platform %q (supported: %s)", m[1], strings.Join(SupportedPlatformIDs(), ", ")))
+		}
+		return newMatchReference(platform, m[2])
+	}
+
+	// Bare game ID, e.g. 7123456789 with platform=EUW1
+	if gameIDPattern.MatchString(raw) {
+		if strings.TrimSpace(platformHint) == "" {
+			return MatchReference{}, matchReferenceError(input, "a bare game ID needs a platform (e.g. platform=EUW1)")
+		}
+		platform, ok := LookupPlatform(platformHint)
+		if !ok {
+			return MatchReference{}, matchReferenceError(input, fmt.Sprintf("unknown platform %q (supported: %s)", platformHint, strings.Join(SupportedPlatformIDs(), ", ")))
+		}
+		return newMatchReference(platform, raw)
+	}
+
+	if strings.Contains(raw, "://") || strings.HasPrefix(strings.ToLower(raw), "www.") {
+		return parseMatchURL(input, raw, platformHint)
+	}
+
+	return MatchReference{}, matchReferenceError(input, "not a match ID, game ID or match link")
+}
+
+// parseMatchURL finds a platform and a game ID in a link
+// Stat sites put both in the path (leagueofgraphs.com/match/euw/123) or the query (?gameId=123&platformId=EUW1).
+// The platform comes from the caller's hint, then the segment after /match/, then a platform query parameter,
+// then the host name. Other segments and query values are ignored: language codes such as ru, tr or kr are aliases too.
+func parseMatchURL(input, raw, platformHint string) (MatchReference, error) {
+	u, err := url.Parse(raw)
+	if err != nil || (u.Host == "" && !strings.HasPrefix(strings.ToLower(raw), "www.")) {
+		return MatchReference{}, matchReferenceError(input, "could not parse the link")
+	}
+
+	text := u.Path + "/" + u.Fragment + "/" + u.RawQuery
+	if unescaped, err := url.PathUnescape(text); err == nil {
+		text = unescaped
+	}
+
+	// A full match ID anywhere in the link is the most reliable signal
+	for _, part := range strings.FieldsFunc(text, func(r rune) bool { return r == '/' || r == '?' || r == '&' || r == '=' || r == '#' }) {
+		if m := matchIDPattern.FindStringSubmatch(part); m != nil {
+			if platform, ok := LookupPlatform(m[1]); ok {
+				return newMatchReference(platform, m[2])
+			}
+		}
+	}
+
+	platform, ok := LookupPlatform(platformHint)
+	if !ok {
+		platform, ok = matchSegmentPlatform(u.Path + "/" + u.Fragment)
+	}
+	if !ok {
+		platform, ok = queryPlatform(u.Query())
+	}
+	if !ok {
+		// Subdomains such as matchhistory.euw.leagueoflegends.com carry the platform too
+		for _, label := range strings.Split(u.Hostname(), ".") {
+			if platform, ok = LookupPlatform(label); ok {
+				break
+			}
+		}
+	}
+
+	for _, token := range tokenSeparator.Split(text, -1) {
+		// Longer numbers are timestamps (e.g. op.gg links), not game IDs
+		if !gameIDPattern.MatchString(token) || len(token) > 12 {
+			continue
+		}
+		if !ok {
+			return MatchReference{}, matchReferenceError(input, "the link has a game ID but no platform; pass platform (e.g. platform=EUW1)")
+		}
+		return newMatchReference(platform, token)
+	}
+
+	return MatchReference{}, matchReferenceError(input, "no game ID found in the link")
+}
+
+// matchSegments are the path segments stat sites put the platform after, e.g. /match/euw/123 or #match-details/EUW1/123
+var matchSegments = []string{"match", "matches", "match-details", "game"}
+
+// platformQueryKeys are the query parameters that hold a platform, e.g. ?gameId=123&platformId=EUW1
+var platformQueryKeys = []string{"platformId", "platform", "region", "server"}
+
+// queryPlatform returns the platform in a platform query parameter; the parameter name is case-insensitive
+func queryPlatform(query url.Values) (Platform, bool) {
+	for _, key := range platformQueryKeys {
+		for name, values := range query {
+			if strings.EqualFold(name, key) && len(values) > 0 {
+				if platform, ok := LookupPlatform(values[0]); ok {
+					return platform, true
+				}
+			}
+		}
+	}
+	return Platform{}, false
+}
+
+// matchSegmentPlatform returns the platform in the segment that follows a match segment
+func matchSegmentPlatform(path string) (Platform, bool) {
+	segments := strings.FieldsFunc(path, func(r rune) bool { return r == '/' })
+	for i := 0; i+1 < len(segments); i++ {
+		for _, name := range matchSegments {
+			if strings.EqualFold(segments[i], name) {
+				if platform, ok := LookupPlatform(segments[i+1]); ok {
+					return platform, true
+				}
+			}
+		}
+	}
+	return Platform{}, false
+}
+
+func newMatchReference(platform Platform, gameID string) (MatchReference, error) {
+	id, err := strconv.ParseInt(gameID, 10, 64)
+	if err != nil {
+		return MatchReference{}, matchReferenceError(gameID, "game ID is out of range")
+	}
+	return MatchReference{
+		MatchID:       fmt.Sprintf("%s_%d", platform.ID, id),
+		Platform:      platform.ID,
+		GameID:        id,
+		RoutingRegion: platform.Routing,
+	}, nil
+}
+
+func matchReferenceError(input, reason string) *MatchReferenceError {
+	return &MatchReferenceError{
+		Input:            input,
+		Reason:           reason,
+		SupportedFormats: SupportedMatchFormats,
+	}
+}
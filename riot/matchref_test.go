@@ -0,0 +1,69 @@
+package riot_test
+
+import (
+	"errors"
+	"testing"
+
+	"lol-ranked-new-meta/riot"
+)
+
+func TestParseMatchReference(t *testing.T) {
+	tests := []struct {
+		input, platform string
+		want            string // Match ID
+		routing         string
+	}{
+		{input: "EUW1_7123456789", want: "EUW1_7123456789", routing: "europe"},
+		{input: "  euw1_7123456789 ", want: "EUW1_7123456789", routing: "europe"},
+		{input: "EUW_7123456789", want: "EUW1_7123456789", routing: "europe"},
+		{input: "na1-4987654321", want: "NA1_4987654321", routing: "americas"},
+		{input: "7123456789", platform: "kr", want: "KR_7123456789", routing: "asia"},
+		{input: "7123456789", platform: "OC1", want: "OC1_7123456789", routing: "sea"},
+		{input: "https://www.leagueofgraphs.com/match/euw/7123456789", want: "EUW1_7123456789", routing: "europe"},
+		{input: "https://example.com/game?gameId=7123456789&platformId=EUN1", want: "EUN1_7123456789", routing: "europe"},
+		{input: "https://example.com/match/BR1_7123456789", want: "BR1_7123456789", routing: "americas"},
+		{input: "https://matchhistory.euw.leagueoflegends.com/en/#match-details/7123456789", want: "EUW1_7123456789", routing: "europe"},
+		// Language segments and query values are not platforms, even when they look like one
+		{input: "https://www.leagueofgraphs.com/ru/match/euw/7123456789", want: "EUW1_7123456789", routing: "europe"},
+		{input: "https://www.leagueofgraphs.com/tr/match/kr/7123456789?hl=ru", want: "KR_7123456789", routing: "asia"},
+		{input: "https://example.com/game?gameId=7123456789&PLATFORMID=tr1&lang=kr", want: "TR1_7123456789", routing: "europe"},
+		// The caller's platform wins over the link
+		{input: "https://www.leagueofgraphs.com/match/euw/7123456789", platform: "EUN1", want: "EUN1_7123456789", routing: "europe"},
+	}
+	for _, tt := range tests {
+		ref, err := riot.ParseMatchReference(tt.input, tt.platform)
+		if err != nil {
+			t.Errorf("ParseMatchReference(%q, %q): %v", tt.input, tt.platform, err)
+			continue
+		}
+		if ref.MatchID != tt.want || ref.RoutingRegion != tt.routing {
+			t.Errorf("ParseMatchReference(%q, %q) = %s (%s), want %s (%s)", tt.input, tt.platform, ref.MatchID, ref.RoutingRegion, tt.want, tt.routing)
+		}
+	}
+}
+
+func TestParseMatchReferenceErrors(t *testing.T) {
+	tests := []struct {
+		input, platform string
+	}{
+		{input: ""},
+		{input: "7123456789"},
+		{input: "7123456789", platform: "atlantis"},
+		{input: "XX9_7123456789"},
+		{input: "not a match"},
+		{input: "https://www.leagueofgraphs.com/match/7123456789"},
+		{input: "https://www.leagueofgraphs.com/match/euw/"},
+		{input: "https://www.leagueofgraphs.com/ru/match/7123456789?lang=tr"},
+	}
+	for _, tt := range tests {
+		_, err := riot.ParseMatchReference(tt.input, tt.platform)
+		var refErr *riot.MatchReferenceError
+		if !errors.As(err, &refErr) {
+			t.Errorf("ParseMatchReference(%q, %q) error = %v, want *riot.MatchReferenceError", tt.input, tt.platform, err)
+			continue
+		}
+		if len(refErr.SupportedFormats) == 0 {
+			t.Errorf("ParseMatchReference(%q, %q) error lists no supported formats", tt.input, tt.platform)
+		}
+	}
+}
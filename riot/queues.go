@@ -0,0 +1,172 @@
+package riot
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"lol-ranked-new-meta/staticdata"
+	"lol-ranked-new-meta/types"
+)
+
+// Map IDs used by match-v5
+const (
+	mapSummonersRift = 11
+	mapHowlingAbyss  = 12
+	mapNexusBlitz    = 21
+	mapRingsOfWrath  = 30
+)
+
+var mapNames = map[int]string{
+	mapSummonersRift: "Summoner's Rift",
+	mapHowlingAbyss:  "Howling Abyss",
+	mapNexusBlitz:    "Nexus Blitz",
+	mapRingsOfWrath:  "Rings of Wrath",
+}
+
+// queueEntry is a known queue in the registry
+type queueEntry struct {
+	name   string
+	format string
+	ranked bool
+}
+
+// queues maps queue IDs to their names and formats
+// See https://static.developer.riotgames.com/docs/lol/queues.json
+var queues = map[int]queueEntry{
+	400:  {name: "Normal Draft", format: types.FormatSummonersRift},
+	420:  {name: "Ranked Solo/Duo", format: types.FormatSummonersRift, ranked: true},
+	430:  {name: "Normal Blind", format: types.FormatSummonersRift},
+	440:  {name: "Ranked Flex", format: types.FormatSummonersRift, ranked: true},
+	450:  {name: "ARAM", format: types.FormatARAM},
+	480:  {name: "Swiftplay", format: types.FormatSummonersRift},
+	490:  {name: "Quickplay", format: types.FormatSummonersRift},
+	700:  {name: "Clash", format: types.FormatSummonersRift},
+	720:  {name: "ARAM Clash", format: types.FormatARAM},
+	830:  {name: "Co-op vs AI (Intro)", format: types.FormatSummonersRift},
+	840:  {name: "Co-op vs AI (Beginner)", format: types.FormatSummonersRift},
+	850:  {name: "Co-op vs AI (Intermediate)", format: types.FormatSummonersRift},
+	870:  {name: "Co-op vs AI (Intro)", format: types.FormatSummonersRift},
+	880:  {name: "Co-op vs AI (Beginner)", format: types.FormatSummonersRift},
+	890:  {name: "Co-op vs AI (Intermediate)", format: types.FormatSummonersRift},
+	900:  {name: "ARURF", format: types.FormatRotating},
+	1020: {name: "One for All", format: types.FormatRotating},
+	1300: {name: "Nexus Blitz", format: types.FormatRotating},
+	1400: {name: "Ultimate Spellbook", format: types.FormatRotating},
+	1700: {name: "Arena", format: types.FormatArena},
+	1710: {name: "Arena", format: types.FormatArena},
+	1900: {name: "URF", format: types.FormatRotating},
+}
+
+// LookupQueue describes a match's queue and map
+// Unknown queues and custom games (queue 0) fall back to the map and game mode
+func LookupQueue(queueID, mapID int, gameMode string) types.QueueInfo {
+	info := types.QueueInfo{
+		QueueID: queueID,
+		MapID:   mapID,
+		MapName: mapNames[mapID],
+	}
+	if info.MapName == "" {
+		info.MapName = fmt.Sprintf("Map %d", mapID)
+	}
+
+	if entry, ok := queues[queueID]; ok {
+		info.Name = entry.name
+		info.Format = entry.format
+		info.Ranked = entry.ranked
+	} else {
+		info.Custom = queueID == 0
+		info.Format = formatFromMap(mapID, gameMode)
+		switch {
+		case info.Custom:
+			info.Name = "Custom Game"
+		case gameMode != "":
+			info.Name = fmt.Sprintf("%s (Queue %d)", gameMode, queueID)
+		default:
+			info.Name = fmt.Sprintf("Queue %d", queueID)
+		}
+	}
+
+	// Custom games rarely have reliable positions, so only matchmade rift games get lane analysis
+	info.HasLanes = info.Format == types.FormatSummonersRift && !info.Custom
+	return info
+}
+
+// MatchQueue describes the queue and map of a match
+func MatchQueue(match *types.RiotMatch) types.QueueInfo {
+	if match == nil {
+		return types.QueueInfo{}
+	}
+	return LookupQueue(match.Info.QueueID, match.Info.MapID, match.Info.GameMode)
+}
+
+// formatFromMap guesses the format of a queue missing from the registry
+func formatFromMap(mapID int, gameMode string) string {
+	switch {
+	case gameMode == "ARAM" || mapID == mapHowlingAbyss:
+		return types.FormatARAM
+	case gameMode == "CHERRY" || mapID == mapRingsOfWrath:
+		return types.FormatArena
+	case gameMode == "CLASSIC" && mapID == mapSummonersRift:
+		return types.FormatSummonersRift
+	case mapID == mapSummonersRift && gameMode == "":
+		return types.FormatSummonersRift
+	default:
+		return types.FormatRotating
+	}
+}
+
+// participantTeamLabel names the side a participant played on in lang: Blue/Red, or their duo in Arena
+func participantTeamLabel(queue types.QueueInfo, p *types.RiotParticipant, lang Language) string {
+	if queue.Format == types.FormatArena && p.PlayerSubteamID > 0 {
+		return lang.sprintf("Duo %d", p.PlayerSubteamID)
+	}
+	return lang.label(teamLabel(p.TeamID))
+}
+
+// participantResult describes a participant's result in lang: Won/Lost, or their placement in Arena
+func participantResult(queue types.QueueInfo, p *types.RiotParticipant, lang Language) string {
+	if queue.Format == types.FormatArena && p.Placement > 0 {
+		return lang.sprintf("%s place", lang.ordinal(p.Placement))
+	}
+	if p.Win {
+		return lang.label("Won")
+	}
+	return lang.label("Lost")
+}
+
+// FormatArenaStandings lists Arena duos from first to last place with labels in lang
+// static is optional - it adds localized champion names
+func FormatArenaStandings(match *types.RiotMatch, static *staticdata.Bundle, lang Language) string {
+	duos := make(map[int][]types.RiotParticipant)
+	placements := make(map[int]int)
+	var subteams []int
+	for _, p := range match.Info.Participants {
+		if _, ok := duos[p.PlayerSubteamID]; !ok {
+			subteams = append(subteams, p.PlayerSubteamID)
+		}
+		duos[p.PlayerSubteamID] = append(duos[p.PlayerSubteamID], p)
+		placement := p.SubteamPlacement
+		if placement == 0 {
+			placement = p.Placement
+		}
+		placements[p.PlayerSubteamID] = placement
+	}
+	sort.Slice(subteams, func(i, j int) bool {
+		return placements[subteams[i]] < placements[subteams[j]]
+	})
+
+	var standings string
+	for _, subteam := range subteams {
+		var members []string
+		kills, deaths := 0, 0
+		for _, p := range duos[subteam] {
+			members = append(members, fmt.Sprintf("%s (%s)", p.SummonerName, championLabel(static, &p)))
+			kills += p.Kills
+			deaths += p.Deaths
+		}
+		standings += lang.sprintf("- %s: Duo %d - %s - %d kills, %d deaths",
+			lang.ordinal(placements[subteam]), subteam, strings.Join(members, " + "), kills, deaths) + "\n"
+	}
+	return standings
+}
@@ -0,0 +1,241 @@
+package riot
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultAppRateLimit is the development key limit, used until Riot reports the real one in response headers
+const DefaultAppRateLimit = "20:1,100:120"
+
+// RateLimiter schedules Riot API requests so they stay inside the application and method rate limits
+// Limits are tracked per routing host (americas, europe, euw1, ...) because Riot enforces them per region
+type RateLimiter struct {
+	mu         sync.Mutex
+	buckets    map[string]*rateBucket
+	defaultApp string
+}
+
+// rateBucket tracks one set of rate limit windows (e.g. the app limit for europe)
+type rateBucket struct {
+	spec         string
+	windows      []*rateWindow
+	blockedUntil time.Time
+}
+
+// rateWindow is a single "limit:seconds" window
+type rateWindow struct {
+	limit  int
+	period time.Duration
+	hits   []time.Time
+}
+
+// NewRateLimiter creates a rate limiter that assumes appLimit (e.g. "20:1,100:120") until headers say otherwise
+func NewRateLimiter(appLimit string) *RateLimiter {
+	if strings.TrimSpace(appLimit) == "" {
+		appLimit = DefaultAppRateLimit
+	}
+	return &RateLimiter{
+		buckets:    make(map[string]*rateBucket),
+		defaultApp: appLimit,
+	}
+}
+
+// Wait blocks until a request to method on host fits in both the app and the method limits, then reserves it
+func (l *RateLimiter) Wait(host, method string) {
+	for {
+		l.mu.Lock()
+		now := time.Now()
+		app := l.bucket(appBucketKey(host), l.defaultApp)
+		methodBucket := l.bucket(methodBucketKey(host, method), "")
+
+		wait := app.waitTime(now)
+		if methodWait := methodBucket.waitTime(now); methodWait > wait {
+			wait = methodWait
+		}
+		if wait <= 0 {
+			app.record(now)
+			methodBucket.record(now)
+			l.mu.Unlock()
+			return
+		}
+		l.mu.Unlock()
+
+		time.Sleep(wait)
+	}
+}
+
+// Remaining returns how many more requests the app limit on host allows before one has to wait out its longest window
+// ok is false when no app limit is known
+func (l *RateLimiter) Remaining(host string) (remaining int, ok bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	var longest *rateWindow
+	for _, w := range l.bucket(appBucketKey(host), l.defaultApp).windows {
+		if longest == nil || w.period > longest.period {
+			longest = w
+		}
+	}
+	if longest == nil {
+		return 0, false
+	}
+	longest.prune(now)
+	if remaining = longest.limit - len(longest.hits); remaining < 0 {
+		remaining = 0
+	}
+	return remaining, true
+}
+
+// Update applies the limits and counts Riot reported in a response
+func (l *RateLimiter) Update(host, method string, header http.Header) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	if spec := header.Get("X-App-Rate-Limit"); spec != "" {
+		l.bucket(appBucketKey(host), l.defaultApp).update(spec, header.Get("X-App-Rate-Limit-Count"), now)
+	}
+	if spec := header.Get("X-Method-Rate-Limit"); spec != "" {
+		l.bucket(methodBucketKey(host, method), "").update(spec, header.Get("X-Method-Rate-Limit-Count"), now)
+	}
+}
+
+// Block stops all requests covered by limitType (X-Rate-Limit-Type: application, method or service) until retryAfter has passed
+func (l *RateLimiter) Block(host, method, limitType string, retryAfter time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	key, spec := methodBucketKey(host, method), ""
+	if strings.EqualFold(limitType, "application") {
+		key, spec = appBucketKey(host), l.defaultApp
+	}
+	bucket := l.bucket(key, spec)
+	until := time.Now().Add(retryAfter)
+	if until.After(bucket.blockedUntil) {
+		bucket.blockedUntil = until
+	}
+}
+
+// bucket returns the bucket for key, creating it with spec if it does not exist yet
+func (l *RateLimiter) bucket(key, spec string) *rateBucket {
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &rateBucket{}
+		b.update(spec, "", time.Now())
+		l.buckets[key] = b
+	}
+	return b
+}
+
+func appBucketKey(host string) string {
+	return host + "|app"
+}
+
+func methodBucketKey(host, method string) string {
+	return host + "|method|" + method
+}
+
+// waitTime returns how long to wait before another request fits in every window
+func (b *rateBucket) waitTime(now time.Time) time.Duration {
+	var wait time.Duration
+	if now.Before(b.blockedUntil) {
+		wait = b.blockedUntil.Sub(now)
+	}
+	for _, w := range b.windows {
+		w.prune(now)
+		if len(w.hits) >= w.limit {
+			if d := w.hits[0].Add(w.period).Sub(now); d > wait {
+				wait = d
+			}
+		}
+	}
+	return wait
+}
+
+func (b *rateBucket) record(now time.Time) {
+	for _, w := range b.windows {
+		w.hits = append(w.hits, now)
+	}
+}
+
+// update replaces the windows when the limit spec changes and catches up with counts made by other clients sharing the key
+func (b *rateBucket) update(spec, counts string, now time.Time) {
+	if spec != b.spec {
+		windows := parseRateLimits(spec)
+		for _, w := range windows {
+			for _, old := range b.windows {
+				if old.period == w.period {
+					w.hits = old.hits
+				}
+			}
+		}
+		b.spec = spec
+		b.windows = windows
+	}
+
+	for _, c := range parseRateLimits(counts) {
+		for _, w := range b.windows {
+			if w.period != c.period {
+				continue
+			}
+			w.prune(now)
+			// c.limit holds the count Riot has seen in this window
+			for len(w.hits) < c.limit {
+				w.hits = append(w.hits, now)
+			}
+		}
+	}
+}
+
+func (w *rateWindow) prune(now time.Time) {
+	cutoff := now.Add(-w.period)
+	i := 0
+	for i < len(w.hits) && !w.hits[i].After(cutoff) {
+		i++
+	}
+	w.hits = w.hits[i:]
+}
+
+// parseRateLimits parses Riot's "limit:seconds,limit:seconds" header format
+func parseRateLimits(spec string) []*rateWindow {
+	var windows []*rateWindow
+	for _, part := range strings.Split(spec, ",") {
+		pieces := strings.Split(strings.TrimSpace(part), ":")
+		if len(pieces) != 2 {
+			continue
+		}
+		limit, err := strconv.Atoi(pieces[0])
+		if err != nil || limit <= 0 {
+			continue
+		}
+		seconds, err := strconv.Atoi(pieces[1])
+		if err != nil || seconds <= 0 {
+			continue
+		}
+		windows = append(windows, &rateWindow{limit: limit, period: time.Duration(seconds) * time.Second})
+	}
+	return windows
+}
+
+// retryAfter reads the Retry-After header (in seconds), returning 0 if it is missing
+func retryAfter(header http.Header) time.Duration {
+	seconds, err := strconv.Atoi(strings.TrimSpace(header.Get("Retry-After")))
+	if err != nil || seconds < 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// backoff returns the exponential delay before retry attempt n (0-based)
+func backoff(attempt int) time.Duration {
+	delay := retryBaseDelay << attempt
+	if delay > maxRetryWait {
+		delay = maxRetryWait
+	}
+	return delay
+}
@@ -0,0 +1,103 @@
+package riot_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"lol-ranked-new-meta/riot"
+)
+
+// timeWait returns how long limiter.Wait(host, method) blocked
+func timeWait(limiter *riot.RateLimiter, host, method string) time.Duration {
+	start := time.Now()
+	limiter.Wait(host, method)
+	return time.Since(start)
+}
+
+func TestRateLimiterWait(t *testing.T) {
+	limiter := riot.NewRateLimiter("2:1,5:120")
+	for i := 0; i < 2; i++ {
+		if d := timeWait(limiter, "europe", "match-v5.getMatch"); d > 100*time.Millisecond {
+			t.Fatalf("request %d waited %s, want none inside the limit", i+1, d)
+		}
+	}
+	if remaining, ok := limiter.Remaining("europe"); !ok || remaining != 3 {
+		t.Errorf("Remaining = %d, %v, want 3 of the 120 s window", remaining, ok)
+	}
+
+	// The third request in the same second waits for the 1 s window; other hosts have their own limits
+	if d := timeWait(limiter, "americas", "match-v5.getMatch"); d > 100*time.Millisecond {
+		t.Errorf("another host waited %s, want none", d)
+	}
+	if d := timeWait(limiter, "europe", "match-v5.getMatch"); d < 800*time.Millisecond {
+		t.Errorf("third request waited %s, want about 1 s", d)
+	}
+}
+
+func TestRateLimiterUpdate(t *testing.T) {
+	limiter := riot.NewRateLimiter("")
+	if remaining, ok := limiter.Remaining("europe"); !ok || remaining != 100 {
+		t.Fatalf("Remaining = %d, %v, want the development key's 100", remaining, ok)
+	}
+
+	// Counts from other clients sharing the key are caught up with
+	limiter.Update("europe", "match-v5.getMatch", http.Header{
+		"X-App-Rate-Limit":       {"10:120"},
+		"X-App-Rate-Limit-Count": {"7:120"},
+	})
+	if remaining, _ := limiter.Remaining("europe"); remaining != 3 {
+		t.Errorf("Remaining = %d after 7 of 10 reported, want 3", remaining)
+	}
+
+	// A new limit keeps the requests already made in windows of the same length
+	limiter.Update("europe", "match-v5.getMatch", http.Header{"X-App-Rate-Limit": {"20:120"}})
+	if remaining, _ := limiter.Remaining("europe"); remaining != 13 {
+		t.Errorf("Remaining = %d after raising the limit to 20, want 13", remaining)
+	}
+
+	// A reported method limit throttles that method only
+	limiter.Update("europe", "match-v5.getMatch", http.Header{
+		"X-Method-Rate-Limit":       {"1:1"},
+		"X-Method-Rate-Limit-Count": {"1:1"},
+	})
+	if d := timeWait(limiter, "europe", "league-v4.getLeagueEntriesByPUUID"); d > 100*time.Millisecond {
+		t.Errorf("another method waited %s, want none", d)
+	}
+	if d := timeWait(limiter, "europe", "match-v5.getMatch"); d < 800*time.Millisecond {
+		t.Errorf("throttled method waited %s, want about 1 s", d)
+	}
+}
+
+func TestRateLimiterBlock(t *testing.T) {
+	const block = 300 * time.Millisecond
+	tests := []struct {
+		limitType   string
+		otherMethod bool // Whether another method on the host is held back too
+	}{
+		{limitType: "application", otherMethod: true},
+		{limitType: "method", otherMethod: false},
+		// Service limits are per endpoint on Riot's side, so they must not stall the whole app
+		{limitType: "service", otherMethod: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.limitType, func(t *testing.T) {
+			limiter := riot.NewRateLimiter("")
+			limiter.Block("europe", "match-v5.getMatch", tt.limitType, block)
+
+			if d := timeWait(limiter, "americas", "match-v5.getMatch"); d > 100*time.Millisecond {
+				t.Errorf("another host waited %s, want none", d)
+			}
+			d := timeWait(limiter, "europe", "league-v4.getLeagueEntriesByPUUID")
+			if blocked := d > block/2; blocked != tt.otherMethod {
+				t.Errorf("another method waited %s, want blocked = %v", d, tt.otherMethod)
+			}
+			if tt.otherMethod {
+				return
+			}
+			if d := timeWait(limiter, "europe", "match-v5.getMatch"); d < block/2 {
+				t.Errorf("blocked method waited %s, want about %s", d, block)
+			}
+		})
+	}
+}
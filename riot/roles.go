@@ -0,0 +1,214 @@
+package riot
+
+import (
+	"sort"
+	"strings"
+
+	"lol-ranked-new-meta/metrics"
+	"lol-ranked-new-meta/types"
+)
+
+// supportItems are the support quest items (World Atlas line and the older Spellthief/Relic/Coin lines)
+var supportItems = map[int]bool{
+	3850: true, 3851: true, 3853: true, 3854: true, 3855: true, 3857: true, 3858: true, 3859: true, 3860: true, 3862: true, 3863: true, 3864: true,
+	3865: true, 3866: true, 3867: true, 3869: true, 3870: true, 3871: true, 3876: true, 3877: true,
+}
+
+// ResolveRoles assigns a lane position to every participant in a mode with lanes, keyed by participant ID
+// Signals are tried from most to least reliable: teamPosition, individualPosition, lane/role, Smite,
+// a support item, CS patterns and finally participant order. Each position is used at most once per team.
+// Returns nil for modes without lanes.
+func ResolveRoles(match *types.RiotMatch) map[int]types.RoleAssignment {
+	if match == nil || !MatchQueue(match).HasLanes {
+		return nil
+	}
+
+	teams := make(map[int][]*types.RiotParticipant)
+	var teamIDs []int
+	for i := range match.Info.Participants {
+		p := &match.Info.Participants[i]
+		if _, ok := teams[p.TeamID]; !ok {
+			teamIDs = append(teamIDs, p.TeamID)
+		}
+		teams[p.TeamID] = append(teams[p.TeamID], p)
+	}
+
+	roles := make(map[int]types.RoleAssignment)
+	for _, teamID := range teamIDs {
+		for id, role := range resolveTeamRoles(teams[teamID]) {
+			roles[id] = role
+		}
+	}
+	return roles
+}
+
+// resolveTeamRoles assigns positions within one team
+func resolveTeamRoles(players []*types.RiotParticipant) map[int]types.RoleAssignment {
+	roles := make(map[int]types.RoleAssignment)
+	taken := make(map[string]bool)
+	assign := func(p *types.RiotParticipant, position, source, confidence string) bool {
+		if position == "" || taken[position] {
+			return false
+		}
+		if _, done := roles[p.ParticipantID]; done {
+			return false
+		}
+		taken[position] = true
+		roles[p.ParticipantID] = types.RoleAssignment{
+			ParticipantID: p.ParticipantID,
+			Position:      position,
+			Source:        source,
+			Confidence:    confidence,
+		}
+		return true
+	}
+
+	// Riot's own signals, in order of reliability; a position claimed by a better signal wins
+	for _, p := range players {
+		assign(p, normalizePosition(p.TeamPosition), "team_position", types.ConfidenceHigh)
+	}
+	for _, p := range players {
+		assign(p, normalizePosition(p.IndividualPosition), "individual_position", types.ConfidenceMedium)
+	}
+	for _, p := range players {
+		assign(p, laneRolePosition(p.Lane, p.Role), "lane_role", types.ConfidenceMedium)
+	}
+
+	// Item and spell signals
+	for _, p := range players {
+		if p.Summoner1ID == smiteSpellID || p.Summoner2ID == smiteSpellID {
+			assign(p, types.PositionJungle, "smite", types.ConfidenceMedium)
+		}
+	}
+	for _, p := range players {
+		if hasSupportItem(p) {
+			assign(p, types.PositionUtility, "support_item", types.ConfidenceMedium)
+		}
+	}
+
+	// CS patterns: the most jungle monsters means jungle, the least total CS means support
+	unresolved := func() []*types.RiotParticipant {
+		var rest []*types.RiotParticipant
+		for _, p := range players {
+			if _, ok := roles[p.ParticipantID]; !ok {
+				rest = append(rest, p)
+			}
+		}
+		return rest
+	}
+	if rest := unresolved(); len(rest) > 1 {
+		sort.SliceStable(rest, func(i, j int) bool { return rest[i].NeutralMinionsKilled > rest[j].NeutralMinionsKilled })
+		if rest[0].NeutralMinionsKilled > 2*rest[0].TotalMinionsKilled {
+			assign(rest[0], types.PositionJungle, "cs_pattern", types.ConfidenceLow)
+		}
+	}
+	if rest := unresolved(); len(rest) > 1 {
+		sort.SliceStable(rest, func(i, j int) bool { return metrics.CreepScore(rest[i]) < metrics.CreepScore(rest[j]) })
+		if metrics.CreepScore(rest[0])*2 < metrics.CreepScore(rest[1]) {
+			assign(rest[0], types.PositionUtility, "cs_pattern", types.ConfidenceLow)
+		}
+	}
+
+	// Whatever is left follows the usual participant order, which matches positionOrder (top, jungle, mid, bottom, support)
+	rest := unresolved()
+	sort.SliceStable(rest, func(i, j int) bool { return rest[i].ParticipantID < rest[j].ParticipantID })
+	for _, p := range rest {
+		for _, position := range positionOrder {
+			if assign(p, position, "participant_order", types.ConfidenceLow) {
+				break
+			}
+		}
+	}
+	return roles
+}
+
+// FindLaneOpponent returns the enemy resolved to the target's position
+// ok is false in modes without lanes or when no enemy shares the target's position
+func FindLaneOpponent(match *types.RiotMatch, roles map[int]types.RoleAssignment, target *types.RiotParticipant) (*types.RiotParticipant, types.LaneOpponent, bool) {
+	if match == nil || target == nil {
+		return nil, types.LaneOpponent{}, false
+	}
+	targetRole, ok := roles[target.ParticipantID]
+	if !ok || targetRole.Position == "" {
+		return nil, types.LaneOpponent{}, false
+	}
+	for i := range match.Info.Participants {
+		p := &match.Info.Participants[i]
+		if p.TeamID == target.TeamID {
+			continue
+		}
+		role, ok := roles[p.ParticipantID]
+		if !ok || role.Position != targetRole.Position {
+			continue
+		}
+		return p, types.LaneOpponent{
+			SummonerName: p.SummonerName,
+			ChampionName: p.ChampionName,
+			Position:     role.Position,
+			Confidence:   lowerConfidence(targetRole.Confidence, role.Confidence),
+		}, true
+	}
+	return nil, types.LaneOpponent{}, false
+}
+
+// ResolvedPosition returns the participant's resolved position, falling back to teamPosition
+func ResolvedPosition(roles map[int]types.RoleAssignment, p *types.RiotParticipant) string {
+	if role, ok := roles[p.ParticipantID]; ok {
+		return role.Position
+	}
+	return p.TeamPosition
+}
+
+// normalizePosition maps position spellings to the teamPosition values; "Invalid" and unknown values return ""
+func normalizePosition(value string) string {
+	switch strings.ToUpper(strings.TrimSpace(value)) {
+	case "TOP":
+		return types.PositionTop
+	case "JUNGLE":
+		return types.PositionJungle
+	case "MIDDLE", "MID":
+		return types.PositionMiddle
+	case "BOTTOM", "BOT", "ADC", "CARRY":
+		return types.PositionBottom
+	case "UTILITY", "SUPPORT":
+		return types.PositionUtility
+	default:
+		return ""
+	}
+}
+
+// laneRolePosition combines the legacy lane and role fields; bottom lane needs the role to tell carry from support
+func laneRolePosition(lane, role string) string {
+	lane = strings.ToUpper(strings.TrimSpace(lane))
+	role = strings.ToUpper(strings.TrimSpace(role))
+	switch lane {
+	case "TOP", "JUNGLE", "MIDDLE", "MID":
+		return normalizePosition(lane)
+	case "BOTTOM", "BOT":
+		switch role {
+		case "DUO_CARRY", "CARRY":
+			return types.PositionBottom
+		case "DUO_SUPPORT", "SUPPORT":
+			return types.PositionUtility
+		}
+	}
+	return ""
+}
+
+func hasSupportItem(p *types.RiotParticipant) bool {
+	for _, itemID := range []int{p.Item0, p.Item1, p.Item2, p.Item3, p.Item4, p.Item5, p.Item6} {
+		if supportItems[itemID] {
+			return true
+		}
+	}
+	return false
+}
+
+// lowerConfidence returns the less certain of two confidence levels
+func lowerConfidence(a, b string) string {
+	rank := map[string]int{types.ConfidenceHigh: 2, types.ConfidenceMedium: 1, types.ConfidenceLow: 0}
+	if rank[a] <= rank[b] {
+		return a
+	}
+	return b
+}
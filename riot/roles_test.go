@@ -0,0 +1,86 @@
+package riot_test
+
+import (
+	"testing"
+
+	"lol-ranked-new-meta/riot"
+	"lol-ranked-new-meta/riottest"
+	"lol-ranked-new-meta/types"
+)
+
+func TestResolveRoles(t *testing.T) {
+	match := riottest.FixtureMatch()
+	roles := riot.ResolveRoles(match)
+	if len(roles) != 10 {
+		t.Fatalf("resolved %d roles, want 10", len(roles))
+	}
+	for _, p := range match.Info.Participants {
+		role := roles[p.ParticipantID]
+		if role.Position != p.TeamPosition || role.Source != "team_position" || role.Confidence != types.ConfidenceHigh {
+			t.Errorf("%s: got %+v, want %s from team_position with high confidence", p.ChampionName, role, p.TeamPosition)
+		}
+	}
+}
+
+func TestResolveRolesFallbacks(t *testing.T) {
+	match := riottest.FixtureMatch()
+	// Strip Riot's position signals from the blue team; spells and CS must place the players
+	for i := range match.Info.Participants {
+		p := &match.Info.Participants[i]
+		if p.TeamID == 100 {
+			p.TeamPosition, p.IndividualPosition, p.Lane, p.Role = "", "", "", ""
+		}
+	}
+	match.Info.Participants[0].IndividualPosition = "TOP"
+
+	roles := riot.ResolveRoles(match)
+	tests := []struct {
+		participantID int
+		position      string
+		source        string
+	}{
+		{1, types.PositionTop, "individual_position"},
+		{2, types.PositionJungle, "smite"},
+		{3, types.PositionMiddle, ""},
+		{4, types.PositionBottom, ""},
+		{5, types.PositionUtility, ""},
+	}
+	for _, tt := range tests {
+		role := roles[tt.participantID]
+		if role.Position != tt.position || (tt.source != "" && role.Source != tt.source) {
+			t.Errorf("participant %d: got %+v, want %s (source %q)", tt.participantID, role, tt.position, tt.source)
+		}
+	}
+	// The red team keeps its teamPosition
+	if role := roles[6]; role.Source != "team_position" {
+		t.Errorf("participant 6: got %+v, want team_position", role)
+	}
+}
+
+func TestResolveRolesWithoutLanes(t *testing.T) {
+	match := riottest.FixtureMatch()
+	match.Info.QueueID = 450 // ARAM
+	if roles := riot.ResolveRoles(match); roles != nil {
+		t.Errorf("ARAM roles = %v, want nil", roles)
+	}
+	if _, _, ok := riot.FindLaneOpponent(match, nil, &match.Info.Participants[0]); ok {
+		t.Error("ARAM has a lane opponent")
+	}
+}
+
+func TestFindLaneOpponent(t *testing.T) {
+	match := riottest.FixtureMatch()
+	target := &match.Info.Participants[0]
+
+	opponent, lane, ok := riot.FindLaneOpponent(match, riot.ResolveRoles(match), target)
+	if !ok || opponent.ChampionName != "Darius" || lane.Position != types.PositionTop || lane.Confidence != types.ConfidenceHigh {
+		t.Fatalf("got %+v (ok %t), want Darius at TOP with high confidence", lane, ok)
+	}
+
+	// The opponent's confidence is the lower of the two players'
+	target.TeamPosition = ""
+	_, lane, ok = riot.FindLaneOpponent(match, riot.ResolveRoles(match), target)
+	if !ok || lane.ChampionName != "Darius" || lane.Confidence != types.ConfidenceMedium {
+		t.Errorf("without teamPosition got %+v (ok %t), want Darius with medium confidence", lane, ok)
+	}
+}
@@ -0,0 +1,142 @@
+package riot
+
+import (
+	"fmt"
+	"strings"
+
+	"lol-ranked-new-meta/staticdata"
+	"lol-ranked-new-meta/types"
+)
+
+// runeValueLabels names the var1-var3 values match-v5 records for each rune
+// Runes not listed here have their non-zero values shown as "Value 1" .. "Value 3"
+var runeValueLabels = map[int][]string{
+	// Precision
+	8005: {"Total Damage", "Bonus Damage", "Expose Damage"}, // Press the Attack
+	8008: {"Time at Max Stacks (s)"},                        // Lethal Tempo
+	8021: {"Total Healing"},                                 // Fleet Footwork
+	8010: {"Total Healing"},                                 // Conqueror
+	9101: {"Total Healing"},                                 // Overheal/Absorb Life
+	9111: {"Total Healing", "Bonus Gold"},                   // Triumph
+	8009: {"Resource Restored"},                             // Presence of Mind
+	9104: {"Time Completed (s)", "Bonus Stacks"},            // Legend: Alacrity
+	9105: {"Time Completed (s)", "Bonus Stacks"},            // Legend: Haste / Tenacity
+	9103: {"Time Completed (s)", "Bonus Stacks"},            // Legend: Bloodline
+	8014: {"Total Bonus Damage"},                            // Coup de Grace
+	8017: {"Total Bonus Damage"},                            // Cut Down
+	8299: {"Total Bonus Damage"},                            // Last Stand
+	// Domination
+	8112: {"Total Damage"},                    // Electrocute
+	8128: {"Total Damage", "Souls Collected"}, // Dark Harvest
+	9923: {"Total Bonus Attacks"},             // Hail of Blades
+	8126: {"Total Bonus Damage"},              // Cheap Shot
+	8139: {"Total Healing"},                   // Taste of Blood
+	8143: {"Total Bonus Damage"},              // Sudden Impact
+	// Sorcery
+	8214: {"Total Damage", "Total Shielding"}, // Summon Aery
+	8229: {"Total Damage"},                    // Arcane Comet
+	8230: {"Activations"},                     // Phase Rush
+	8226: {"Mana Restored"},                   // Manaflow Band
+	8233: {"Total Bonus Damage"},              // Absolute Focus
+	8237: {"Total Bonus Damage"},              // Scorch
+	// Resolve
+	8437: {"Total Damage", "Total Healing"},           // Grasp of the Undying
+	8439: {"Total Damage Mitigated", "Total Damage"},  // Aftershock
+	8465: {"Total Shielding"},                         // Guardian
+	8444: {"Total Healing"},                           // Second Wind
+	8446: {"Total Damage"},                            // Demolish
+	8453: {"Total Healing", "Total Healing Received"}, // Revitalize
+	8463: {"Total Healing"},                           // Font of Life
+	// Inspiration
+	8369: {"Total Damage", "Gold Earned"}, // First Strike
+	8360: {"Summoner Swaps"},              // Unsealed Spellbook
+	8351: {"Total Bonus Damage"},          // Glacial Augment
+}
+
+// BuildRunePage resolves a participant's rune page to names and labelled values
+// static may be nil, in which case runes are listed by ID. Returns nil when the participant has no rune page (e.g. Arena).
+func BuildRunePage(participant *types.RiotParticipant, static *staticdata.Bundle) *types.RunePage {
+	if participant == nil || len(participant.Perks.Styles) == 0 {
+		return nil
+	}
+
+	page := &types.RunePage{}
+	for _, style := range participant.Perks.Styles {
+		styleName := static.RuneStyleName(style.Style)
+		switch style.Description {
+		case "primaryStyle":
+			page.PrimaryStyle = styleName
+		case "subStyle":
+			page.SecondaryStyle = styleName
+		}
+		for i, selection := range style.Selections {
+			choice := types.RuneChoice{
+				ID:     selection.Perk,
+				Name:   static.RuneName(selection.Perk),
+				Style:  styleName,
+				Values: runeValues(selection),
+			}
+			if r, ok := static.Rune(selection.Perk); ok {
+				choice.Keystone = r.IsKeystone()
+			} else {
+				// Without static data the first primary selection is the keystone
+				choice.Keystone = style.Description == "primaryStyle" && i == 0
+			}
+			if choice.Keystone {
+				page.Keystone = choice.Name
+			}
+			page.Runes = append(page.Runes, choice)
+		}
+	}
+
+	shards := participant.Perks.StatPerks
+	for _, id := range []int{shards.Offense, shards.Flex, shards.Defense} {
+		if id != 0 {
+			page.StatShards = append(page.StatShards, static.RuneName(id))
+		}
+	}
+	return page
+}
+
+// runeValues labels a selection's non-zero var1-var3 values
+func runeValues(selection types.RiotPerkSelection) []types.StatPair {
+	labels := runeValueLabels[selection.Perk]
+	var values []types.StatPair
+	for i, value := range []int{selection.Var1, selection.Var2, selection.Var3} {
+		if value == 0 {
+			continue
+		}
+		label := fmt.Sprintf("Value %d", i+1)
+		if i < len(labels) {
+			label = labels[i]
+		}
+		values = append(values, types.StatPair{Label: label, Value: fmt.Sprintf("%d", value)})
+	}
+	return values
+}
+
+// FormatRunePage lists the rune page for the deep dive; the page structure is labelled in lang, rune values keep the game's labels
+func FormatRunePage(page *types.RunePage, lang Language) string {
+	if page == nil {
+		return ""
+	}
+
+	var result string
+	result += lang.sprintf("- Primary: %s (Keystone: %s), Secondary: %s", page.PrimaryStyle, page.Keystone, page.SecondaryStyle) + "\n"
+	for _, choice := range page.Runes {
+		line := fmt.Sprintf("- %s (%s)", choice.Name, choice.Style)
+		if choice.Keystone {
+			line += " [" + lang.label("KEYSTONE") + "]"
+		}
+		if len(choice.Values) > 0 {
+			var values []string
+			for _, v := range choice.Values {
+				values = append(values, fmt.Sprintf("%s: %s", v.Label, v.Value))
+			}
+			line += " - " + strings.Join(values, ", ")
+		}
+		result += line + "\n"
+	}
+	result += lang.sprintf("- Stat Shards: %s", lang.label(joinOrNone(page.StatShards))) + "\n"
+	return result
+}
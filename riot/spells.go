@@ -0,0 +1,228 @@
+package riot
+
+import (
+	"fmt"
+	"strings"
+
+	"lol-ranked-new-meta/staticdata"
+	"lol-ranked-new-meta/types"
+)
+
+// Summoner spell IDs the spell analysis calls out (Smite is smiteSpellID in live.go)
+const (
+	flashSpellID    = 4
+	teleportSpellID = 12 // Also Unleashed Teleport
+	igniteSpellID   = 14
+)
+
+// knownSpellNames names the Summoner's Rift spells when Data Dragon data is unavailable
+var knownSpellNames = map[int]string{
+	1:               "Cleanse",
+	3:               "Exhaust",
+	6:               "Ghost",
+	7:               "Heal",
+	21:              "Barrier",
+	flashSpellID:    "Flash",
+	smiteSpellID:    "Smite",
+	teleportSpellID: "Teleport",
+	igniteSpellID:   "Ignite",
+}
+
+// igniteSpellName is Ignite's spellName in timeline damage recaps
+const igniteSpellName = "summonerdot"
+
+// SpellUsage is one of a participant's summoner spells and how often it was cast
+type SpellUsage struct {
+	SpellID        int
+	Label          string // e.g. "Flash (ID 4)"
+	Casts          int
+	CastsPerMinute float64
+	MaxCasts       int // Casts possible at base cooldown over the whole game; 0 without static data
+}
+
+// SpellUsages returns the participant's two summoner spells with their cast rates
+// static is optional - it adds spell names and the base-cooldown maximum
+func SpellUsages(p *types.RiotParticipant, gameDuration int64, static *staticdata.Bundle) []SpellUsage {
+	if p == nil {
+		return nil
+	}
+	minutes := float64(gameDuration) / 60.0
+	var usages []SpellUsage
+	for _, spell := range [][2]int{{p.Summoner1ID, p.Summoner1Casts}, {p.Summoner2ID, p.Summoner2Casts}} {
+		if spell[0] == 0 {
+			continue
+		}
+		usage := SpellUsage{SpellID: spell[0], Label: spellLabel(static, spell[0]), Casts: spell[1]}
+		if minutes > 0 {
+			usage.CastsPerMinute = float64(spell[1]) / minutes
+		}
+		// The spell is up at the start, then once per cooldown; haste and runes only raise this
+		if s, ok := static.SummonerSpell(spell[0]); ok && s.Cooldown > 0 {
+			usage.MaxCasts = 1 + int(float64(gameDuration)/s.Cooldown)
+		}
+		usages = append(usages, usage)
+	}
+	return usages
+}
+
+// findSpell returns the usage of the given spell, if the participant took it
+func findSpell(usages []SpellUsage, spellID int) (SpellUsage, bool) {
+	for _, u := range usages {
+		if u.SpellID == spellID {
+			return u, true
+		}
+	}
+	return SpellUsage{}, false
+}
+
+// IgniteKills counts champion kills whose victim took Ignite damage from the participant
+// ok is false when the timeline has no damage recaps to tell
+func IgniteKills(timeline *types.RiotMatchTimeline, participantID int) (int, bool) {
+	if timeline == nil {
+		return 0, false
+	}
+	kills, recaps := 0, false
+	for _, frame := range timeline.Info.Frames {
+		for _, event := range frame.Events {
+			if event.Type != "CHAMPION_KILL" || len(event.VictimDamageReceived) == 0 {
+				continue
+			}
+			recaps = true
+			for _, d := range event.VictimDamageReceived {
+				if d.ParticipantID == participantID && strings.EqualFold(d.SpellName, igniteSpellName) {
+					kills++
+					break
+				}
+			}
+		}
+	}
+	return kills, recaps
+}
+
+// formatSpellUsage formats one spell in lang as e.g. "Flash (ID 4): Used 5 times (0.16/min, at most 7 at base cooldown)"
+func formatSpellUsage(u SpellUsage, lang Language) string {
+	line := lang.sprintf("%s: Used %d times (%.2f/min", u.Label, u.Casts, u.CastsPerMinute)
+	if u.MaxCasts > 0 {
+		line += lang.sprintf(", at most %d at base cooldown", u.MaxCasts)
+	}
+	return line + ")"
+}
+
+// FormatSpellComparison compares the target's summoner spell usage with their lane opponent's, with labels in lang
+// Calls out Flash counts, Ignite kill conversion (needs timeline damage recaps) and Teleport usage
+func FormatSpellComparison(match *types.RiotMatch, target *types.RiotParticipant, extras *MatchExtras, lang Language) string {
+	if match == nil || target == nil {
+		return ""
+	}
+	static := extras.static()
+	yours := SpellUsages(target, match.Info.GameDuration, static)
+	if len(yours) == 0 {
+		return ""
+	}
+
+	var section string
+	opponent, _, hasOpponent := FindLaneOpponent(match, extras.roles(match), target)
+	var theirs []SpellUsage
+	if hasOpponent {
+		theirs = SpellUsages(opponent, match.Info.GameDuration, static)
+		section += lang.sprintf("Lane Opponent %s (%s):", opponent.SummonerName, championLabel(static, opponent)) + "\n"
+		for _, u := range theirs {
+			section += "- " + formatSpellUsage(u, lang) + "\n"
+		}
+		section += "\n"
+	}
+
+	var economy string
+	flash, hasFlash := findSpell(yours, flashSpellID)
+	theirFlash, theirHasFlash := findSpell(theirs, flashSpellID)
+	switch {
+	case hasFlash && theirHasFlash:
+		economy += lang.sprintf("- %s: %d casts vs %d by %s", spellName(static, flashSpellID), flash.Casts, theirFlash.Casts, championLabel(static, opponent)) + "\n"
+	case hasFlash:
+		economy += lang.sprintf("- %s: %d casts", spellName(static, flashSpellID), flash.Casts) + "\n"
+	}
+
+	if ignite, ok := findSpell(yours, igniteSpellID); ok {
+		if kills, known := IgniteKills(extras.timeline(), target.ParticipantID); known {
+			economy += lang.sprintf("- %s Kill Conversion: %d of %d casts hit a champion who then died (%s)",
+				spellName(static, igniteSpellID), kills, ignite.Casts, percentOf(kills, ignite.Casts)) + "\n"
+		} else {
+			economy += lang.sprintf("- %s: %d casts; kill conversion needs timeline damage data", spellName(static, igniteSpellID), ignite.Casts) + "\n"
+		}
+	}
+
+	teleport, hasTeleport := findSpell(yours, teleportSpellID)
+	theirTeleport, theirHasTeleport := findSpell(theirs, teleportSpellID)
+	switch {
+	case hasTeleport && theirHasTeleport:
+		economy += lang.sprintf("- %s: %d casts vs %d by %s", spellName(static, teleportSpellID), teleport.Casts, theirTeleport.Casts, championLabel(static, opponent)) + "\n"
+	case hasTeleport:
+		economy += lang.sprintf("- %s: %d casts", spellName(static, teleportSpellID), teleport.Casts) + "\n"
+	case theirHasTeleport:
+		economy += lang.sprintf("- %s: %s used it %d times; %s did not take it", spellName(static, teleportSpellID),
+			championLabel(static, opponent), theirTeleport.Casts, championLabel(static, target)) + "\n"
+	}
+
+	if economy == "" {
+		return section
+	}
+	section += lang.label("Spell Economy") + ":\n" + economy
+	return section
+}
+
+// SpellStatPairs summarizes summoner spell economy for KeyStatistics
+// They are computed from the match data, not by the LLM
+func SpellStatPairs(match *types.RiotMatch, target *types.RiotParticipant, extras *MatchExtras) []types.StatPair {
+	if match == nil || target == nil {
+		return nil
+	}
+	static := extras.static()
+	opponent, _, hasOpponent := FindLaneOpponent(match, extras.roles(match), target)
+	var theirs []SpellUsage
+	if hasOpponent {
+		theirs = SpellUsages(opponent, match.Info.GameDuration, static)
+	}
+
+	var stats []types.StatPair
+	for _, u := range SpellUsages(target, match.Info.GameDuration, static) {
+		name := spellName(static, u.SpellID)
+		context := fmt.Sprintf("%.2f/min", u.CastsPerMinute)
+		if u.MaxCasts > 0 {
+			context += fmt.Sprintf(", at most %d at base cooldown", u.MaxCasts)
+		}
+		if their, ok := findSpell(theirs, u.SpellID); ok {
+			context += fmt.Sprintf("; %s used it %d times", opponent.ChampionName, their.Casts)
+		}
+		stats = append(stats, types.StatPair{Label: name + " Casts", Value: fmt.Sprintf("%d", u.Casts), Context: context})
+
+		if u.SpellID == igniteSpellID {
+			if kills, known := IgniteKills(extras.timeline(), target.ParticipantID); known {
+				stats = append(stats, types.StatPair{
+					Label:   name + " Kill Conversion",
+					Value:   percentOf(kills, u.Casts),
+					Context: fmt.Sprintf("%d of %d casts hit a champion who then died", kills, u.Casts),
+				})
+			}
+		}
+	}
+	return stats
+}
+
+// spellName returns the spell's display name, falling back to the known spells and then the ID
+func spellName(static *staticdata.Bundle, spellID int) string {
+	if s, ok := static.SummonerSpell(spellID); ok {
+		return s.Name
+	}
+	if name, ok := knownSpellNames[spellID]; ok {
+		return name
+	}
+	return fmt.Sprintf("Summoner Spell %d", spellID)
+}
+
+// percentOf formats part/whole as a percentage, or "n/a" when whole is zero
+func percentOf(part, whole int) string {
+	if whole <= 0 {
+		return "n/a"
+	}
+	return fmt.Sprintf("%.0f%%", float64(part)/float64(whole)*100)
+}
@@ -0,0 +1,58 @@
+package riot_test
+
+import (
+	"testing"
+
+	"lol-ranked-new-meta/riot"
+	"lol-ranked-new-meta/riottest"
+)
+
+func TestIgniteKills(t *testing.T) {
+	timeline := riottest.FixtureTimeline()
+	tests := []struct {
+		participantID int
+		want          int
+	}{
+		{1, 0}, // Flash + Teleport
+		{3, 1}, // Ahri
+		{5, 1}, // Thresh
+		{10, 0},
+	}
+	for _, tt := range tests {
+		kills, ok := riot.IgniteKills(timeline, tt.participantID)
+		if !ok || kills != tt.want {
+			t.Errorf("IgniteKills(participant %d) = %d, %t, want %d, true", tt.participantID, kills, ok, tt.want)
+		}
+	}
+}
+
+func TestIgniteKillsWithoutRecaps(t *testing.T) {
+	if _, ok := riot.IgniteKills(nil, 3); ok {
+		t.Error("IgniteKills(nil) reported damage recaps")
+	}
+
+	timeline := riottest.FixtureTimeline()
+	for i := range timeline.Info.Frames {
+		for j := range timeline.Info.Frames[i].Events {
+			timeline.Info.Frames[i].Events[j].VictimDamageReceived = nil
+		}
+	}
+	if kills, ok := riot.IgniteKills(timeline, 3); ok || kills != 0 {
+		t.Errorf("IgniteKills without recaps = %d, %t, want 0, false", kills, ok)
+	}
+}
+
+func TestSpellUsagesWithoutStaticData(t *testing.T) {
+	match := riottest.FixtureMatch()
+	usages := riot.SpellUsages(&match.Info.Participants[0], match.Info.GameDuration, nil)
+	if len(usages) != 2 {
+		t.Fatalf("got %d spells, want 2", len(usages))
+	}
+	// Known spells are named without Data Dragon; the base-cooldown maximum needs it
+	if usages[0].Label != "Flash (ID 4)" || usages[1].Label != "Teleport (ID 12)" {
+		t.Errorf("labels = %q, %q, want Flash (ID 4) and Teleport (ID 12)", usages[0].Label, usages[1].Label)
+	}
+	if usages[0].Casts != 4 || usages[0].MaxCasts != 0 {
+		t.Errorf("Flash = %d casts, max %d, want 4 and 0", usages[0].Casts, usages[0].MaxCasts)
+	}
+}
@@ -0,0 +1,337 @@
+package riot
+
+import (
+	"fmt"
+	"strings"
+
+	"lol-ranked-new-meta/metrics"
+	"lol-ranked-new-meta/types"
+)
+
+// Verbosity selects how much detail the match summary includes
+type Verbosity int
+
+const (
+	VerbosityCompact  Verbosity = iota // Scoreboard, target stats and lane matchup only
+	VerbosityStandard                  // Adds team comparison, draft, ranks, item and event timelines
+	VerbosityFull                      // Adds derived metrics for every participant
+)
+
+// ParseVerbosity parses "compact", "standard" or "full" (case-insensitive)
+func ParseVerbosity(value string) (Verbosity, bool) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "compact":
+		return VerbosityCompact, true
+	case "standard":
+		return VerbosityStandard, true
+	case "full":
+		return VerbosityFull, true
+	default:
+		return VerbosityFull, false
+	}
+}
+
+func (v Verbosity) String() string {
+	switch v {
+	case VerbosityCompact:
+		return "compact"
+	case VerbosityStandard:
+		return "standard"
+	default:
+		return "full"
+	}
+}
+
+// Summary formats: how the scoreboard, derived metrics and team aggregates are encoded
+const (
+	SummaryFormatText = "text" // Prose lists
+	SummaryFormatJSON = "json" // One compact JSON object (see BuildCompactMatch); the remaining sections stay prose
+)
+
+// SummaryOptions controls the size and encoding of the match summary
+type SummaryOptions struct {
+	Verbosity   Verbosity
+	TokenBudget int      // Estimated tokens the summary may use; 0 = unlimited
+	Format      string   // SummaryFormatText (default) or SummaryFormatJSON
+	FocusAreas  []string // Opt-in sections, e.g. types.FocusCommunication
+	Language    Language // Labels and section headings; the zero value is English
+}
+
+// EstimateTokens roughly estimates the tokens text uses with OpenAI tokenizers (about 4 characters per token)
+func EstimateTokens(text string) int {
+	return (len(text) + 3) / 4
+}
+
+// Section priorities: required sections are never trimmed; optional ones are trimmed lowest priority first
+const (
+	priorityRequired = 0
+	priorityLow      = 1
+	priorityMedium   = 2
+	priorityHigh     = 3
+)
+
+// summarySection is one block of the summary
+type summarySection struct {
+	name     string // Used for the === NAME === heading and the omitted-sections note; empty for raw blocks
+	body     string
+	priority int
+}
+
+// summaryBuilder collects summary sections for a verbosity level and trims them to a token budget
+type summaryBuilder struct {
+	opts     SummaryOptions
+	sections []summarySection
+}
+
+// raw adds a block without a heading that is always kept
+func (b *summaryBuilder) raw(body string) {
+	b.sections = append(b.sections, summarySection{body: body, priority: priorityRequired})
+}
+
+// section adds a headed block if the verbosity includes level; empty bodies are skipped
+func (b *summaryBuilder) section(name, body string, level Verbosity, priority int) {
+	if body == "" || level > b.opts.Verbosity {
+		return
+	}
+	b.sections = append(b.sections, summarySection{name: name, body: body, priority: priority})
+}
+
+// String renders the sections, dropping optional ones (lowest priority, then last added) until the budget fits
+// Dropped sections are named at the end so the model knows the data exists but was left out
+func (b *summaryBuilder) String() string {
+	kept := make([]bool, len(b.sections))
+	for i := range kept {
+		kept[i] = true
+	}
+	var omitted []string
+
+	for b.opts.TokenBudget > 0 && EstimateTokens(b.render(kept, omitted)) > b.opts.TokenBudget {
+		drop := -1
+		for i, s := range b.sections {
+			if !kept[i] || s.priority == priorityRequired {
+				continue
+			}
+			if drop == -1 || s.priority <= b.sections[drop].priority {
+				drop = i
+			}
+		}
+		if drop == -1 {
+			break // Only required sections are left
+		}
+		kept[drop] = false
+		omitted = append(omitted, b.opts.Language.heading(b.sections[drop].name))
+	}
+	return b.render(kept, omitted)
+}
+
+func (b *summaryBuilder) render(kept []bool, omitted []string) string {
+	var sb strings.Builder
+	for i, s := range b.sections {
+		if !kept[i] {
+			continue
+		}
+		if s.name != "" {
+			sb.WriteString("\n=== " + b.opts.Language.heading(s.name) + " ===\n")
+		}
+		sb.WriteString(s.body)
+	}
+	if len(omitted) > 0 {
+		sb.WriteString(b.opts.Language.sprintf("- Omitted to fit the token budget: %s. Do not guess their contents.", strings.Join(omitted, ", ")) + "\n")
+	}
+	return sb.String()
+}
+
+// BuildMatchSummary converts Riot match data into a summary for OpenAI analysis at the given verbosity and token budget
+// extras is optional; championFilter and summonerFilter select the deep dive target
+func BuildMatchSummary(match *types.RiotMatch, extras *MatchExtras, championFilter, summonerFilter string, opts SummaryOptions) string {
+	if match == nil {
+		return ""
+	}
+	b := &summaryBuilder{opts: opts}
+	queue := MatchQueue(match)
+
+	// Roles are resolved once and shared by every section
+	if extras == nil || extras.Roles == nil {
+		resolved := MatchExtras{}
+		if extras != nil {
+			resolved = *extras
+		}
+		resolved.Roles = ResolveRoles(match)
+		extras = &resolved
+	}
+	roles := extras.Roles
+	lang := opts.Language
+
+	var header string
+	header += fmt.Sprintf("%s: %s\n", lang.label("Match ID"), match.Metadata.MatchID)
+	header += fmt.Sprintf("%s: %s\n", lang.label("Game Mode"), match.Info.GameMode)
+	header += fmt.Sprintf("%s: %d %s (%.2f %s)\n", lang.label("Game Duration"), match.Info.GameDuration, lang.label("seconds"),
+		float64(match.Info.GameDuration)/60.0, lang.label("minutes"))
+	header += fmt.Sprintf("%s: %s\n", lang.label("Game Version"), match.Info.GameVersion)
+	header += fmt.Sprintf("%s: %s on %s (format: %s)\n\n", lang.label("Queue"), queue.Name, queue.MapName, queue.Format)
+	jsonFormat := opts.Format == SummaryFormatJSON
+
+	// Team summaries; Arena has eight duos ranked by placement instead of two teams
+	// The JSON format carries team aggregates itself, but not Arena placements
+	if queue.Format == types.FormatArena {
+		header += lang.label("Duo Standings") + ":\n"
+		header += FormatArenaStandings(match, extras.static(), lang)
+	} else if !jsonFormat {
+		header += lang.label("Teams") + ":\n"
+		for _, team := range match.Info.Teams {
+			result := lang.label("Lost")
+			if team.Win {
+				result = lang.label("Won")
+			}
+			if queue.Format == types.FormatSummonersRift {
+				header += lang.sprintf("- Team %s (%s): %d turrets destroyed, %d dragons, %d barons",
+					lang.label(teamLabel(team.TeamID)), result, team.Objectives.Tower.Kills, team.Objectives.Dragon.Kills, team.Objectives.Baron.Kills) + "\n"
+			} else {
+				header += lang.sprintf("- Team %s (%s): %d turrets destroyed, %d champion kills",
+					lang.label(teamLabel(team.TeamID)), result, team.Objectives.Tower.Kills, team.Objectives.Champion.Kills) + "\n"
+			}
+		}
+	}
+	b.raw(header)
+	if !jsonFormat {
+		b.section("TEAM COMPARISON", FormatTeamComparison(metrics.CompareTeams(match), queue, lang), VerbosityStandard, priorityHigh)
+	}
+
+	participants := "\n" + lang.label("Participants") + ":\n"
+	var targetParticipant *types.RiotParticipant
+	filterProvided := strings.TrimSpace(championFilter) != "" || strings.TrimSpace(summonerFilter) != ""
+	filterMatched := false
+	for i := range match.Info.Participants {
+		participant := match.Info.Participants[i]
+		teamName := participantTeamLabel(queue, &participant, lang)
+		result := participantResult(queue, &participant, lang)
+
+		// Check if this is the target participant for deep dive
+		isTarget := false
+		if championFilter != "" && matchesFilter(participant.ChampionName, championFilter) {
+			isTarget = true
+			targetParticipant = &match.Info.Participants[i]
+			filterMatched = true
+		}
+		if summonerFilter != "" && matchesFilter(participant.SummonerName, summonerFilter) {
+			isTarget = true
+			targetParticipant = &match.Info.Participants[i]
+			filterMatched = true
+		}
+
+		marker := ""
+		if isTarget {
+			marker = " [" + lang.heading("TARGET FOR DEEP DIVE") + "]"
+		}
+
+		participants += lang.sprintf("- %s (%s, %s, %s)%s: K/D/A: %d/%d/%d, CS: %d, Gold: %d, Damage: %d",
+			participant.SummonerName,
+			championLabel(extras.static(), &participant),
+			teamName,
+			result,
+			marker,
+			participant.Kills,
+			participant.Deaths,
+			participant.Assists,
+			metrics.CreepScore(&participant),
+			participant.GoldEarned,
+			participant.TotalDamageDealtToChampions,
+		) + "\n"
+	}
+	if jsonFormat {
+		// The JSON carries the scoreboard, derived metrics and team aggregates the text format lists separately
+		data := lang.label("MATCH DATA (compact JSON):") + "\n" + FormatCompactMatch(BuildCompactMatch(match, roles, targetParticipant, opts.Verbosity))
+		if queue.Format == types.FormatArena {
+			data = "\n" + data
+		}
+		b.raw(data)
+	} else {
+		b.raw(participants)
+	}
+
+	targetTeamID := 0
+	if targetParticipant != nil {
+		targetTeamID = targetParticipant.TeamID
+	}
+	b.section("DRAFT", FormatDraft(BuildDraft(match, roles, extras.static(), targetParticipant), targetTeamID, lang), VerbosityStandard, priorityMedium)
+
+	// Standard lists team metrics and the target's; full lists every participant
+	switch {
+	case jsonFormat:
+	case opts.Verbosity >= VerbosityFull || targetParticipant == nil:
+		b.section("DERIVED METRICS", formatDerivedMetrics(match, queue, nil, extras.static(), lang), VerbosityStandard, priorityHigh)
+	default:
+		b.section("DERIVED METRICS", formatDerivedMetrics(match, queue, targetParticipant, extras.static(), lang), VerbosityStandard, priorityHigh)
+	}
+
+	if ranks := extras.ranks(); len(ranks) > 0 {
+		b.section("LOBBY RANKS", FormatLobbyRanks(ranks, extras.static(), lang), VerbosityStandard, priorityLow)
+	}
+
+	// If we have a target participant, add detailed stats
+	if targetParticipant != nil {
+		b.section("DETAILED STATS FOR TARGET PLAYER", FormatParticipantDeepDive(match, targetParticipant, extras, lang), VerbosityCompact, priorityRequired)
+		b.section("OPPONENT COMPOSITION", FormatOpponentComposition(match, roles, targetParticipant, extras.static(), lang), VerbosityCompact, priorityRequired)
+		b.section("ITEM BUILD TIMELINE", FormatItemBuildTimeline(targetParticipant, extras, match.Info.GameDuration, lang), VerbosityStandard, priorityMedium)
+		b.section("SUMMONER SPELL USAGE", FormatSpellComparison(match, targetParticipant, extras, lang), VerbosityStandard, priorityMedium)
+	}
+
+	if findings := extras.findings(); len(findings) > 0 {
+		b.section("RULE-BASED FINDINGS", FormatRuleFindings(findings, lang), VerbosityCompact, priorityHigh)
+	}
+
+	if HasFocusArea(opts.FocusAreas, types.FocusCommunication) {
+		b.section("COMMUNICATION", FormatCommunication(match, BuildCommunicationProfiles(match), targetParticipant, extras.static(), lang), VerbosityCompact, priorityHigh)
+	}
+
+	timeline := extras.timeline()
+	if timeline != nil {
+		b.section("TIMELINE HIGHLIGHTS", FormatTimelineHighlights(match, roles, timeline, targetParticipant, extras.static(), lang), VerbosityStandard, priorityMedium)
+	}
+
+	if filterProvided && !filterMatched {
+		b.raw("\n" + lang.label("NOTE: No participant matched the provided champion/summoner filter. Deep dive details may be limited.") + "\n")
+	}
+
+	limitations := "\n" + lang.heading("DATA LIMITATIONS") + ":\n"
+	if timeline == nil {
+		limitations += lang.label("- No event timeline or objective timestamps are available in this summary.") + "\n"
+		limitations += lang.label("- Exact item purchase times are not included.") + "\n"
+	} else {
+		limitations += lang.label("- Timestamps above come from the Riot match timeline (mm:ss game time).") + "\n"
+	}
+	if opts.Verbosity >= VerbosityStandard {
+		limitations += lang.label("- Derived metrics are computed exactly from the match data; cite them rather than estimating.") + "\n"
+	}
+	if static := extras.static(); static == nil {
+		limitations += lang.label("- Item and summoner spell names are not included (IDs only).") + "\n"
+	} else if static.MatchesGamePatch() {
+		limitations += lang.sprintf("- Item, rune and summoner spell names come from Data Dragon patch %s.", static.Version) + "\n"
+	} else {
+		limitations += lang.sprintf("- The match was played on patch %s, but item, rune and summoner spell names and descriptions come from Data Dragon patch %s; items and runes changed since then may be described as they are now.", static.GamePatch, static.Version) + "\n"
+	}
+	if queue.Format != types.FormatArena && opts.Verbosity >= VerbosityStandard {
+		limitations += lang.label("- Draft pick order is not available, only ban order; role-targeted bans are inferred from champion class.") + "\n"
+	}
+	if len(extras.ranks()) > 0 {
+		limitations += lang.label("- Lobby ranks are current standings (fetched now), not the ranks at the time of the match.") + "\n"
+	}
+	if extras.mastery() != nil && targetParticipant != nil {
+		limitations += lang.label("- Champion mastery is the player's current total, including any games played after this match.") + "\n"
+	}
+	switch queue.Format {
+	case types.FormatARAM:
+		limitations += lang.label("- This is an ARAM game: there are no lanes, lane opponents, jungle or vision objectives.") + "\n"
+	case types.FormatArena:
+		limitations += lang.label("- This is an Arena game: duos are ranked by placement; there are no lanes, minions or objectives. Augment names are not available.") + "\n"
+	case types.FormatRotating:
+		limitations += lang.sprintf("- This is a featured mode (%s); Summoner's Rift benchmarks may not apply.", queue.Name) + "\n"
+	}
+	if opts.Verbosity < VerbosityFull {
+		limitations += lang.sprintf("- This is a %s summary; some sections are left out at this verbosity.", opts.Verbosity) + "\n"
+	}
+	limitations += lang.label("- Do not infer exact timings unless explicitly provided above.") + "\n"
+	b.raw(limitations)
+
+	return b.String()
+}
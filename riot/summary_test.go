@@ -0,0 +1,103 @@
+package riot_test
+
+import (
+	"strings"
+	"testing"
+
+	"lol-ranked-new-meta/riot"
+	"lol-ranked-new-meta/riottest"
+	"lol-ranked-new-meta/types"
+)
+
+func TestBuildMatchSummaryKillParticipation(t *testing.T) {
+	match := riottest.FixtureMatch()
+	summary := riot.BuildMatchSummary(match, &riot.MatchExtras{Timeline: riottest.FixtureTimeline()}, "Aatrox", "", riot.SummaryOptions{Verbosity: riot.VerbosityFull})
+
+	// Kill participation is cited from the metrics package only, never also from Riot's challenges
+	if n := strings.Count(summary, "Kill Participation:"); n != 1 {
+		t.Errorf("summary cites kill participation %d times, want 1", n)
+	}
+	if !strings.Contains(summary, "- Kill Participation: 41%\n") || !strings.Contains(summary, "KP 41%") {
+		t.Error("the deep dive and derived metrics disagree on Aatrox's kill participation (want 41%)")
+	}
+	if strings.Contains(summary, "Vision Score per Minute") {
+		t.Error("summary still lists the challenge vision score per minute")
+	}
+}
+
+func TestBuildMatchSummaryLocalized(t *testing.T) {
+	match := riottest.FixtureMatch()
+	extras := &riot.MatchExtras{Timeline: riottest.FixtureTimeline()}
+	german, _ := riot.ParseLanguage("de")
+	summary := riot.BuildMatchSummary(match, extras, "Aatrox", "", riot.SummaryOptions{Verbosity: riot.VerbosityFull, Language: german})
+
+	for _, want := range []string{
+		"- Team Blau (Gewonnen): 9 Türme zerstört, 3 Drachen, 1 Barone\n",
+		"- Fenrir (Aatrox, Blau, Gewonnen) [ZIEL DER DETAILANALYSE (TARGET FOR DEEP DIVE)]: K/D/A: 6/3/7, CS: 222, Gold: 13120, Schaden: 24310\n",
+		"Leistungskennzahlen:\n- K/D/A: 6/3/7 (KDA-Verhältnis: 4.33)\n",
+		"Ergebnis: Sieg\n",
+		"- Erstes Blut: Nein\n",
+		"  -> LANE-GEGNER: Aatrox gegen Darius (TOP, Konfidenz high)\n",
+		"Erstes Blut: 03:21 - Ahri (Blau) tötete Syndra\n",
+		"Todeszeitpunkte von Aatrox: 14:05 (getötet von Darius)\n",
+		"DATENEINSCHRÄNKUNGEN (DATA LIMITATIONS):\n",
+		"- Leite keine genauen Zeitpunkte ab, wenn sie oben nicht ausdrücklich angegeben sind.\n",
+	} {
+		if !strings.Contains(summary, want) {
+			t.Errorf("German summary is missing %q", want)
+		}
+	}
+	for _, english := range []string{"turrets destroyed", "Performance Metrics", "Victory", "(You)", "Kill Times", "Do not infer exact timings"} {
+		if strings.Contains(summary, english) {
+			t.Errorf("German summary still contains %q", english)
+		}
+	}
+
+	// The NOTE and omitted-sections lines are localized too
+	summary = riot.BuildMatchSummary(match, extras, "Nobody", "", riot.SummaryOptions{Verbosity: riot.VerbosityFull, TokenBudget: 300, Language: german})
+	for _, want := range []string{"HINWEIS: Kein Teilnehmer passt", "- Wegen des Token-Budgets ausgelassen: "} {
+		if !strings.Contains(summary, want) {
+			t.Errorf("German summary is missing %q:\n%s", want, summary)
+		}
+	}
+
+	// With the de_DE Data Dragon bundle the draft, team comparison, spell and item sections and the champion names are German too
+	extras = &riot.MatchExtras{Timeline: riottest.FixtureTimeline(), Static: fixtureBundle(t, german.Locale)}
+	summary = riot.BuildMatchSummary(match, extras, "Jinx", "", riot.SummaryOptions{Verbosity: riot.VerbosityFull, Language: german})
+	for _, want := range []string{
+		"(Blau gegen Rot, Differenz aus Sicht von Blau)\n",
+		"- Massenkontrolle (Sekunden): 760 gegen 760 (+0)\n",
+		"- Erste: Blut Blau, Turm Blau, Inhibitor Blau, Drache Blau, Herold Blau, Baron Blau\n",
+		"Team Blau (DEIN TEAM):\n- Banns: Yasuo (Zug 1), Zed (Zug 2), Pyke (Zug 3), Kha'Zix (Zug 5)\n",
+		"Gegnerische Banns von Champions aus der Rolle des Ziels (BOTTOM, nach Champion-Klasse): Samira (von Rot), Kai'Sa (von Rot)\n",
+		"- Moss Walker (Lee Sin, Blau, Gewonnen): ",
+		"- Moss Walker (Lee Sin): KB 59%, Schadensanteil 15%",
+		"Lane-Gegner Longshot (Caitlyn):\n- Blitz (ID 4): 4-mal benutzt",
+		"Zauberökonomie:\n- Blitz: 4 Einsätze gegen 4 von Caitlyn\n",
+		"- Item 3: Unendlichkeitsklinge (ID 3031) - zuerst gekauft bei 19:40\n",
+		"Goldeinkommen: 494 Gold/Minute\n",
+	} {
+		if !strings.Contains(summary, want) {
+			t.Errorf("German summary with static data is missing %q", want)
+		}
+	}
+	for _, english := range []string{"differential", "YOUR TEAM", "Composition", "Enemy bans", "Lane Opponent", "Spell Economy", "casts", "Purchase Order", "LeeSin"} {
+		if strings.Contains(summary, english) {
+			t.Errorf("German summary with static data still contains %q", english)
+		}
+	}
+
+	// Every translation keeps its format verbs, including the lobby ranks and communication sections
+	extras.Ranks = []types.PlayerRank{
+		{SummonerName: "Fenrir", ChampionName: "Aatrox", TeamID: 100, SoloDuo: &types.RankedStanding{Tier: "GOLD", Division: "II", LeaguePoints: 40, Wins: 10, Losses: 8}},
+		{SummonerName: "Iron Vow", ChampionName: "Darius", TeamID: 200, Error: "not found"},
+	}
+	for _, lang := range riot.Languages {
+		summary := riot.BuildMatchSummary(match, extras, "Aatrox", "", riot.SummaryOptions{
+			Verbosity: riot.VerbosityFull, Language: lang, FocusAreas: []string{types.FocusCommunication},
+		})
+		if strings.Contains(summary, "%!") {
+			t.Errorf("%s summary has a broken format verb", lang.Name)
+		}
+	}
+}
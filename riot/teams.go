@@ -0,0 +1,49 @@
+package riot
+
+import (
+	"fmt"
+
+	"lol-ranked-new-meta/types"
+)
+
+// FormatTeamComparison lists both teams' totals in lang with the blue-minus-red differential and who took each first
+// Objective lines are only listed on Summoner's Rift
+func FormatTeamComparison(cmp *types.TeamComparison, queue types.QueueInfo, lang Language) string {
+	if cmp == nil || len(cmp.Teams) != 2 {
+		return ""
+	}
+	a, b := cmp.Teams[0], cmp.Teams[1]
+	d := cmp.Differential
+
+	var result string
+	result += lang.sprintf("(%s vs %s, differential from %s's side)",
+		lang.label(teamLabel(a.TeamID)), lang.label(teamLabel(b.TeamID)), lang.label(teamLabel(a.TeamID))) + "\n"
+	line := func(label string, x, y, diff int) {
+		result += fmt.Sprintf("- %s: %d %s %d (%+d)\n", lang.label(label), x, lang.label("vs"), y, diff)
+	}
+	line("Kills", a.Kills, b.Kills, d.Kills)
+	line("Gold Earned", a.GoldEarned, b.GoldEarned, d.GoldEarned)
+	line("Damage to Champions", a.DamageToChampions, b.DamageToChampions, d.DamageToChampions)
+	line("Damage to Buildings", a.DamageToBuildings, b.DamageToBuildings, d.DamageToBuildings)
+	line("Vision Score", a.VisionScore, b.VisionScore, d.VisionScore)
+	line("CC Time (seconds)", a.CCTime, b.CCTime, d.CCTime)
+	line("Turrets", a.Turrets, b.Turrets, d.Turrets)
+	line("Inhibitors", a.Inhibitors, b.Inhibitors, d.Inhibitors)
+	if queue.Format != types.FormatSummonersRift {
+		return result
+	}
+	line("Dragons", a.Dragons, b.Dragons, d.Dragons)
+	line("Rift Heralds", a.Heralds, b.Heralds, d.Heralds)
+	line("Barons", a.Barons, b.Barons, d.Barons)
+
+	first := func(teamID int) string {
+		if teamID == 0 {
+			return lang.label("none")
+		}
+		return lang.label(teamLabel(teamID))
+	}
+	f := cmp.Firsts
+	result += lang.sprintf("- Firsts: Blood %s, Tower %s, Inhibitor %s, Dragon %s, Herald %s, Baron %s",
+		first(f.FirstBlood), first(f.FirstTower), first(f.FirstInhibitor), first(f.FirstDragon), first(f.FirstHerald), first(f.FirstBaron)) + "\n"
+	return result
+}
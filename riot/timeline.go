@@ -0,0 +1,203 @@
+package riot
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"lol-ranked-new-meta/staticdata"
+	"lol-ranked-new-meta/types"
+)
+
+// ItemPurchase is a single item purchase taken from the match timeline
+type ItemPurchase struct {
+	ItemID    int
+	Timestamp int64 // Milliseconds of game time
+}
+
+// ItemPurchases returns the participant's item purchases in order, with undone purchases removed
+func ItemPurchases(timeline *types.RiotMatchTimeline, participantID int) []ItemPurchase {
+	if timeline == nil {
+		return nil
+	}
+
+	var purchases []ItemPurchase
+	for _, frame := range timeline.Info.Frames {
+		for _, event := range frame.Events {
+			if event.ParticipantID != participantID {
+				continue
+			}
+			switch event.Type {
+			case "ITEM_PURCHASED":
+				purchases = append(purchases, ItemPurchase{ItemID: event.ItemID, Timestamp: event.Timestamp})
+			case "ITEM_UNDO":
+				// An undone purchase has BeforeID set to the item that was refunded
+				if event.BeforeID == 0 {
+					continue
+				}
+				for i := len(purchases) - 1; i >= 0; i-- {
+					if purchases[i].ItemID == event.BeforeID {
+						purchases = append(purchases[:i], purchases[i+1:]...)
+						break
+					}
+				}
+			}
+		}
+	}
+	return purchases
+}
+
+// firstPurchase returns the earliest purchase of the given item
+func firstPurchase(purchases []ItemPurchase, itemID int) (ItemPurchase, bool) {
+	for _, p := range purchases {
+		if p.ItemID == itemID {
+			return p, true
+		}
+	}
+	return ItemPurchase{}, false
+}
+
+// FormatGameTime formats milliseconds of game time as mm:ss
+func FormatGameTime(ms int64) string {
+	seconds := ms / 1000
+	return fmt.Sprintf("%02d:%02d", seconds/60, seconds%60)
+}
+
+// teamLabel returns the display name for a team ID
+func teamLabel(teamID int) string {
+	if teamID == 200 {
+		return "Red"
+	}
+	return "Blue"
+}
+
+// FormatTimelineHighlights summarizes objective timings and the target's laning numbers from the match timeline, labelled in lang
+// targetParticipant is optional - without it only match-wide events are listed; static is optional - it adds localized champion names
+func FormatTimelineHighlights(match *types.RiotMatch, roles map[int]types.RoleAssignment, timeline *types.RiotMatchTimeline, targetParticipant *types.RiotParticipant, static *staticdata.Bundle, lang Language) string {
+	if match == nil || timeline == nil {
+		return ""
+	}
+
+	teamByParticipant := make(map[int]int, len(match.Info.Participants))
+	championByParticipant := make(map[int]string, len(match.Info.Participants))
+	for _, p := range match.Info.Participants {
+		teamByParticipant[p.ParticipantID] = p.TeamID
+		championByParticipant[p.ParticipantID] = championLabel(static, &p)
+	}
+
+	var highlights string
+	var objectives []string
+	var targetKills, targetDeaths []string
+	firstBlood := ""
+
+	for _, frame := range timeline.Info.Frames {
+		for _, event := range frame.Events {
+			switch event.Type {
+			case "CHAMPION_KILL":
+				if firstBlood == "" && event.KillerID != 0 {
+					firstBlood = lang.sprintf("%s - %s (%s) killed %s",
+						FormatGameTime(event.Timestamp), championByParticipant[event.KillerID],
+						lang.label(teamLabel(teamByParticipant[event.KillerID])), championByParticipant[event.VictimID])
+				}
+				if targetParticipant == nil {
+					continue
+				}
+				if event.KillerID == targetParticipant.ParticipantID {
+					targetKills = append(targetKills, lang.sprintf("%s (killed %s)", FormatGameTime(event.Timestamp), championByParticipant[event.VictimID]))
+				}
+				if event.VictimID == targetParticipant.ParticipantID {
+					killer := championByParticipant[event.KillerID]
+					if killer == "" {
+						killer = lang.label("minions/turret")
+					}
+					targetDeaths = append(targetDeaths, lang.sprintf("%s (killed by %s)", FormatGameTime(event.Timestamp), killer))
+				}
+			case "ELITE_MONSTER_KILL":
+				monster := event.MonsterType
+				if event.MonsterSubType != "" {
+					monster += " (" + event.MonsterSubType + ")"
+				}
+				objectives = append(objectives, lang.sprintf("%s - %s team took %s",
+					FormatGameTime(event.Timestamp), lang.label(teamLabel(event.KillerTeamID)), monster))
+			case "BUILDING_KILL":
+				// TeamID on a building kill is the team that lost the building
+				building := strings.TrimSpace(event.LaneType + " " + event.TowerType)
+				if event.BuildingType == "INHIBITOR_BUILDING" {
+					building = strings.TrimSpace(event.LaneType + " INHIBITOR")
+				}
+				objectives = append(objectives, lang.sprintf("%s - %s team destroyed %s",
+					FormatGameTime(event.Timestamp), lang.label(teamLabel(otherTeam(event.TeamID))), building))
+			}
+		}
+	}
+
+	if firstBlood != "" {
+		highlights += lang.sprintf("First Blood: %s", firstBlood) + "\n"
+	}
+
+	if len(objectives) > 0 {
+		highlights += "\n" + lang.label("Objective Timeline") + ":\n"
+		for _, o := range objectives {
+			highlights += "- " + o + "\n"
+		}
+	}
+
+	if targetParticipant == nil {
+		return highlights
+	}
+
+	highlights += "\n" + lang.sprintf("%s Kill Times: %s", championLabel(static, targetParticipant), lang.label(joinOrNone(targetKills))) + "\n"
+	highlights += lang.sprintf("%s Death Times: %s", championLabel(static, targetParticipant), lang.label(joinOrNone(targetDeaths))) + "\n"
+
+	// Lane opponents only exist in modes with lanes; elsewhere the numbers are shown without a comparison
+	opponent, _, _ := FindLaneOpponent(match, roles, targetParticipant)
+
+	for _, minute := range []int{10, 15} {
+		frame := frameAt(timeline, int64(minute)*60*1000)
+		if frame == nil {
+			continue
+		}
+		you, ok := frame.ParticipantFrames[strconv.Itoa(targetParticipant.ParticipantID)]
+		if !ok {
+			continue
+		}
+		line := lang.sprintf("At %d:00 - Gold: %d, CS: %d, XP: %d, Level: %d",
+			minute, you.TotalGold, you.MinionsKilled+you.JungleMinionsKilled, you.XP, you.Level)
+		if opponent != nil {
+			if opp, ok := frame.ParticipantFrames[strconv.Itoa(opponent.ParticipantID)]; ok {
+				line += lang.sprintf(" | vs %s - Gold: %d, CS: %d, XP: %d (Gold diff: %+d, CS diff: %+d)",
+					championLabel(static, opponent), opp.TotalGold, opp.MinionsKilled+opp.JungleMinionsKilled, opp.XP,
+					you.TotalGold-opp.TotalGold,
+					(you.MinionsKilled+you.JungleMinionsKilled)-(opp.MinionsKilled+opp.JungleMinionsKilled))
+			}
+		}
+		highlights += line + "\n"
+	}
+
+	return highlights
+}
+
+// frameAt returns the first frame at or after the given game time, or nil if the game ended earlier
+func frameAt(timeline *types.RiotMatchTimeline, ms int64) *types.RiotTimelineFrame {
+	for i := range timeline.Info.Frames {
+		if timeline.Info.Frames[i].Timestamp >= ms {
+			return &timeline.Info.Frames[i]
+		}
+	}
+	return nil
+}
+
+// otherTeam returns the opposing team ID
+func otherTeam(teamID int) int {
+	if teamID == 100 {
+		return 200
+	}
+	return 100
+}
+
+func joinOrNone(values []string) string {
+	if len(values) == 0 {
+		return "none"
+	}
+	return strings.Join(values, ", ")
+}
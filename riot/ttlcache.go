@@ -0,0 +1,62 @@
+package riot
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+// ttlCache keeps Riot responses that change over time (ranks, mastery) for a limited period
+// Values are stored as JSON so callers always get their own copy
+type ttlCache struct {
+	ttl     time.Duration
+	mu      sync.Mutex
+	entries map[string]ttlEntry
+}
+
+type ttlEntry struct {
+	value   []byte
+	expires time.Time
+}
+
+func newTTLCache(ttl time.Duration) *ttlCache {
+	return &ttlCache{
+		ttl:     ttl,
+		entries: make(map[string]ttlEntry),
+	}
+}
+
+// get decodes the cached value for key into out, reporting whether a fresh entry was found
+func (c *ttlCache) get(key string, out interface{}) bool {
+	c.mu.Lock()
+	e, ok := c.entries[key]
+	if ok && time.Now().After(e.expires) {
+		delete(c.entries, key)
+		ok = false
+	}
+	c.mu.Unlock()
+
+	return ok && json.Unmarshal(e.value, out) == nil
+}
+
+// put stores value for key until the TTL expires
+func (c *ttlCache) put(key string, value interface{}) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// Drop expired entries now and then so long-running servers do not grow without bound
+	now := time.Now()
+	if len(c.entries) > 0 && len(c.entries)%500 == 0 {
+		for k, e := range c.entries {
+			if now.After(e.expires) {
+				delete(c.entries, k)
+			}
+		}
+	}
+	c.entries[key] = ttlEntry{value: data, expires: now.Add(c.ttl)}
+}
@@ -0,0 +1,343 @@
+package riottest
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"lol-ranked-new-meta/riot"
+	"lol-ranked-new-meta/staticdata"
+	"lol-ranked-new-meta/types"
+)
+
+// FixtureMatchID is the match ID of the bundled ranked solo/duo fixture (EUW1, patch 14.3)
+const FixtureMatchID = "EUW1_7000000001"
+
+// StaticDataVersion is the Data Dragon version of the bundled static data fixture, matching the fixture match's patch
+const StaticDataVersion = "14.3.1"
+
+//go:embed fixtures/*.json fixtures/ddragon
+var fixtures embed.FS
+
+// Call is a request received by the fake server
+type Call struct {
+	Region string // First path segment of the request, i.e. the {region} of the client's base URL
+	Path   string // Riot API path, e.g. /lol/match/v5/matches/EUW1_1
+	Query  string
+	Token  string // X-Riot-Token header
+}
+
+// Failure is a canned error response for a path
+type Failure struct {
+	Status     int
+	RetryAfter int // Seconds, sent as Retry-After on 429 responses
+	Times      int // Number of requests to fail; 0 fails every request
+}
+
+// Server is a fake Riot API serving fixture JSON, for tests that must run without a live key
+// Point a riot.Client at it with Client() or SetBaseURL(BaseURL())
+type Server struct {
+	*httptest.Server
+
+	mu           sync.Mutex
+	apiKey       string
+	appRateLimit string              // Sent as X-App-Rate-Limit on every response
+	responses    map[string][]byte   // Riot API path -> JSON body
+	failures     map[string]*Failure // Riot API path -> canned error
+	calls        []Call
+}
+
+// NewServer starts an empty fake Riot API server
+// Callers must Close it when done
+func NewServer() *Server {
+	s := &Server{
+		appRateLimit: riot.DefaultAppRateLimit,
+		responses:    make(map[string][]byte),
+		failures:     make(map[string]*Failure),
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	return s
+}
+
+// NewServerWithFixtures starts a fake server preloaded with the bundled match, timeline, league and mastery fixtures
+func NewServerWithFixtures() *Server {
+	s := NewServer()
+	s.AddMatchJSON(FixtureMatchID, mustReadFixture("match.json"))
+	s.AddTimelineJSON(FixtureMatchID, mustReadFixture("timeline.json"))
+
+	var leagues map[string][]types.RiotLeagueEntry
+	if err := json.Unmarshal(mustReadFixture("league.json"), &leagues); err != nil {
+		panic(fmt.Sprintf("riottest: invalid league fixture: %v", err))
+	}
+	for puuid, entries := range leagues {
+		s.AddLeagueEntries(puuid, entries...)
+	}
+
+	var masteries []types.RiotChampionMastery
+	if err := json.Unmarshal(mustReadFixture("mastery.json"), &masteries); err != nil {
+		panic(fmt.Sprintf("riottest: invalid mastery fixture: %v", err))
+	}
+	for _, mastery := range masteries {
+		s.AddChampionMastery(mastery)
+	}
+	return s
+}
+
+// BaseURL returns the base URL to pass to riot.Client.SetBaseURL
+// The region is kept as the first path segment so Calls can report it
+func (s *Server) BaseURL() string {
+	return s.URL + "/{region}"
+}
+
+// Client returns a riot.Client that talks to this server
+func (s *Server) Client(apiKey, region string) *riot.Client {
+	client := riot.NewClient(apiKey, region)
+	client.SetBaseURL(s.BaseURL())
+	client.SetHTTPClient(s.Server.Client())
+	return client
+}
+
+// RequireAPIKey makes the server answer 403 to requests without this X-Riot-Token
+func (s *Server) RequireAPIKey(apiKey string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.apiKey = apiKey
+}
+
+// AddMatch serves match for its match ID
+func (s *Server) AddMatch(match *types.RiotMatch) {
+	s.AddJSON("/lol/match/v5/matches/"+match.Metadata.MatchID, match)
+}
+
+// SetAppRateLimit makes the server report spec (e.g. "30:120") as the app rate limit instead of the development key limit
+func (s *Server) SetAppRateLimit(spec string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.appRateLimit = spec
+}
+
+// AddMatchJSON serves raw match JSON for matchID
+func (s *Server) AddMatchJSON(matchID string, raw []byte) {
+	s.AddRaw("/lol/match/v5/matches/"+matchID, raw)
+}
+
+// AddTimeline serves timeline for its match ID
+func (s *Server) AddTimeline(timeline *types.RiotMatchTimeline) {
+	s.AddJSON("/lol/match/v5/matches/"+timeline.Metadata.MatchID+"/timeline", timeline)
+}
+
+// AddTimelineJSON serves raw timeline JSON for matchID
+func (s *Server) AddTimelineJSON(matchID string, raw []byte) {
+	s.AddRaw("/lol/match/v5/matches/"+matchID+"/timeline", raw)
+}
+
+// AddAccount serves account for its Riot ID, and matchIDs as the account's match history
+func (s *Server) AddAccount(account types.RiotAccount, matchIDs ...string) {
+	s.AddJSON(fmt.Sprintf("/riot/account/v1/accounts/by-riot-id/%s/%s", account.GameName, account.TagLine), account)
+	if matchIDs == nil {
+		matchIDs = []string{}
+	}
+	s.AddJSON(fmt.Sprintf("/lol/match/v5/matches/by-puuid/%s/ids", account.Puuid), matchIDs)
+}
+
+// AddLeagueEntries serves entries as the league-v4 ranked entries for puuid; no entries means unranked
+func (s *Server) AddLeagueEntries(puuid string, entries ...types.RiotLeagueEntry) {
+	if entries == nil {
+		entries = []types.RiotLeagueEntry{}
+	}
+	s.AddJSON("/lol/league/v4/entries/by-puuid/"+puuid, entries)
+}
+
+// AddChampionMastery serves mastery for its player and champion; champions without one answer 404 like Riot does
+func (s *Server) AddChampionMastery(mastery types.RiotChampionMastery) {
+	s.AddJSON(fmt.Sprintf("/lol/champion-mastery/v4/champion-masteries/by-puuid/%s/by-champion/%d", mastery.Puuid, mastery.ChampionID), mastery)
+}
+
+// AddActiveGame serves game as the spectator-v5 active game of every non-bot participant
+func (s *Server) AddActiveGame(game *types.RiotCurrentGameInfo) {
+	for _, p := range game.Participants {
+		if p.Puuid != "" {
+			s.AddJSON("/lol/spectator/v5/active-games/by-summoner/"+p.Puuid, game)
+		}
+	}
+}
+
+// AddJSON serves value encoded as JSON for path
+func (s *Server) AddJSON(path string, value interface{}) {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		panic(fmt.Sprintf("riottest: failed to encode response for %s: %v", path, err))
+	}
+	s.AddRaw(path, raw)
+}
+
+// AddRaw serves raw as the JSON body for path
+func (s *Server) AddRaw(path string, raw []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.responses[path] = raw
+}
+
+// Fail makes requests to path return an error response, e.g. Failure{Status: 429, RetryAfter: 1, Times: 1}
+func (s *Server) Fail(path string, failure Failure) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures[path] = &failure
+}
+
+// FailMatch makes requests for matchID return status
+func (s *Server) FailMatch(matchID string, status int) {
+	s.Fail("/lol/match/v5/matches/"+matchID, Failure{Status: status, RetryAfter: 1})
+}
+
+// Calls returns every request received so far
+func (s *Server) Calls() []Call {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Call(nil), s.calls...)
+}
+
+// CallCount returns how many requests were made to path
+func (s *Server) CallCount(path string) int {
+	count := 0
+	for _, call := range s.Calls() {
+		if call.Path == path {
+			count++
+		}
+	}
+	return count
+}
+
+// FixtureMatch returns the bundled match fixture
+func FixtureMatch() *types.RiotMatch {
+	var match types.RiotMatch
+	if err := json.Unmarshal(mustReadFixture("match.json"), &match); err != nil {
+		panic(fmt.Sprintf("riottest: invalid match fixture: %v", err))
+	}
+	return &match
+}
+
+// FixtureTimeline returns the bundled timeline fixture
+func FixtureTimeline() *types.RiotMatchTimeline {
+	var timeline types.RiotMatchTimeline
+	if err := json.Unmarshal(mustReadFixture("timeline.json"), &timeline); err != nil {
+		panic(fmt.Sprintf("riottest: invalid timeline fixture: %v", err))
+	}
+	return &timeline
+}
+
+// FixtureActiveGame returns an active game built from the bundled match fixture: same players, champions and spells
+func FixtureActiveGame() *types.RiotCurrentGameInfo {
+	match := FixtureMatch()
+	game := &types.RiotCurrentGameInfo{
+		GameID:            match.Info.GameID + 1,
+		GameType:          "MATCHED",
+		MapID:             match.Info.MapID,
+		PlatformID:        match.Info.PlatformID,
+		GameMode:          match.Info.GameMode,
+		GameQueueConfigID: match.Info.QueueID,
+	}
+	for _, team := range match.Info.Teams {
+		for _, ban := range team.Bans {
+			game.BannedChampions = append(game.BannedChampions, types.RiotBannedChampion{ChampionID: ban.ChampionID, TeamID: team.TeamID, PickTurn: ban.PickTurn})
+		}
+	}
+	for _, p := range match.Info.Participants {
+		game.Participants = append(game.Participants, types.RiotCurrentGameParticipant{
+			ChampionID: p.ChampionID,
+			TeamID:     p.TeamID,
+			Puuid:      p.Puuid,
+			RiotID:     p.RiotIDGameName + "#" + p.RiotIDTagline,
+			Spell1ID:   p.Summoner1ID,
+			Spell2ID:   p.Summoner2ID,
+		})
+	}
+	return game
+}
+
+// NewStaticData writes the bundled Data Dragon fixture into dir and returns a service reading it
+// The fixture covers the fixture match's champions, bans, items, spells and runes in en_US, and a partial de_DE
+func NewStaticData(dir string) (*staticdata.Service, error) {
+	err := fs.WalkDir(fixtures, "fixtures/ddragon", func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		target := filepath.Join(dir, strings.TrimPrefix(path, "fixtures/ddragon/"))
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return err
+		}
+		return os.WriteFile(target, mustReadFixture(strings.TrimPrefix(path, "fixtures/")), 0o644)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to write static data fixture: %w", err)
+	}
+	return staticdata.NewService(dir)
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	// Split "/{region}/lol/..." into the region and the Riot API path
+	region, path := "", r.URL.Path
+	if parts := strings.SplitN(strings.TrimPrefix(r.URL.Path, "/"), "/", 2); len(parts) == 2 && parts[0] != "lol" && parts[0] != "riot" {
+		region, path = parts[0], "/"+parts[1]
+	}
+
+	s.mu.Lock()
+	s.calls = append(s.calls, Call{Region: region, Path: path, Query: r.URL.RawQuery, Token: r.Header.Get("X-Riot-Token")})
+	apiKey := s.apiKey
+	appRateLimit := s.appRateLimit
+	failure := s.failures[path]
+	if failure != nil && failure.Times > 0 {
+		failure.Times--
+		if failure.Times == 0 {
+			delete(s.failures, path)
+		}
+	}
+	body, ok := s.responses[path]
+	s.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json;charset=utf-8")
+	w.Header().Set("X-App-Rate-Limit", appRateLimit)
+
+	switch {
+	case apiKey != "" && r.Header.Get("X-Riot-Token") != apiKey:
+		writeStatus(w, http.StatusForbidden, "Forbidden")
+	case failure != nil:
+		if failure.Status == http.StatusTooManyRequests {
+			w.Header().Set("Retry-After", strconv.Itoa(failure.RetryAfter))
+			w.Header().Set("X-Rate-Limit-Type", "application")
+		}
+		writeStatus(w, failure.Status, http.StatusText(failure.Status))
+	case !ok:
+		writeStatus(w, http.StatusNotFound, "Data not found")
+	default:
+		w.WriteHeader(http.StatusOK)
+		w.Write(body)
+	}
+}
+
+// writeStatus writes an error body in Riot's {"status": {...}} format
+func writeStatus(w http.ResponseWriter, status int, message string) {
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"status": map[string]interface{}{
+			"message":     message,
+			"status_code": status,
+		},
+	})
+}
+
+func mustReadFixture(name string) []byte {
+	raw, err := fixtures.ReadFile("fixtures/" + name)
+	if err != nil {
+		panic(fmt.Sprintf("riottest: missing fixture %s: %v", name, err))
+	}
+	return raw
+}
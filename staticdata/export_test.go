@@ -0,0 +1,13 @@
+package staticdata
+
+import "time"
+
+// ExpireFailures makes every remembered load failure due, as if failureTTL had passed
+func (s *Service) ExpireFailures() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for key, failed := range s.failures {
+		failed.expires = time.Now()
+		s.failures[key] = failed
+	}
+}
@@ -0,0 +1,513 @@
+package staticdata
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultLocale is the Data Dragon locale used when none is requested
+const DefaultLocale = "en_US"
+
+// failureTTL is how long a bundle that failed to load is reported as failed before the disk is read again
+const failureTTL = time.Minute
+
+// Item is a Data Dragon item
+type Item struct {
+	ID          int
+	Name        string
+	Description string // HTML stripped
+	Plaintext   string
+	GoldTotal   int
+	Tags        []string
+	From        []int // Components
+	Into        []int // Items this builds into
+	Depth       int
+}
+
+// Completed reports whether the item is a finished item (built from components and not a component itself)
+func (i Item) Completed() bool {
+	return len(i.From) > 0 && len(i.Into) == 0
+}
+
+// Champion is a Data Dragon champion
+type Champion struct {
+	Key   int    // Numeric champion ID used by the match API
+	ID    string // Data Dragon ID, e.g. "MonkeyKing"
+	Name  string // Display name, e.g. "Wukong"
+	Title string
+	Tags  []string // e.g. Fighter, Mage, Marksman
+}
+
+// RuneStyle is a rune tree (Precision, Domination, ...)
+type RuneStyle struct {
+	ID   int
+	Key  string
+	Name string
+}
+
+// Rune is a single rune or stat shard
+type Rune struct {
+	ID        int
+	Key       string
+	Name      string
+	ShortDesc string // HTML stripped
+	StyleID   int    // 0 for stat shards
+	Slot      int    // 0 = keystone row
+}
+
+// IsKeystone reports whether the rune sits in the keystone row of its tree
+func (r Rune) IsKeystone() bool {
+	return r.StyleID != 0 && r.Slot == 0
+}
+
+// SummonerSpell is a Data Dragon summoner spell
+type SummonerSpell struct {
+	ID          int    // Numeric ID used by the match API (summoner1Id/summoner2Id)
+	Key         string // e.g. "SummonerFlash"
+	Name        string
+	Description string
+	Cooldown    float64 // Base cooldown in seconds
+}
+
+// Bundle holds the static data for one patch and locale
+type Bundle struct {
+	Version    string // Data Dragon version the data was loaded from
+	Locale     string
+	GamePatch  string // major.minor of the requested game version; empty when none was given
+	items      map[int]Item
+	champions  map[int]Champion
+	runeStyles map[int]RuneStyle
+	runes      map[int]Rune
+	spells     map[int]SummonerSpell
+}
+
+// Service loads Data Dragon bundles from a local directory laid out as <basePath>/<version>/data/<locale>/*.json
+// (the layout of the extracted dragontail archive). Bundles are loaded lazily and kept in memory.
+type Service struct {
+	basePath string
+	versions []string // Newest first
+	mu       sync.Mutex
+	bundles  map[string]*Bundle
+	failures map[string]failedLoad
+}
+
+// failedLoad remembers a bundle that could not be loaded, so repeated requests do not hit the disk
+type failedLoad struct {
+	err     error
+	expires time.Time
+}
+
+// NewService scans basePath for Data Dragon versions
+func NewService(basePath string) (*Service, error) {
+	entries, err := os.ReadDir(basePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read static data directory: %w", err)
+	}
+
+	var versions []string
+	for _, entry := range entries {
+		if entry.IsDir() && parseVersion(entry.Name()) != nil {
+			versions = append(versions, entry.Name())
+		}
+	}
+	if len(versions) == 0 {
+		return nil, fmt.Errorf("no Data Dragon versions found in %s", basePath)
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		return compareVersions(versions[i], versions[j]) > 0
+	})
+
+	return &Service{
+		basePath: basePath,
+		versions: versions,
+		bundles:  make(map[string]*Bundle),
+		failures: make(map[string]failedLoad),
+	}, nil
+}
+
+// Versions returns the available Data Dragon versions, newest first
+func (s *Service) Versions() []string {
+	return append([]string(nil), s.versions...)
+}
+
+// Bundle returns the static data for a match's game version (e.g. "14.3.562.1234") in the default locale
+func (s *Service) Bundle(gameVersion string) (*Bundle, error) {
+	return s.BundleForLocale(gameVersion, DefaultLocale)
+}
+
+// BundleForLocale returns the static data for a match's game version in the given locale
+// The patch is matched on major.minor; if it is not available locally the newest version is used instead,
+// and the returned bundle's Version differs from its GamePatch (see MatchesGamePatch)
+func (s *Service) BundleForLocale(gameVersion, locale string) (*Bundle, error) {
+	if locale == "" {
+		locale = DefaultLocale
+	}
+	version := s.resolveVersion(gameVersion)
+	key := version + "|" + locale
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	bundle, ok := s.bundles[key]
+	if !ok {
+		if failed, ok := s.failures[key]; ok && time.Now().Before(failed.expires) {
+			return nil, failed.err
+		}
+		var err error
+		bundle, err = loadBundle(filepath.Join(s.basePath, version, "data", locale), version, locale)
+		if err != nil {
+			s.failures[key] = failedLoad{err: err, expires: time.Now().Add(failureTTL)}
+			return nil, err
+		}
+		delete(s.failures, key)
+		s.bundles[key] = bundle
+	}
+
+	// Bundles are shared between patches that fall back to the same version, so the requested patch goes on a copy
+	resolved := *bundle
+	resolved.GamePatch = gamePatch(gameVersion)
+	return &resolved, nil
+}
+
+// resolveVersion picks the local Data Dragon version matching the game version's major.minor
+func (s *Service) resolveVersion(gameVersion string) string {
+	if patch := gamePatch(gameVersion); patch != "" {
+		for _, v := range s.versions {
+			if strings.HasPrefix(v+".", patch+".") {
+				return v
+			}
+		}
+	}
+	return s.versions[0]
+}
+
+// gamePatch returns the major.minor of a game version like "14.3.562.1234", or "" if it has none
+func gamePatch(gameVersion string) string {
+	parts := strings.Split(gameVersion, ".")
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return ""
+	}
+	return parts[0] + "." + parts[1]
+}
+
+// MatchesGamePatch reports whether the bundle was loaded for the requested game patch rather than as a fallback
+// Bundles requested without a game version (live games) always match
+func (b *Bundle) MatchesGamePatch() bool {
+	return b.GamePatch == "" || strings.HasPrefix(b.Version+".", b.GamePatch+".")
+}
+
+// Item returns the item with the given ID
+func (b *Bundle) Item(id int) (Item, bool) {
+	if b == nil {
+		return Item{}, false
+	}
+	item, ok := b.items[id]
+	return item, ok
+}
+
+// Champion returns the champion with the given numeric ID
+func (b *Bundle) Champion(key int) (Champion, bool) {
+	if b == nil {
+		return Champion{}, false
+	}
+	champion, ok := b.champions[key]
+	return champion, ok
+}
+
+// ChampionByName returns the champion with the given Data Dragon ID (as used in match championName)
+func (b *Bundle) ChampionByName(id string) (Champion, bool) {
+	if b == nil {
+		return Champion{}, false
+	}
+	for _, champion := range b.champions {
+		if strings.EqualFold(champion.ID, id) {
+			return champion, true
+		}
+	}
+	return Champion{}, false
+}
+
+// Rune returns the rune or stat shard with the given perk ID
+func (b *Bundle) Rune(id int) (Rune, bool) {
+	if shard, ok := statShards[id]; ok {
+		return shard, true
+	}
+	if b == nil {
+		return Rune{}, false
+	}
+	r, ok := b.runes[id]
+	return r, ok
+}
+
+// RuneStyle returns the rune tree with the given style ID
+func (b *Bundle) RuneStyle(id int) (RuneStyle, bool) {
+	if b == nil {
+		return RuneStyle{}, false
+	}
+	style, ok := b.runeStyles[id]
+	return style, ok
+}
+
+// SummonerSpell returns the summoner spell with the given numeric ID
+func (b *Bundle) SummonerSpell(id int) (SummonerSpell, bool) {
+	if b == nil {
+		return SummonerSpell{}, false
+	}
+	spell, ok := b.spells[id]
+	return spell, ok
+}
+
+// ItemName returns the item's name, or "Item <id>" if it is unknown
+func (b *Bundle) ItemName(id int) string {
+	if item, ok := b.Item(id); ok {
+		return item.Name
+	}
+	return fmt.Sprintf("Item %d", id)
+}
+
+// ChampionName returns the champion's display name, or "Champion <id>" if it is unknown
+func (b *Bundle) ChampionName(key int) string {
+	if champion, ok := b.Champion(key); ok {
+		return champion.Name
+	}
+	return fmt.Sprintf("Champion %d", key)
+}
+
+// RuneName returns the rune's name, or "Rune <id>" if it is unknown
+func (b *Bundle) RuneName(id int) string {
+	if r, ok := b.Rune(id); ok {
+		return r.Name
+	}
+	return fmt.Sprintf("Rune %d", id)
+}
+
+// RuneStyleName returns the rune tree's name, or "Style <id>" if it is unknown
+func (b *Bundle) RuneStyleName(id int) string {
+	if style, ok := b.RuneStyle(id); ok {
+		return style.Name
+	}
+	return fmt.Sprintf("Style %d", id)
+}
+
+// SummonerSpellName returns the spell's name, or "Summoner Spell <id>" if it is unknown
+func (b *Bundle) SummonerSpellName(id int) string {
+	if spell, ok := b.SummonerSpell(id); ok {
+		return spell.Name
+	}
+	return fmt.Sprintf("Summoner Spell %d", id)
+}
+
+// statShards are not part of runesReforged.json, so their names are kept here
+var statShards = map[int]Rune{
+	5001: {ID: 5001, Key: "HealthScaling", Name: "Health Scaling", ShortDesc: "+10-180 Health (based on level)"},
+	5002: {ID: 5002, Key: "Armor", Name: "Armor", ShortDesc: "+6 Armor"},
+	5003: {ID: 5003, Key: "MagicRes", Name: "Magic Resist", ShortDesc: "+8 Magic Resist"},
+	5005: {ID: 5005, Key: "AttackSpeed", Name: "Attack Speed", ShortDesc: "+10% Attack Speed"},
+	5007: {ID: 5007, Key: "CDRScaling", Name: "Ability Haste", ShortDesc: "+8 Ability Haste"},
+	5008: {ID: 5008, Key: "Adaptive", Name: "Adaptive Force", ShortDesc: "+9 Adaptive Force"},
+	5010: {ID: 5010, Key: "MoveSpeed", Name: "Move Speed", ShortDesc: "+2% Move Speed"},
+	5011: {ID: 5011, Key: "Health", Name: "Health", ShortDesc: "+65 Health"},
+	5013: {ID: 5013, Key: "Tenacity", Name: "Tenacity and Slow Resist", ShortDesc: "+10% Tenacity and Slow Resist"},
+}
+
+// Raw Data Dragon file formats
+
+type itemFile struct {
+	Data map[string]struct {
+		Name        string   `json:"name"`
+		Description string   `json:"description"`
+		Plaintext   string   `json:"plaintext"`
+		Tags        []string `json:"tags"`
+		From        []string `json:"from"`
+		Into        []string `json:"into"`
+		Depth       int      `json:"depth"`
+		Gold        struct {
+			Total int `json:"total"`
+		} `json:"gold"`
+	} `json:"data"`
+}
+
+type championFile struct {
+	Data map[string]struct {
+		ID    string   `json:"id"`
+		Key   string   `json:"key"`
+		Name  string   `json:"name"`
+		Title string   `json:"title"`
+		Tags  []string `json:"tags"`
+	} `json:"data"`
+}
+
+type summonerFile struct {
+	Data map[string]struct {
+		ID          string    `json:"id"`
+		Key         string    `json:"key"`
+		Name        string    `json:"name"`
+		Description string    `json:"description"`
+		Cooldown    []float64 `json:"cooldown"`
+	} `json:"data"`
+}
+
+type runeStyleFile []struct {
+	ID    int    `json:"id"`
+	Key   string `json:"key"`
+	Name  string `json:"name"`
+	Slots []struct {
+		Runes []struct {
+			ID        int    `json:"id"`
+			Key       string `json:"key"`
+			Name      string `json:"name"`
+			ShortDesc string `json:"shortDesc"`
+		} `json:"runes"`
+	} `json:"slots"`
+}
+
+// loadBundle reads item, champion, summoner and runesReforged JSON from dir
+func loadBundle(dir, version, locale string) (*Bundle, error) {
+	bundle := &Bundle{
+		Version:    version,
+		Locale:     locale,
+		items:      make(map[int]Item),
+		champions:  make(map[int]Champion),
+		runeStyles: make(map[int]RuneStyle),
+		runes:      make(map[int]Rune),
+		spells:     make(map[int]SummonerSpell),
+	}
+
+	var items itemFile
+	if err := readJSON(filepath.Join(dir, "item.json"), &items); err != nil {
+		return nil, err
+	}
+	for idStr, raw := range items.Data {
+		id, err := strconv.Atoi(idStr)
+		if err != nil {
+			continue
+		}
+		bundle.items[id] = Item{
+			ID:          id,
+			Name:        raw.Name,
+			Description: stripHTML(raw.Description),
+			Plaintext:   raw.Plaintext,
+			GoldTotal:   raw.Gold.Total,
+			Tags:        raw.Tags,
+			From:        atoiAll(raw.From),
+			Into:        atoiAll(raw.Into),
+			Depth:       raw.Depth,
+		}
+	}
+
+	var champions championFile
+	if err := readJSON(filepath.Join(dir, "champion.json"), &champions); err != nil {
+		return nil, err
+	}
+	for _, raw := range champions.Data {
+		key, err := strconv.Atoi(raw.Key)
+		if err != nil {
+			continue
+		}
+		bundle.champions[key] = Champion{Key: key, ID: raw.ID, Name: raw.Name, Title: raw.Title, Tags: raw.Tags}
+	}
+
+	var spells summonerFile
+	if err := readJSON(filepath.Join(dir, "summoner.json"), &spells); err != nil {
+		return nil, err
+	}
+	for _, raw := range spells.Data {
+		id, err := strconv.Atoi(raw.Key)
+		if err != nil {
+			continue
+		}
+		spell := SummonerSpell{ID: id, Key: raw.ID, Name: raw.Name, Description: stripHTML(raw.Description)}
+		if len(raw.Cooldown) > 0 {
+			spell.Cooldown = raw.Cooldown[0]
+		}
+		bundle.spells[id] = spell
+	}
+
+	var styles runeStyleFile
+	if err := readJSON(filepath.Join(dir, "runesReforged.json"), &styles); err != nil {
+		return nil, err
+	}
+	for _, style := range styles {
+		bundle.runeStyles[style.ID] = RuneStyle{ID: style.ID, Key: style.Key, Name: style.Name}
+		for slot, s := range style.Slots {
+			for _, raw := range s.Runes {
+				bundle.runes[raw.ID] = Rune{
+					ID:        raw.ID,
+					Key:       raw.Key,
+					Name:      raw.Name,
+					ShortDesc: stripHTML(raw.ShortDesc),
+					StyleID:   style.ID,
+					Slot:      slot,
+				}
+			}
+		}
+	}
+
+	return bundle, nil
+}
+
+func readJSON(path string, out interface{}) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read static data file: %w", err)
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", filepath.Base(path), err)
+	}
+	return nil
+}
+
+var htmlTag = regexp.MustCompile(`<[^>]*>`)
+
+// stripHTML removes Data Dragon's markup tags (<br>, <stats>, ...) from descriptions
+func stripHTML(value string) string {
+	value = strings.ReplaceAll(value, "<br>", " ")
+	value = htmlTag.ReplaceAllString(value, "")
+	return strings.Join(strings.Fields(value), " ")
+}
+
+func atoiAll(values []string) []int {
+	result := make([]int, 0, len(values))
+	for _, v := range values {
+		if n, err := strconv.Atoi(v); err == nil {
+			result = append(result, n)
+		}
+	}
+	return result
+}
+
+// parseVersion parses a Data Dragon version like "14.3.1", returning nil if it is not one
+func parseVersion(version string) []int {
+	parts := strings.Split(version, ".")
+	if len(parts) < 2 {
+		return nil
+	}
+	numbers := make([]int, len(parts))
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			return nil
+		}
+		numbers[i] = n
+	}
+	return numbers
+}
+
+// compareVersions returns >0 if a is newer than b, <0 if older and 0 if equal
+func compareVersions(a, b string) int {
+	va, vb := parseVersion(a), parseVersion(b)
+	for i := 0; i < len(va) && i < len(vb); i++ {
+		if va[i] != vb[i] {
+			return va[i] - vb[i]
+		}
+	}
+	return len(va) - len(vb)
+}
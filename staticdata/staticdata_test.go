@@ -0,0 +1,204 @@
+package staticdata_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"lol-ranked-new-meta/staticdata"
+)
+
+// writeDataDragon writes a minimal Data Dragon locale for version under base, naming Aatrox championName
+func writeDataDragon(t *testing.T, base, version, locale, championName string) {
+	t.Helper()
+	dir := filepath.Join(base, version, "data", locale)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		"item.json": `{"data": {
+			"1001": {"name": "Boots", "description": "<mainText>Move speed</mainText>", "into": ["3006"], "gold": {"total": 300}},
+			"3006": {"name": "Berserker's Greaves", "from": ["1001", "1042"], "gold": {"total": 1100}}
+		}}`,
+		"champion.json": fmt.Sprintf(`{"data": {"Aatrox": {"id": "Aatrox", "key": "266", "name": %q, "tags": ["Fighter", "Tank"]}}}`, championName),
+		"summoner.json": `{"data": {"SummonerFlash": {"id": "SummonerFlash", "key": "4", "name": "Flash", "cooldown": [300]}}}`,
+		"runesReforged.json": `[{"id": 8000, "key": "Precision", "name": "Precision", "slots": [
+			{"runes": [{"id": 8008, "key": "LethalTempo", "name": "Lethal Tempo"}]},
+			{"runes": [{"id": 9101, "key": "AbsorbLife", "name": "Absorb Life"}]}
+		]}]`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// newService returns a service over patches 14.2, 14.3 (also in de_DE) and 14.10
+func newService(t *testing.T) (*staticdata.Service, string) {
+	t.Helper()
+	base := t.TempDir()
+	for _, version := range []string{"14.2.1", "14.3.1", "14.10.1"} {
+		writeDataDragon(t, base, version, staticdata.DefaultLocale, "Aatrox")
+	}
+	writeDataDragon(t, base, "14.3.1", "de_DE", "Aatrox (DE)")
+	// Other entries of an extracted dragontail archive are not versions
+	for _, dir := range []string{"img", "lolpatch_14.3"} {
+		if err := os.MkdirAll(filepath.Join(base, dir), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	service, err := staticdata.NewService(base)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	return service, base
+}
+
+func TestNewService(t *testing.T) {
+	service, _ := newService(t)
+	if got, want := service.Versions(), []string{"14.10.1", "14.3.1", "14.2.1"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Versions = %v, want %v (newest first, compared numerically)", got, want)
+	}
+
+	if _, err := staticdata.NewService(t.TempDir()); err == nil {
+		t.Error("NewService on an empty directory succeeded, want an error")
+	}
+	if _, err := staticdata.NewService(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("NewService on a missing directory succeeded, want an error")
+	}
+}
+
+func TestBundleForLocale(t *testing.T) {
+	service, _ := newService(t)
+	tests := []struct {
+		name         string
+		gameVersion  string
+		locale       string
+		version      string
+		gamePatch    string
+		matchesPatch bool
+		champion     string
+	}{
+		{name: "game patch", gameVersion: "14.3.562.1234", locale: "en_US", version: "14.3.1", gamePatch: "14.3", matchesPatch: true, champion: "Aatrox"},
+		{name: "locale", gameVersion: "14.3.562.1234", locale: "de_DE", version: "14.3.1", gamePatch: "14.3", matchesPatch: true, champion: "Aatrox (DE)"},
+		{name: "default locale", gameVersion: "14.2.550.1", locale: "", version: "14.2.1", gamePatch: "14.2", matchesPatch: true, champion: "Aatrox"},
+		// A patch missing locally falls back to the newest version and says so
+		{name: "patch fallback", gameVersion: "14.5.570.1", locale: "en_US", version: "14.10.1", gamePatch: "14.5", matchesPatch: false, champion: "Aatrox"},
+		{name: "14.1 is not 14.10", gameVersion: "14.1.540.1", locale: "en_US", version: "14.10.1", gamePatch: "14.1", matchesPatch: false, champion: "Aatrox"},
+		// Live games have no game version and use the newest patch
+		{name: "no game version", gameVersion: "", locale: "en_US", version: "14.10.1", gamePatch: "", matchesPatch: true, champion: "Aatrox"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bundle, err := service.BundleForLocale(tt.gameVersion, tt.locale)
+			if err != nil {
+				t.Fatalf("BundleForLocale: %v", err)
+			}
+			if bundle.Version != tt.version || bundle.GamePatch != tt.gamePatch || bundle.MatchesGamePatch() != tt.matchesPatch {
+				t.Errorf("bundle = %s for patch %q (matches %v), want %s for %q (matches %v)",
+					bundle.Version, bundle.GamePatch, bundle.MatchesGamePatch(), tt.version, tt.gamePatch, tt.matchesPatch)
+			}
+			if name := bundle.ChampionName(266); name != tt.champion {
+				t.Errorf("ChampionName(266) = %q, want %q", name, tt.champion)
+			}
+		})
+	}
+
+	// Patches falling back to the same version share its data but keep their own GamePatch
+	a, _ := service.Bundle("14.5.570.1")
+	b, _ := service.Bundle("14.6.580.1")
+	if a.GamePatch != "14.5" || b.GamePatch != "14.6" {
+		t.Errorf("GamePatch = %q and %q, want 14.5 and 14.6", a.GamePatch, b.GamePatch)
+	}
+}
+
+func TestBundleForLocaleFailure(t *testing.T) {
+	service, base := newService(t)
+	if _, err := service.BundleForLocale("14.3.562.1234", "fr_FR"); err == nil {
+		t.Fatal("BundleForLocale with a missing locale succeeded, want an error")
+	}
+
+	// The failure is remembered for failureTTL, so a locale extracted meanwhile is not read yet
+	writeDataDragon(t, base, "14.3.1", "fr_FR", "Aatrox (FR)")
+	if _, err := service.BundleForLocale("14.3.562.1234", "fr_FR"); err == nil {
+		t.Error("BundleForLocale read the disk again inside the failure TTL")
+	}
+
+	service.ExpireFailures()
+	bundle, err := service.BundleForLocale("14.3.562.1234", "fr_FR")
+	if err != nil {
+		t.Fatalf("BundleForLocale after the failure TTL: %v", err)
+	}
+	if name := bundle.ChampionName(266); name != "Aatrox (FR)" {
+		t.Errorf("ChampionName(266) = %q, want Aatrox (FR)", name)
+	}
+}
+
+func TestMatchesGamePatch(t *testing.T) {
+	tests := []struct {
+		version   string
+		gamePatch string
+		want      bool
+	}{
+		{"14.3.1", "14.3", true},
+		{"14.3.1", "", true},
+		{"14.10.1", "14.1", false},
+		{"14.10.1", "14.10", true},
+		{"14.4.1", "14.3", false},
+	}
+	for _, tt := range tests {
+		bundle := &staticdata.Bundle{Version: tt.version, GamePatch: tt.gamePatch}
+		if got := bundle.MatchesGamePatch(); got != tt.want {
+			t.Errorf("MatchesGamePatch(%s for %q) = %v, want %v", tt.version, tt.gamePatch, got, tt.want)
+		}
+	}
+}
+
+func TestBundleLookups(t *testing.T) {
+	service, _ := newService(t)
+	bundle, err := service.Bundle("14.3.562.1234")
+	if err != nil {
+		t.Fatalf("Bundle: %v", err)
+	}
+
+	if item, ok := bundle.Item(1001); !ok || item.Description != "Move speed" || item.Completed() {
+		t.Errorf("Item(1001) = %+v, want Boots with HTML stripped, not completed", item)
+	}
+	if item, ok := bundle.Item(3006); !ok || !item.Completed() || !reflect.DeepEqual(item.From, []int{1001, 1042}) {
+		t.Errorf("Item(3006) = %+v, want a completed item built from 1001 and 1042", item)
+	}
+	if champion, ok := bundle.ChampionByName("aatrox"); !ok || champion.Key != 266 {
+		t.Errorf("ChampionByName(aatrox) = %+v, want key 266", champion)
+	}
+	if spell, ok := bundle.SummonerSpell(4); !ok || spell.Name != "Flash" || spell.Cooldown != 300 {
+		t.Errorf("SummonerSpell(4) = %+v, want Flash with a 300 s cooldown", spell)
+	}
+	if r, ok := bundle.Rune(8008); !ok || !r.IsKeystone() || r.StyleID != 8000 {
+		t.Errorf("Rune(8008) = %+v, want the Precision keystone", r)
+	}
+	if r, ok := bundle.Rune(9101); !ok || r.IsKeystone() || r.Slot != 1 {
+		t.Errorf("Rune(9101) = %+v, want a slot 1 rune", r)
+	}
+
+	// Unknown IDs fall back to placeholders; stat shards are known without a bundle
+	var missing *staticdata.Bundle
+	names := []struct{ got, want string }{
+		{bundle.ItemName(9999), "Item 9999"},
+		{bundle.ChampionName(1), "Champion 1"},
+		{bundle.SummonerSpellName(99), "Summoner Spell 99"},
+		{bundle.RuneStyleName(1), "Style 1"},
+		{missing.RuneName(5008), "Adaptive Force"},
+		{missing.RuneName(8008), "Rune 8008"},
+		{missing.RuneStyleName(8000), "Style 8000"},
+		{missing.SummonerSpellName(4), "Summoner Spell 4"},
+	}
+	for _, name := range names {
+		if name.got != name.want {
+			t.Errorf("name = %q, want %q", name.got, name.want)
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package types
+
+// BenchmarkReport rates one participant's stats against players of the same role and tier
+// Computed from the configured benchmark dataset, not by the LLM
+type BenchmarkReport struct {
+	Puuid      string            `json:"puuid"`
+	Position   string            `json:"position"`    // PositionTop .. PositionUtility
+	Tier       string            `json:"tier"`        // IRON .. CHALLENGER, or ALL when the player's rank is unknown
+	TierSource string            `json:"tier_source"` // solo_duo, flex or all_tiers
+	Version    string            `json:"version"`     // Benchmark dataset version
+	Source     string            `json:"source"`      // Where the dataset's games came from
+	Patches    string            `json:"patches"`     // Patch range of the dataset's games
+	QueueID    int               `json:"queue_id"`    // Queue of the dataset's games, and of every rated match
+	Samples    int               `json:"samples"`     // Player-games in the role and tier cohort
+	Ratings    []BenchmarkRating `json:"ratings"`
+}
+
+// BenchmarkRating places one stat within the benchmark distribution
+// Percentile is how many players of the role and tier did worse, so fewer deaths rate higher
+type BenchmarkRating struct {
+	Metric        string  `json:"metric"` // cs_per_minute, vision_per_minute, damage_per_minute, kill_participation, deaths_per_10
+	Label         string  `json:"label"`  // e.g. "CS/min"
+	Value         float64 `json:"value"`
+	Median        float64 `json:"median"`
+	Percentile    int     `json:"percentile"` // 1-99
+	LowerIsBetter bool    `json:"lower_is_better,omitempty"`
+}
@@ -0,0 +1,63 @@
+package types
+
+import "encoding/json"
+
+// RiotChallenges holds the commonly useful metrics from a participant's match-v5 challenges
+// Ratios are 0-1 (e.g. KillParticipation 0.59 = 59%); lane metrics are zero in modes without lanes
+type RiotChallenges struct {
+	KDA                              float64 `json:"kda"`
+	KillParticipation                float64 `json:"killParticipation"`
+	DamagePerMinute                  float64 `json:"damagePerMinute"`
+	GoldPerMinute                    float64 `json:"goldPerMinute"`
+	TeamDamagePercentage             float64 `json:"teamDamagePercentage"`
+	DamageTakenOnTeamPercentage      float64 `json:"damageTakenOnTeamPercentage"`
+	VisionScorePerMinute             float64 `json:"visionScorePerMinute"`
+	EffectiveHealAndShielding        float64 `json:"effectiveHealAndShielding"`
+	BountyGold                       float64 `json:"bountyGold"`
+	LaneMinionsFirst10Minutes        int     `json:"laneMinionsFirst10Minutes"`
+	JungleCsBefore10Minutes          float64 `json:"jungleCsBefore10Minutes"`
+	MaxCsAdvantageOnLaneOpponent     float64 `json:"maxCsAdvantageOnLaneOpponent"`
+	MaxLevelLeadLaneOpponent         int     `json:"maxLevelLeadLaneOpponent"`
+	LaningPhaseGoldExpAdvantage      int     `json:"laningPhaseGoldExpAdvantage"`      // 1 if ahead in gold and XP at 14:00
+	EarlyLaningPhaseGoldExpAdvantage int     `json:"earlyLaningPhaseGoldExpAdvantage"` // 1 if ahead in gold and XP at 7:00
+	VisionScoreAdvantageLaneOpponent float64 `json:"visionScoreAdvantageLaneOpponent"`
+	TurretPlatesTaken                int     `json:"turretPlatesTaken"`
+	TakedownsFirstXMinutes           int     `json:"takedownsFirstXMinutes"` // Takedowns before 10:00
+	SoloKills                        int     `json:"soloKills"`
+	OutnumberedKills                 int     `json:"outnumberedKills"`
+	PickKillWithAlly                 int     `json:"pickKillWithAlly"`
+	KillsNearEnemyTurret             int     `json:"killsNearEnemyTurret"`
+	Multikills                       int     `json:"multikills"`
+	SkillshotsHit                    int     `json:"skillshotsHit"`
+	SkillshotsDodged                 int     `json:"skillshotsDodged"`
+	AbilityUses                      int     `json:"abilityUses"`
+	EnemyChampionImmobilizations     int     `json:"enemyChampionImmobilizations"`
+	SaveAllyFromDeath                int     `json:"saveAllyFromDeath"`
+	SurvivedSingleDigitHpCount       int     `json:"survivedSingleDigitHpCount"`
+	TurretTakedowns                  int     `json:"turretTakedowns"`
+	DragonTakedowns                  int     `json:"dragonTakedowns"`
+	BaronTakedowns                   int     `json:"baronTakedowns"`
+	RiftHeraldTakedowns              int     `json:"riftHeraldTakedowns"`
+	BuffsStolen                      int     `json:"buffsStolen"`
+	EnemyJungleMonsterKills          float64 `json:"enemyJungleMonsterKills"`
+	ControlWardsPlaced               int     `json:"controlWardsPlaced"`
+	StealthWardsPlaced               int     `json:"stealthWardsPlaced"`
+	WardTakedowns                    int     `json:"wardTakedowns"`
+}
+
+// TypedChallenges decodes the participant's challenges into RiotChallenges
+// The raw map is kept on RiotParticipant so dashboards still get every metric Riot sends
+// ok is false when the match has no challenge data (e.g. some older or custom games)
+func (p *RiotParticipant) TypedChallenges() (RiotChallenges, bool) {
+	var challenges RiotChallenges
+	if len(p.Challenges) == 0 {
+		return challenges, false
+	}
+	data, err := json.Marshal(p.Challenges)
+	if err != nil {
+		return challenges, false
+	}
+	// A metric Riot sends with an unexpected number type is skipped; the rest still decode
+	json.Unmarshal(data, &challenges)
+	return challenges, true
+}
@@ -0,0 +1,27 @@
+package types
+
+// Analysis sources reported in MatchResponse.AnalysisSource
+const (
+	AnalysisSourceLLM   = "llm"
+	AnalysisSourceRules = "rules" // The LLM was unavailable; the analysis comes from the rules engine alone
+)
+
+// Severities of a coaching finding
+const (
+	SeverityStrength = "strength"
+	SeverityIssue    = "issue"
+)
+
+// CoachingFinding is the result of one deterministic coaching rule applied to the deep dive target
+type CoachingFinding struct {
+	Rule        string   `json:"rule"`     // deaths, vision, control_wards, cs, objectives
+	Severity    string   `json:"severity"` // SeverityStrength or SeverityIssue
+	Category    string   `json:"category"` // combat, vision, farming, objective - as in SpecificEvent
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+	Impact      string   `json:"impact"`
+	Data        []string `json:"data"`
+	Benchmarked bool     `json:"benchmarked,omitempty"`  // Judged against the benchmark dataset's percentiles rather than fixed thresholds
+	Suggestion  string   `json:"suggestion,omitempty"`   // Issues only: what to change next game
+	CoachingTip string   `json:"coaching_tip,omitempty"` // Issues only: a habit that fixes the issue
+}
@@ -0,0 +1,26 @@
+package types
+
+// Focus area that adds the communication profile to the summary and response
+const FocusCommunication = "communication"
+
+// CommunicationProfile summarizes how much and how a player pinged
+type CommunicationProfile struct {
+	Puuid          string      `json:"puuid"`
+	SummonerName   string      `json:"summoner_name"`
+	ChampionName   string      `json:"champion_name"`
+	TeamID         int         `json:"team_id"`
+	TotalPings     int         `json:"total_pings"`
+	PingsPerMinute float64     `json:"pings_per_minute"`
+	TeamAverage    float64     `json:"team_average"`   // Teammates' average pings per minute, excluding this player
+	Informative    int         `json:"informative"`    // Enemy missing/vision, need vision, vision cleared, danger
+	CallToAction   int         `json:"call_to_action"` // All in, push, on my way, assist me, command
+	Caution        int         `json:"caution"`        // Get back, retreat, hold
+	Mix            []PingCount `json:"mix"`            // Non-zero ping types, most used first
+	Style          string      `json:"style"`          // silent, informative, directive, cautious, balanced or negative
+}
+
+// PingCount is how often a ping type was used
+type PingCount struct {
+	Type  string `json:"type"`
+	Count int    `json:"count"`
+}
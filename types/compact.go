@@ -0,0 +1,48 @@
+package types
+
+// CompactMatch is a token-lean JSON encoding of a match's scoreboard for LLM prompts
+// The match ID, queue, duration and patch stay in the prose header. Participants are a table:
+// Columns names each position in a Participants row and is given to the model in the legend rather than the JSON.
+type CompactMatch struct {
+	Target       int             `json:"tgt,omitempty"` // Participant ID of the deep dive target
+	Columns      []string        `json:"-"`
+	Participants [][]interface{} `json:"p"`
+	Teams        []CompactTeam   `json:"t,omitempty"` // Teams, or duos in Arena
+	Firsts       *CompactFirsts  `json:"f,omitempty"` // Absent for Arena
+}
+
+// CompactTeam holds a team's aggregates and derived rates with short keys
+// Every number is omitted when zero; below standard verbosity only kills and objectives are set
+type CompactTeam struct {
+	TeamID            int     `json:"id"`
+	Win               bool    `json:"w"`
+	Kills             int     `json:"k,omitempty"`
+	Deaths            int     `json:"d,omitempty"`
+	Assists           int     `json:"a,omitempty"`
+	GoldEarned        int     `json:"g,omitempty"`
+	DamageToChampions int     `json:"dmg,omitempty"`
+	VisionScore       int     `json:"vs,omitempty"`
+	CSPerMinute       float64 `json:"cspm,omitempty"`
+	VisionPerMinute   float64 `json:"vspm,omitempty"`
+	DamagePerMinute   float64 `json:"dpm,omitempty"`
+	GoldPerMinute     float64 `json:"gpm,omitempty"`
+	DamagePerGold     float64 `json:"dpg,omitempty"`
+	DeathsPer10       float64 `json:"d10,omitempty"`
+	DamageToBuildings int     `json:"bld,omitempty"`
+	CCTime            int     `json:"cc,omitempty"` // Seconds, summed from totalTimeCCDealt
+	Turrets           int     `json:"tw,omitempty"`
+	Inhibitors        int     `json:"inh,omitempty"`
+	Dragons           int     `json:"dr,omitempty"`
+	Heralds           int     `json:"hr,omitempty"`
+	Barons            int     `json:"bn,omitempty"`
+}
+
+// CompactFirsts holds the team ID that took each first objective; omitted when no team did
+type CompactFirsts struct {
+	Blood     int `json:"fb,omitempty"`
+	Tower     int `json:"ftw,omitempty"`
+	Inhibitor int `json:"finh,omitempty"`
+	Dragon    int `json:"fdr,omitempty"`
+	Herald    int `json:"fhr,omitempty"`
+	Baron     int `json:"fbn,omitempty"`
+}
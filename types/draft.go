@@ -0,0 +1,35 @@
+package types
+
+// Draft describes the bans and picks of a match
+type Draft struct {
+	Teams             []TeamDraft `json:"teams"`
+	TargetPosition    string      `json:"target_position,omitempty"`     // Resolved position of the analyzed player
+	BansTargetingRole []DraftBan  `json:"bans_targeting_role,omitempty"` // Enemy bans of champions whose class belongs to TargetPosition
+	// RoleBansDeterminable is false when BansTargetingRole cannot be filled: there is no static data,
+	// or no champion class is tied to TargetPosition alone (only BOTTOM and UTILITY have one)
+	RoleBansDeterminable bool `json:"role_bans_determinable"`
+}
+
+// TeamDraft is one team's bans (in pick order) and final composition
+type TeamDraft struct {
+	TeamID      int         `json:"team_id"`
+	Bans        []DraftBan  `json:"bans"`
+	Composition []DraftPick `json:"composition"`
+}
+
+// DraftBan is a single ban; empty bans (champion ID -1) are left out
+type DraftBan struct {
+	TeamID       int    `json:"team_id"`
+	PickTurn     int    `json:"pick_turn"`
+	ChampionID   int    `json:"champion_id"`
+	ChampionName string `json:"champion_name"`
+}
+
+// DraftPick is a champion in a team's composition
+type DraftPick struct {
+	SummonerName string   `json:"summoner_name"`
+	ChampionID   int      `json:"champion_id"`
+	ChampionName string   `json:"champion_name"`
+	Position     string   `json:"position,omitempty"`
+	Classes      []string `json:"classes,omitempty"` // Data Dragon tags, e.g. Fighter, Mage
+}
@@ -0,0 +1,44 @@
+package types
+
+// Ranked queue types reported by league-v4
+const (
+	QueueTypeSolo = "RANKED_SOLO_5x5"
+	QueueTypeFlex = "RANKED_FLEX_SR"
+)
+
+// RiotLeagueEntry represents a league-v4 entry: a player's standing in one ranked queue
+type RiotLeagueEntry struct {
+	LeagueID     string `json:"leagueId"`
+	Puuid        string `json:"puuid"`
+	QueueType    string `json:"queueType"` // RANKED_SOLO_5x5, RANKED_FLEX_SR
+	Tier         string `json:"tier"`      // IRON .. CHALLENGER
+	Rank         string `json:"rank"`      // Division: I .. IV
+	LeaguePoints int    `json:"leaguePoints"`
+	Wins         int    `json:"wins"`
+	Losses       int    `json:"losses"`
+	HotStreak    bool   `json:"hotStreak"`
+	Veteran      bool   `json:"veteran"`
+	FreshBlood   bool   `json:"freshBlood"`
+	Inactive     bool   `json:"inactive"`
+}
+
+// RankedStanding is a player's current standing in one ranked queue
+type RankedStanding struct {
+	Tier         string `json:"tier"`
+	Division     string `json:"division,omitempty"` // Empty for Master and above
+	LeaguePoints int    `json:"league_points"`
+	Wins         int    `json:"wins"`
+	Losses       int    `json:"losses"`
+}
+
+// PlayerRank is the ranked context of one participant in a match
+// SoloDuo and Flex are nil when the player is unranked in that queue
+type PlayerRank struct {
+	Puuid        string          `json:"puuid"`
+	SummonerName string          `json:"summoner_name"`
+	ChampionName string          `json:"champion_name"`
+	TeamID       int             `json:"team_id"`
+	SoloDuo      *RankedStanding `json:"solo_duo,omitempty"`
+	Flex         *RankedStanding `json:"flex,omitempty"`
+	Error        string          `json:"error,omitempty"` // Set when the rank could not be fetched
+}
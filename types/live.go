@@ -0,0 +1,82 @@
+package types
+
+// RiotCurrentGameInfo represents a spectator-v5 active game
+type RiotCurrentGameInfo struct {
+	GameID            int64                        `json:"gameId"`
+	GameType          string                       `json:"gameType"`
+	GameStartTime     int64                        `json:"gameStartTime"` // Unix milliseconds; 0 while still in loading screen
+	MapID             int                          `json:"mapId"`
+	GameLength        int64                        `json:"gameLength"` // Seconds since the game started
+	PlatformID        string                       `json:"platformId"`
+	GameMode          string                       `json:"gameMode"`
+	GameQueueConfigID int                          `json:"gameQueueConfigId"`
+	BannedChampions   []RiotBannedChampion         `json:"bannedChampions"`
+	Participants      []RiotCurrentGameParticipant `json:"participants"`
+}
+
+type RiotBannedChampion struct {
+	ChampionID int `json:"championId"`
+	TeamID     int `json:"teamId"`
+	PickTurn   int `json:"pickTurn"`
+}
+
+type RiotCurrentGameParticipant struct {
+	ChampionID    int                  `json:"championId"`
+	Perks         RiotCurrentGamePerks `json:"perks"`
+	ProfileIconID int                  `json:"profileIconId"`
+	Bot           bool                 `json:"bot"`
+	TeamID        int                  `json:"teamId"`
+	Puuid         string               `json:"puuid"`
+	RiotID        string               `json:"riotId"` // gameName#tagLine
+	Spell1ID      int                  `json:"spell1Id"`
+	Spell2ID      int                  `json:"spell2Id"`
+}
+
+type RiotCurrentGamePerks struct {
+	PerkIDs      []int `json:"perkIds"`
+	PerkStyle    int   `json:"perkStyle"`
+	PerkSubStyle int   `json:"perkSubStyle"`
+}
+
+// LiveGameResponse is the pre-game scouting report for a player's active game
+type LiveGameResponse struct {
+	GameID        int64              `json:"game_id,omitempty"`
+	PlatformID    string             `json:"platform_id,omitempty"`
+	QueueID       int                `json:"queue_id,omitempty"`
+	GameMode      string             `json:"game_mode,omitempty"`
+	GameStartTime int64              `json:"game_start_time,omitempty"`
+	GameLength    int64              `json:"game_length,omitempty"`
+	Players       []LivePlayerReport `json:"players"`
+	GamePlan      string             `json:"game_plan,omitempty"` // LLM-written lane-by-lane plan for the requested player's team
+	Error         string             `json:"error,omitempty"`
+}
+
+// LivePlayerReport scouts one participant of an active game
+type LivePlayerReport struct {
+	Puuid          string          `json:"puuid"`
+	RiotID         string          `json:"riot_id"`
+	ChampionID     int             `json:"champion_id"`
+	ChampionName   string          `json:"champion_name"`
+	TeamID         int             `json:"team_id"`
+	Spells         []string        `json:"spells"`
+	LikelyPosition string          `json:"likely_position,omitempty"` // Guessed from smite and recent games; spectator-v5 does not report roles
+	SoloDuo        *RankedStanding `json:"solo_duo,omitempty"`
+	Flex           *RankedStanding `json:"flex,omitempty"`
+	RecentForm     *RecentForm     `json:"recent_form,omitempty"`
+	IsRequested    bool            `json:"is_requested,omitempty"` // The player the report was requested for
+	Error          string          `json:"error,omitempty"`
+}
+
+// RecentForm summarizes a player's most recent matches
+type RecentForm struct {
+	Games         int     `json:"games"`
+	Wins          int     `json:"wins"`
+	Losses        int     `json:"losses"`
+	Kills         float64 `json:"avg_kills"`
+	Deaths        float64 `json:"avg_deaths"`
+	Assists       float64 `json:"avg_assists"`
+	ChampionGames int     `json:"champion_games"` // Recent games on the champion they are playing now
+	MostPlayed    string  `json:"most_played,omitempty"`
+	MainPosition  string  `json:"main_position,omitempty"`
+	CurrentStreak string  `json:"current_streak,omitempty"` // e.g. "3W" or "2L"
+}
@@ -0,0 +1,15 @@
+package types
+
+// RiotChampionMastery represents a champion-mastery-v4 entry: a player's mastery on one champion
+type RiotChampionMastery struct {
+	Puuid                        string `json:"puuid"`
+	ChampionID                   int    `json:"championId"`
+	ChampionLevel                int    `json:"championLevel"`
+	ChampionPoints               int    `json:"championPoints"`
+	LastPlayTime                 int64  `json:"lastPlayTime"` // Unix milliseconds
+	ChampionPointsSinceLastLevel int    `json:"championPointsSinceLastLevel"`
+	ChampionPointsUntilNextLevel int    `json:"championPointsUntilNextLevel"`
+	ChampionSeasonMilestone      int    `json:"championSeasonMilestone"`
+	MarkRequiredForNextLevel     int    `json:"markRequiredForNextLevel"`
+	TokensEarned                 int    `json:"tokensEarned"`
+}
@@ -2,24 +2,37 @@ package types
 
 // MatchRequest represents the incoming request for match analysis
 type MatchRequest struct {
-	MatchID      string   `json:"match_id"`
+	MatchID      string   `json:"match_id"`                // Match ID (EUW1_123), bare game ID or match link
+	Platform     string   `json:"platform,omitempty"`      // Optional: platform for a bare game ID or a link without one (e.g. EUW1)
 	Region       string   `json:"region,omitempty"`        // Optional: overrides default region
 	ChampionName string   `json:"champion_name,omitempty"` // Optional: for deep dive analysis on specific champion
 	SummonerName string   `json:"summoner_name,omitempty"` // Optional: for deep dive analysis on specific summoner
-	FocusAreas   []string `json:"focus_areas,omitempty"`   // Optional: which data aspects to analyze deeply (combat, vision, objectives, items, matchup, economy, farming)
+	FocusAreas   []string `json:"focus_areas,omitempty"`   // Optional: which data aspects to analyze deeply (combat, vision, objectives, items, matchup, economy, farming, communication)
+	Verbosity    string   `json:"verbosity,omitempty"`     // Optional: summary detail sent to the LLM (compact, standard, full); defaults to SUMMARY_VERBOSITY
+	Language     string   `json:"language,omitempty"`      // Optional: analysis language (en, de, pl, es); defaults to English
 }
 
 // MatchResponse represents the response from the match advisor
 type MatchResponse struct {
-	MatchID            string              `json:"match_id"`
-	Analysis           string              `json:"analysis"`
-	Suggestions        []string            `json:"suggestions"`
-	CoachingTips       []string            `json:"coaching_tips"`
-	ChampionDeepDive   string              `json:"champion_deep_dive,omitempty"`  // Optional: deep dive analysis for specific champion
-	StructuredInsights *StructuredInsights `json:"structured_insights,omitempty"` // New: structured data-driven insights
-	DeepDiveTarget     string              `json:"deep_dive_target,omitempty"`
-	DeepDiveMode       string              `json:"deep_dive_mode,omitempty"` // requested, auto, match
-	Error              string              `json:"error,omitempty"`
+	MatchID            string                 `json:"match_id"`
+	Analysis           string                 `json:"analysis"`
+	Suggestions        []string               `json:"suggestions"`
+	CoachingTips       []string               `json:"coaching_tips"`
+	ChampionDeepDive   string                 `json:"champion_deep_dive,omitempty"`  // Optional: deep dive analysis for specific champion
+	StructuredInsights *StructuredInsights    `json:"structured_insights,omitempty"` // New: structured data-driven insights
+	DeepDiveTarget     string                 `json:"deep_dive_target,omitempty"`
+	DeepDiveMode       string                 `json:"deep_dive_mode,omitempty"`  // requested, auto, match
+	LobbyRanks         []PlayerRank           `json:"lobby_ranks,omitempty"`     // Optional: ranked standing of every participant
+	Queue              *QueueInfo             `json:"queue,omitempty"`           // Queue and map the match was played on
+	Metrics            *MatchMetrics          `json:"metrics,omitempty"`         // Derived numbers computed from the match data
+	TeamComparison     *TeamComparison        `json:"team_comparison,omitempty"` // Team totals and differentials; absent for Arena
+	Draft              *Draft                 `json:"draft,omitempty"`           // Bans in pick order and team compositions; absent for Arena
+	Communication      []CommunicationProfile `json:"communication,omitempty"`   // Ping profiles; only with the communication focus area
+	Benchmarks         *BenchmarkReport       `json:"benchmarks,omitempty"`      // Deep dive target rated against their role and tier; absent without lanes
+	AnalysisSource     string                 `json:"analysis_source,omitempty"` // AnalysisSourceLLM, or AnalysisSourceRules when the LLM was unavailable
+	RuleFindings       []CoachingFinding      `json:"rule_findings,omitempty"`   // Deterministic coaching rules applied to the deep dive target
+	Error              string                 `json:"error,omitempty"`
+	SupportedFormats   []string               `json:"supported_formats,omitempty"` // Set when the match reference could not be parsed
 }
 
 // StructuredInsights provides specific, data-driven insights about the match
@@ -28,6 +41,7 @@ type StructuredInsights struct {
 	WhatWentWrong   []SpecificEvent  `json:"what_went_wrong"`
 	CriticalMoments []CriticalMoment `json:"critical_moments"`
 	ItemAnalysis    *ItemAnalysis    `json:"item_analysis,omitempty"`
+	RuneAnalysis    *RuneAnalysis    `json:"rune_analysis,omitempty"`
 	MatchupAnalysis *MatchupAnalysis `json:"matchup_analysis,omitempty"`
 	KeyStatistics   KeyStatistics    `json:"key_statistics"`
 }
@@ -68,11 +82,12 @@ type ItemTiming struct {
 
 // MatchupAnalysis provides champion matchup and team composition analysis
 type MatchupAnalysis struct {
-	LaneMatchup     string   `json:"lane_matchup"`     // How the champion fared vs opponent
-	TeamComposition string   `json:"team_composition"` // Overall team comp analysis
-	Synergies       []string `json:"synergies"`        // Good synergies with teammates
-	Counters        []string `json:"counters"`         // Champion counters in this match
-	WinConditions   []string `json:"win_conditions"`   // What needed to happen to win
+	LaneMatchup     string        `json:"lane_matchup"`            // How the champion fared vs opponent
+	TeamComposition string        `json:"team_composition"`        // Overall team comp analysis
+	Synergies       []string      `json:"synergies"`               // Good synergies with teammates
+	Counters        []string      `json:"counters"`                // Champion counters in this match
+	WinConditions   []string      `json:"win_conditions"`          // What needed to happen to win
+	LaneOpponent    *LaneOpponent `json:"lane_opponent,omitempty"` // Resolved from the match data, not by the LLM
 }
 
 // KeyStatistics highlights important numbers from the match
@@ -81,6 +96,9 @@ type KeyStatistics struct {
 	Objectives []StatPair `json:"objectives"`
 	Economy    []StatPair `json:"economy"`
 	Vision     []StatPair `json:"vision"`
+	Challenges []StatPair `json:"challenges,omitempty"` // Filled from Riot challenge metrics, not by the LLM
+	Benchmarks []StatPair `json:"benchmarks,omitempty"` // Filled from the role and rank benchmark dataset, not by the LLM
+	Spells     []StatPair `json:"spells,omitempty"`     // Filled from summoner spell casts, not by the LLM
 }
 
 // StatPair represents a key statistic
@@ -90,6 +108,40 @@ type StatPair struct {
 	Context string `json:"context,omitempty"` // Additional context or comparison
 }
 
+// PlayerMatchesResponse lists a player's recent matches for the match picker
+type PlayerMatchesResponse struct {
+	Puuid    string               `json:"puuid,omitempty"`
+	GameName string               `json:"game_name,omitempty"`
+	TagLine  string               `json:"tag_line,omitempty"`
+	Matches  []PlayerMatchSummary `json:"matches"`
+	Error    string               `json:"error,omitempty"`
+}
+
+// PlayerMatchSummary is a short, one-line view of a match from the player's perspective
+type PlayerMatchSummary struct {
+	MatchID      string `json:"match_id"`
+	QueueID      int    `json:"queue_id,omitempty"`
+	GameMode     string `json:"game_mode,omitempty"`
+	GameCreation int64  `json:"game_creation,omitempty"` // Unix milliseconds
+	GameDuration int64  `json:"game_duration,omitempty"` // Seconds
+	ChampionID   int    `json:"champion_id,omitempty"`
+	ChampionName string `json:"champion_name,omitempty"`
+	TeamPosition string `json:"team_position,omitempty"`
+	Kills        int    `json:"kills"`
+	Deaths       int    `json:"deaths"`
+	Assists      int    `json:"assists"`
+	CS           int    `json:"cs"`
+	Win          bool   `json:"win"`
+	Error        string `json:"error,omitempty"` // Set when this match could not be loaded
+}
+
+// RiotAccount represents an account-v1 account (Riot ID to PUUID mapping)
+type RiotAccount struct {
+	Puuid    string `json:"puuid"`
+	GameName string `json:"gameName"`
+	TagLine  string `json:"tagLine"`
+}
+
 // RiotMatch represents the structure of match data from Riot API
 // This is a simplified version - you may need to expand based on your needs
 type RiotMatch struct {
@@ -192,6 +244,12 @@ type RiotParticipant struct {
 	PhysicalDamageDealt            int                    `json:"physicalDamageDealt"`
 	PhysicalDamageDealtToChampions int                    `json:"physicalDamageDealtToChampions"`
 	PhysicalDamageTaken            int                    `json:"physicalDamageTaken"`
+	Placement                      int                    `json:"placement"`      // Arena: final placement of the player's duo (1-8)
+	PlayerAugment1                 int                    `json:"playerAugment1"` // Arena augments, in pick order
+	PlayerAugment2                 int                    `json:"playerAugment2"`
+	PlayerAugment3                 int                    `json:"playerAugment3"`
+	PlayerAugment4                 int                    `json:"playerAugment4"`
+	PlayerSubteamID                int                    `json:"playerSubteamId"` // Arena: duo the player fought in (1-8)
 	ProfileIcon                    int                    `json:"profileIcon"`
 	PushPings                      int                    `json:"pushPings"`
 	Puuid                          string                 `json:"puuid"`
@@ -205,6 +263,7 @@ type RiotParticipant struct {
 	Spell2Casts                    int                    `json:"spell2Casts"`
 	Spell3Casts                    int                    `json:"spell3Casts"`
 	Spell4Casts                    int                    `json:"spell4Casts"`
+	SubteamPlacement               int                    `json:"subteamPlacement"` // Arena: placement of the player's subteam
 	Summoner1Casts                 int                    `json:"summoner1Casts"`
 	Summoner1ID                    int                    `json:"summoner1Id"`
 	Summoner2Casts                 int                    `json:"summoner2Casts"`
@@ -0,0 +1,45 @@
+package types
+
+// MatchMetrics holds derived numbers computed from the match data, not by the LLM
+// Shares are 0-1 (e.g. KillParticipation 0.59 = 59%); rates are per minute of game time
+type MatchMetrics struct {
+	GameMinutes  float64              `json:"game_minutes"`
+	Participants []ParticipantMetrics `json:"participants"`
+	Teams        []TeamMetrics        `json:"teams"`
+}
+
+// ParticipantMetrics are one participant's derived numbers
+type ParticipantMetrics struct {
+	Puuid             string  `json:"puuid"`
+	ParticipantID     int     `json:"participant_id"`
+	SummonerName      string  `json:"summoner_name"`
+	ChampionName      string  `json:"champion_name"`
+	TeamID            int     `json:"team_id"` // Arena: the duo (subteam) the player fought in
+	KillParticipation float64 `json:"kill_participation"`
+	DamageShare       float64 `json:"damage_share"` // Share of the team's damage to champions
+	GoldShare         float64 `json:"gold_share"`   // Share of the team's gold earned
+	CSPerMinute       float64 `json:"cs_per_minute"`
+	VisionPerMinute   float64 `json:"vision_per_minute"`
+	DamagePerMinute   float64 `json:"damage_per_minute"`
+	DamagePerGold     float64 `json:"damage_per_gold"`
+	DeathsPer10       float64 `json:"deaths_per_10"`
+}
+
+// TeamMetrics are a team's totals and rates
+type TeamMetrics struct {
+	TeamID          int     `json:"team_id"`
+	Win             bool    `json:"win"`
+	Kills           int     `json:"kills"`
+	Deaths          int     `json:"deaths"`
+	Assists         int     `json:"assists"`
+	GoldEarned      int     `json:"gold_earned"`
+	DamageToChamps  int     `json:"damage_to_champions"`
+	CS              int     `json:"cs"`
+	VisionScore     int     `json:"vision_score"`
+	CSPerMinute     float64 `json:"cs_per_minute"`
+	VisionPerMinute float64 `json:"vision_per_minute"`
+	DamagePerMinute float64 `json:"damage_per_minute"`
+	GoldPerMinute   float64 `json:"gold_per_minute"`
+	DamagePerGold   float64 `json:"damage_per_gold"`
+	DeathsPer10     float64 `json:"deaths_per_10"`
+}
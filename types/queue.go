@@ -0,0 +1,21 @@
+package types
+
+// Game formats that change how a match is analyzed
+const (
+	FormatSummonersRift = "summoners_rift" // 5v5 with lanes, jungle and objectives
+	FormatARAM          = "aram"           // 5v5 on a single lane, random champions
+	FormatArena         = "arena"          // 16 players in eight duos ranked by placement (1st-8th)
+	FormatRotating      = "rotating"       // Other featured modes (URF, One for All, Nexus Blitz, ...)
+)
+
+// QueueInfo describes the queue and map a match was played on
+type QueueInfo struct {
+	QueueID  int    `json:"queue_id"`
+	MapID    int    `json:"map_id"`
+	Name     string `json:"name"`     // e.g. "Ranked Solo/Duo"
+	MapName  string `json:"map_name"` // e.g. "Summoner's Rift"
+	Format   string `json:"format"`   // FormatSummonersRift, FormatARAM, FormatArena or FormatRotating
+	Ranked   bool   `json:"ranked"`
+	HasLanes bool   `json:"has_lanes"` // Whether lane positions and lane opponents are meaningful
+	Custom   bool   `json:"custom,omitempty"`
+}
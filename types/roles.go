@@ -0,0 +1,34 @@
+package types
+
+// Lane positions as reported by match-v5 teamPosition
+const (
+	PositionTop     = "TOP"
+	PositionJungle  = "JUNGLE"
+	PositionMiddle  = "MIDDLE"
+	PositionBottom  = "BOTTOM"
+	PositionUtility = "UTILITY"
+)
+
+// Confidence levels of a resolved role
+const (
+	ConfidenceHigh   = "high"
+	ConfidenceMedium = "medium"
+	ConfidenceLow    = "low"
+)
+
+// RoleAssignment is the position resolved for one participant and the signal it came from
+type RoleAssignment struct {
+	ParticipantID int    `json:"participant_id"`
+	Position      string `json:"position"`   // PositionTop .. PositionUtility; empty when unresolved
+	Source        string `json:"source"`     // team_position, individual_position, lane_role, smite, support_item, cs_pattern, participant_order
+	Confidence    string `json:"confidence"` // ConfidenceHigh, ConfidenceMedium or ConfidenceLow
+}
+
+// LaneOpponent is the resolved lane opponent of the deep dive target
+// Confidence is the lower of the two players' role confidences
+type LaneOpponent struct {
+	SummonerName string `json:"summoner_name"`
+	ChampionName string `json:"champion_name"`
+	Position     string `json:"position"`
+	Confidence   string `json:"confidence"`
+}
@@ -0,0 +1,27 @@
+package types
+
+// RuneAnalysis pairs the analyzed player's rune page with the LLM's evaluation of it
+// Page is filled from the match data, Evaluation and Recommendations by the LLM
+type RuneAnalysis struct {
+	Page            *RunePage `json:"page,omitempty"`
+	Evaluation      string    `json:"evaluation"`
+	Recommendations []string  `json:"recommendations"`
+}
+
+// RunePage is a participant's rune page with names resolved from Data Dragon
+type RunePage struct {
+	PrimaryStyle   string       `json:"primary_style"`
+	SecondaryStyle string       `json:"secondary_style"`
+	Keystone       string       `json:"keystone"`
+	Runes          []RuneChoice `json:"runes"` // Primary tree first, keystone first
+	StatShards     []string     `json:"stat_shards"`
+}
+
+// RuneChoice is one selected rune and the values the game recorded for it
+type RuneChoice struct {
+	ID       int        `json:"id"`
+	Name     string     `json:"name"`
+	Style    string     `json:"style"`
+	Keystone bool       `json:"keystone,omitempty"`
+	Values   []StatPair `json:"values,omitempty"` // e.g. "Total Healing: 1843" for Conqueror
+}
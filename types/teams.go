@@ -0,0 +1,50 @@
+package types
+
+// TeamComparison sets the two teams' totals side by side, computed from the match data
+type TeamComparison struct {
+	Teams        []TeamTotals     `json:"teams"`
+	Differential TeamDifferential `json:"differential"` // Blue side minus red side
+	Firsts       FirstObjectives  `json:"firsts"`
+}
+
+// TeamTotals are one team's totals across its players and objectives
+type TeamTotals struct {
+	TeamID            int  `json:"team_id"`
+	Win               bool `json:"win"`
+	Kills             int  `json:"kills"`
+	GoldEarned        int  `json:"gold_earned"`
+	DamageToChampions int  `json:"damage_to_champions"`
+	DamageToBuildings int  `json:"damage_to_buildings"`
+	VisionScore       int  `json:"vision_score"`
+	CCTime            int  `json:"cc_time"` // Seconds of crowd control dealt (totalTimeCCDealt); timeCCingOthers is a weighted score, not seconds
+	Turrets           int  `json:"turrets"`
+	Inhibitors        int  `json:"inhibitors"`
+	Dragons           int  `json:"dragons"`
+	Heralds           int  `json:"heralds"`
+	Barons            int  `json:"barons"`
+}
+
+// TeamDifferential is the difference between the two teams' totals
+type TeamDifferential struct {
+	Kills             int `json:"kills"`
+	GoldEarned        int `json:"gold_earned"`
+	DamageToChampions int `json:"damage_to_champions"`
+	DamageToBuildings int `json:"damage_to_buildings"`
+	VisionScore       int `json:"vision_score"`
+	CCTime            int `json:"cc_time"`
+	Turrets           int `json:"turrets"`
+	Inhibitors        int `json:"inhibitors"`
+	Dragons           int `json:"dragons"`
+	Heralds           int `json:"heralds"`
+	Barons            int `json:"barons"`
+}
+
+// FirstObjectives records which team took each first; 0 when nobody did
+type FirstObjectives struct {
+	FirstBlood     int `json:"first_blood"`
+	FirstTower     int `json:"first_tower"`
+	FirstInhibitor int `json:"first_inhibitor"`
+	FirstDragon    int `json:"first_dragon"`
+	FirstHerald    int `json:"first_herald"`
+	FirstBaron     int `json:"first_baron"`
+}
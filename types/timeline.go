@@ -0,0 +1,93 @@
+package types
+
+// RiotMatchTimeline represents the match-v5 timeline for a match
+// Frames are sampled every FrameInterval milliseconds and carry the events that happened since the previous frame
+type RiotMatchTimeline struct {
+	Metadata RiotMatchMetadata     `json:"metadata"`
+	Info     RiotMatchTimelineInfo `json:"info"`
+}
+
+type RiotMatchTimelineInfo struct {
+	EndOfGameResult string                    `json:"endOfGameResult"`
+	FrameInterval   int64                     `json:"frameInterval"`
+	GameID          int64                     `json:"gameId"`
+	Participants    []RiotTimelineParticipant `json:"participants"`
+	Frames          []RiotTimelineFrame       `json:"frames"`
+}
+
+type RiotTimelineParticipant struct {
+	ParticipantID int    `json:"participantId"`
+	Puuid         string `json:"puuid"`
+}
+
+type RiotTimelineFrame struct {
+	Events            []RiotTimelineEvent                     `json:"events"`
+	ParticipantFrames map[string]RiotTimelineParticipantFrame `json:"participantFrames"` // Keyed by participant ID ("1".."10")
+	Timestamp         int64                                   `json:"timestamp"`
+}
+
+type RiotTimelineParticipantFrame struct {
+	CurrentGold              int                  `json:"currentGold"`
+	GoldPerSecond            int                  `json:"goldPerSecond"`
+	JungleMinionsKilled      int                  `json:"jungleMinionsKilled"`
+	Level                    int                  `json:"level"`
+	MinionsKilled            int                  `json:"minionsKilled"`
+	ParticipantID            int                  `json:"participantId"`
+	Position                 RiotTimelinePosition `json:"position"`
+	TimeEnemySpentControlled int                  `json:"timeEnemySpentControlled"`
+	TotalGold                int                  `json:"totalGold"`
+	XP                       int                  `json:"xp"`
+}
+
+type RiotTimelinePosition struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+// RiotTimelineEvent is a single timeline event
+// Only the fields relevant to the event Type are populated (e.g. ItemID for ITEM_PURCHASED, KillerID/VictimID for CHAMPION_KILL)
+type RiotTimelineEvent struct {
+	Type                    string               `json:"type"`
+	Timestamp               int64                `json:"timestamp"`
+	RealTimestamp           int64                `json:"realTimestamp,omitempty"`
+	ParticipantID           int                  `json:"participantId,omitempty"`
+	ItemID                  int                  `json:"itemId,omitempty"`
+	BeforeID                int                  `json:"beforeId,omitempty"`
+	AfterID                 int                  `json:"afterId,omitempty"`
+	GoldGain                int                  `json:"goldGain,omitempty"`
+	SkillSlot               int                  `json:"skillSlot,omitempty"`
+	LevelUpType             string               `json:"levelUpType,omitempty"`
+	Level                   int                  `json:"level,omitempty"`
+	KillerID                int                  `json:"killerId,omitempty"`
+	VictimID                int                  `json:"victimId,omitempty"`
+	AssistingParticipantIDs []int                `json:"assistingParticipantIds,omitempty"`
+	Bounty                  int                  `json:"bounty,omitempty"`
+	ShutdownBounty          int                  `json:"shutdownBounty,omitempty"`
+	KillStreakLength        int                  `json:"killStreakLength,omitempty"`
+	MultiKillLength         int                  `json:"multiKillLength,omitempty"`
+	KillType                string               `json:"killType,omitempty"`
+	KillerTeamID            int                  `json:"killerTeamId,omitempty"`
+	TeamID                  int                  `json:"teamId,omitempty"`
+	MonsterType             string               `json:"monsterType,omitempty"`
+	MonsterSubType          string               `json:"monsterSubType,omitempty"`
+	BuildingType            string               `json:"buildingType,omitempty"`
+	TowerType               string               `json:"towerType,omitempty"`
+	LaneType                string               `json:"laneType,omitempty"`
+	WardType                string               `json:"wardType,omitempty"`
+	CreatorID               int                  `json:"creatorId,omitempty"`
+	Position                RiotTimelinePosition `json:"position,omitempty"`
+	VictimDamageReceived    []RiotDamageInstance `json:"victimDamageReceived,omitempty"` // CHAMPION_KILL: damage the victim took shortly before dying
+}
+
+// RiotDamageInstance is one source of damage in a champion kill's damage recap
+type RiotDamageInstance struct {
+	Basic          bool   `json:"basic"`
+	MagicDamage    int    `json:"magicDamage"`
+	Name           string `json:"name"` // Champion name of the source
+	ParticipantID  int    `json:"participantId"`
+	PhysicalDamage int    `json:"physicalDamage"`
+	SpellName      string `json:"spellName"` // Lower case, e.g. "summonerdot" for Ignite
+	SpellSlot      int    `json:"spellSlot"`
+	TrueDamage     int    `json:"trueDamage"`
+	Type           string `json:"type"`
+}